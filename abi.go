@@ -0,0 +1,242 @@
+// Copyright (c) 2019 Perlin
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package wavelet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"github.com/pkg/errors"
+	"github.com/valyala/fastjson"
+	"strconv"
+	"strings"
+)
+
+// ContractABISection is the name of the WebAssembly custom section a smart contract may
+// ship its ABI within. The section holds a JSON document of the form:
+//
+//	{"functions": [{"name": "transfer", "params": [{"name": "recipient", "type": "account_id"}, {"name": "amount", "type": "u64"}]}]}
+const ContractABISection = "wavelet_abi"
+
+// Types of smart contract function parameters which may be declared in an ABI.
+const (
+	ABITypeU64       = "u64"
+	ABITypeBytes     = "bytes"
+	ABITypeString    = "string"
+	ABITypeAccountID = "account_id"
+)
+
+// ContractABI describes the functions exported by a smart contract, alongside the named and
+// typed parameters each function expects.
+type ContractABI struct {
+	Functions []ContractABIFunction
+}
+
+type ContractABIFunction struct {
+	Name   string
+	Params []ContractABIParam
+}
+
+type ContractABIParam struct {
+	Name string
+	Type string
+}
+
+// ReadContractABISection returns the contents of the ABI custom section of a WebAssembly
+// module, or nil should the module not ship an ABI.
+func ReadContractABISection(code []byte) ([]byte, error) {
+	if len(code) < 8 || !bytes.Equal(code[:4], []byte("\x00asm")) {
+		return nil, errors.New("abi: code is not a webassembly module")
+	}
+
+	for buf := code[8:]; len(buf) > 0; {
+		id := buf[0]
+
+		size, n := binary.Uvarint(buf[1:])
+		if n <= 0 || uint64(len(buf)-1-n) < size {
+			return nil, errors.New("abi: malformed webassembly section")
+		}
+
+		section := buf[1+n : 1+n+int(size)]
+		buf = buf[1+n+int(size):]
+
+		if id != 0 {
+			continue
+		}
+
+		nameLen, n := binary.Uvarint(section)
+		if n <= 0 || uint64(len(section)-n) < nameLen {
+			return nil, errors.New("abi: malformed webassembly custom section name")
+		}
+
+		if string(section[n:n+int(nameLen)]) == ContractABISection {
+			return section[n+int(nameLen):], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ParseContractABI parses and validates a JSON-encoded ABI.
+func ParseContractABI(raw []byte) (ContractABI, error) {
+	var abi ContractABI
+
+	v, err := fastjson.ParseBytes(raw)
+	if err != nil {
+		return abi, errors.Wrap(err, "abi: invalid json")
+	}
+
+	if v.Type() != fastjson.TypeObject {
+		return abi, errors.New("abi: must be a json object")
+	}
+
+	functions := make(map[string]struct{})
+
+	for _, fv := range v.GetArray("functions") {
+		fn := ContractABIFunction{Name: string(fv.GetStringBytes("name"))}
+
+		if len(fn.Name) == 0 {
+			return abi, errors.New("abi: function must have a name")
+		}
+
+		if _, exists := functions[fn.Name]; exists {
+			return abi, errors.Errorf("abi: function %q is declared more than once", fn.Name)
+		}
+
+		functions[fn.Name] = struct{}{}
+
+		params := make(map[string]struct{})
+
+		for _, pv := range fv.GetArray("params") {
+			param := ContractABIParam{Name: string(pv.GetStringBytes("name")), Type: string(pv.GetStringBytes("type"))}
+
+			if len(param.Name) == 0 {
+				return abi, errors.Errorf("abi: parameter of function %q must have a name", fn.Name)
+			}
+
+			if _, exists := params[param.Name]; exists {
+				return abi, errors.Errorf("abi: parameter %q of function %q is declared more than once", param.Name, fn.Name)
+			}
+
+			params[param.Name] = struct{}{}
+
+			switch param.Type {
+			case ABITypeU64, ABITypeBytes, ABITypeString, ABITypeAccountID:
+			default:
+				return abi, errors.Errorf("abi: parameter %q of function %q has unknown type %q", param.Name, fn.Name, param.Type)
+			}
+
+			fn.Params = append(fn.Params, param)
+		}
+
+		abi.Functions = append(abi.Functions, fn)
+	}
+
+	return abi, nil
+}
+
+// Function looks up a function declared in the ABI by its name.
+func (a ContractABI) Function(name string) (ContractABIFunction, bool) {
+	for _, fn := range a.Functions {
+		if fn.Name == name {
+			return fn, true
+		}
+	}
+
+	return ContractABIFunction{}, false
+}
+
+// EncodeParams encodes named arguments into a function payload in the order the parameters
+// of the function are declared. Unsigned integers are encoded in decimal, bytes and account
+// IDs in hex, and strings as is. Integers are little-endian encoded, and bytes and strings
+// are length-prefixed with an unsigned little-endian 32-bit integer.
+func (f ContractABIFunction) EncodeParams(args map[string]string) ([]byte, error) {
+	if len(args) != len(f.Params) {
+		return nil, errors.Errorf("abi: function %q expects %d arguments, but got %d", f.Name, len(f.Params), len(args))
+	}
+
+	var buf bytes.Buffer
+	var intBuf [8]byte
+
+	for _, param := range f.Params {
+		arg, exists := args[param.Name]
+		if !exists {
+			return nil, errors.Errorf("abi: missing argument %q for function %q", param.Name, f.Name)
+		}
+
+		switch param.Type {
+		case ABITypeU64:
+			val, err := strconv.ParseUint(arg, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "abi: argument %q must be an unsigned 64-bit integer", param.Name)
+			}
+
+			binary.LittleEndian.PutUint64(intBuf[:8], val)
+			buf.Write(intBuf[:8])
+		case ABITypeBytes, ABITypeString:
+			val := []byte(arg)
+
+			if param.Type == ABITypeBytes {
+				var err error
+
+				if val, err = hex.DecodeString(arg); err != nil {
+					return nil, errors.Wrapf(err, "abi: argument %q must be hex-encoded", param.Name)
+				}
+			}
+
+			binary.LittleEndian.PutUint32(intBuf[:4], uint32(len(val)))
+			buf.Write(intBuf[:4])
+			buf.Write(val)
+		case ABITypeAccountID:
+			val, err := hex.DecodeString(arg)
+			if err != nil {
+				return nil, errors.Wrapf(err, "abi: argument %q must be hex-encoded", param.Name)
+			}
+
+			if len(val) != SizeAccountID {
+				return nil, errors.Errorf("abi: argument %q must be %d bytes long", param.Name, SizeAccountID)
+			}
+
+			buf.Write(val)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ParseABIArgs parses a list of arguments of the form name=value.
+func ParseABIArgs(args []string) (map[string]string, error) {
+	parsed := make(map[string]string, len(args))
+
+	for _, arg := range args {
+		idx := strings.IndexByte(arg, '=')
+		if idx <= 0 {
+			return nil, errors.Errorf("abi: argument %q must be of the form name=value", arg)
+		}
+
+		if _, exists := parsed[arg[:idx]]; exists {
+			return nil, errors.Errorf("abi: argument %q is specified more than once", arg[:idx])
+		}
+
+		parsed[arg[:idx]] = arg[idx+1:]
+	}
+
+	return parsed, nil
+}
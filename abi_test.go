@@ -0,0 +1,178 @@
+// Copyright (c) 2019 Perlin
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package wavelet
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/perlin-network/noise/skademlia"
+	"github.com/perlin-network/wavelet/avl"
+	"github.com/perlin-network/wavelet/store"
+	"github.com/perlin-network/wavelet/sys"
+	"github.com/stretchr/testify/assert"
+)
+
+const testABI = `{"functions":[{"name":"transfer","params":[{"name":"recipient","type":"account_id"},{"name":"amount","type":"u64"},{"name":"memo","type":"string"},{"name":"data","type":"bytes"}]}]}`
+
+func TestReadContractABISection(t *testing.T) {
+	code, err := ioutil.ReadFile("testdata/transfer_back.wasm")
+	assert.NoError(t, err)
+
+	abi, err := ReadContractABISection(code)
+	assert.NoError(t, err)
+	assert.Nil(t, abi)
+
+	abi, err = ReadContractABISection(withCustomSection(code, ContractABISection, []byte(testABI)))
+	assert.NoError(t, err)
+	assert.Equal(t, testABI, string(abi))
+
+	// Custom sections with other names are skipped over.
+	abi, err = ReadContractABISection(withCustomSection(code, "name", []byte("ignored")))
+	assert.NoError(t, err)
+	assert.Nil(t, abi)
+
+	_, err = ReadContractABISection([]byte("not wasm"))
+	assert.Error(t, err)
+
+	_, err = ReadContractABISection(append(code, 0, 0xff))
+	assert.Error(t, err)
+}
+
+func TestParseContractABI(t *testing.T) {
+	abi, err := ParseContractABI([]byte(testABI))
+	assert.NoError(t, err)
+
+	fn, exists := abi.Function("transfer")
+	assert.True(t, exists)
+	assert.Len(t, fn.Params, 4)
+
+	_, exists = abi.Function("missing")
+	assert.False(t, exists)
+
+	for _, invalid := range []string{
+		`not json`,
+		`[]`,
+		`{"functions":[{"params":[]}]}`,
+		`{"functions":[{"name":"a"},{"name":"a"}]}`,
+		`{"functions":[{"name":"a","params":[{"type":"u64"}]}]}`,
+		`{"functions":[{"name":"a","params":[{"name":"x","type":"u64"},{"name":"x","type":"u64"}]}]}`,
+		`{"functions":[{"name":"a","params":[{"name":"x","type":"f64"}]}]}`,
+	} {
+		_, err := ParseContractABI([]byte(invalid))
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestContractABIEncodeParams(t *testing.T) {
+	abi, err := ParseContractABI([]byte(testABI))
+	assert.NoError(t, err)
+
+	fn, _ := abi.Function("transfer")
+
+	recipient := strings.Repeat("ab", SizeAccountID)
+
+	args, err := ParseABIArgs([]string{"amount=10", "recipient=" + recipient, "memo=hi", "data=0102"})
+	assert.NoError(t, err)
+
+	params, err := fn.EncodeParams(args)
+	assert.NoError(t, err)
+
+	expected, _ := hex.DecodeString(recipient)
+	expected = append(expected, 10, 0, 0, 0, 0, 0, 0, 0)
+	expected = append(expected, 2, 0, 0, 0, 'h', 'i')
+	expected = append(expected, 2, 0, 0, 0, 1, 2)
+
+	assert.Equal(t, expected, params)
+
+	for _, invalid := range [][]string{
+		{"amount=10", "recipient=" + recipient, "memo=hi"},
+		{"amount=10", "recipient=" + recipient, "memo=hi", "other=1"},
+		{"amount=-1", "recipient=" + recipient, "memo=hi", "data=0102"},
+		{"amount=10", "recipient=abcd", "memo=hi", "data=0102"},
+		{"amount=10", "recipient=" + recipient, "memo=hi", "data=zz"},
+	} {
+		args, err := ParseABIArgs(invalid)
+		assert.NoError(t, err)
+
+		_, err = fn.EncodeParams(args)
+		assert.Error(t, err, invalid)
+	}
+
+	_, err = ParseABIArgs([]string{"amount"})
+	assert.Error(t, err)
+
+	_, err = ParseABIArgs([]string{"amount=1", "amount=2"})
+	assert.Error(t, err)
+}
+
+func TestApplyContractTransactionABI(t *testing.T) {
+	t.Parallel()
+
+	state := avl.New(store.NewInmem())
+	round := NewRound(0, state.Checksum(), 0, Transaction{}, Transaction{})
+
+	keys, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	WriteAccountBalance(state, keys.PublicKey(), 1000000000)
+
+	code, err := ioutil.ReadFile("testdata/transfer_back.wasm")
+	assert.NoError(t, err)
+
+	// Case 1 - Smart contracts shipping an invalid ABI may not be spawned
+	tx := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagContract, buildContractSpawnPayload(100000, 0, withCustomSection(code, ContractABISection, []byte("{"))).Marshal()))
+	assert.Error(t, ApplyTransaction(&round, state, &tx))
+
+	// Case 2 - The ABI is stored alongside the code of the smart contract
+	tx = AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagContract, buildContractSpawnPayload(100000, 0, withCustomSection(code, ContractABISection, []byte(testABI))).Marshal()))
+	assert.NoError(t, ApplyTransaction(&round, state, &tx))
+
+	contractID := AccountID(tx.ID)
+
+	abi, exists := ReadAccountContractABI(state, contractID)
+	assert.True(t, exists)
+	assert.Equal(t, testABI, string(abi))
+
+	// Case 3 - Upgrading to code without an ABI drops the ABI
+	tx = AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagContractLifecycle, (ContractLifecycle{Opcode: sys.UpgradeContract, Contract: contractID, Code: code}).Marshal()))
+	assert.NoError(t, ApplyTransaction(&round, state, &tx))
+
+	_, exists = ReadAccountContractABI(state, contractID)
+	assert.False(t, exists)
+}
+
+// withCustomSection appends a custom section to a WebAssembly module.
+func withCustomSection(code []byte, name string, payload []byte) []byte {
+	var nameLen, sectionLen [binary.MaxVarintLen64]byte
+
+	section := append([]byte{}, nameLen[:binary.PutUvarint(nameLen[:], uint64(len(name)))]...)
+	section = append(section, name...)
+	section = append(section, payload...)
+
+	out := append([]byte{}, code...)
+	out = append(out, 0)
+	out = append(out, sectionLen[:binary.PutUvarint(sectionLen[:], uint64(len(section)))]...)
+
+	return append(out, section...)
+}
@@ -37,6 +37,12 @@ type Accounts struct {
 	tree *avl.Tree
 
 	profile *avl.GCProfile
+
+	// Whether or not the old roots of the accounts tree are to be kept rather than garbage
+	// collected, and the number of old roots to keep. All old roots are kept forever should
+	// archival be enabled and the window be zero. Otherwise, only the latest old root is kept.
+	archive       bool
+	archiveWindow uint64
 }
 
 func NewAccounts(kv store.KV) *Accounts {
@@ -71,7 +77,45 @@ func (a *Accounts) Snapshot() *avl.Tree {
 	return snapshot
 }
 
+// SnapshotAt returns a snapshot of all accounts as they were when the Merkle root of the
+// accounts tree was root.
+func (a *Accounts) SnapshotAt(root MerkleNodeID) (*avl.Tree, error) {
+	a.RLock()
+	snapshot, err := a.tree.SnapshotAt(root)
+	a.RUnlock()
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "accounts: failed to load tree at merkle root %x", root)
+	}
+
+	return snapshot, nil
+}
+
+// NextOldRootIndex returns the index at which the current Merkle root of the accounts tree is
+// kept amongst its old roots once the accounts tree is next committed.
+func (a *Accounts) NextOldRootIndex() uint64 {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.tree.NextOldRootIndex()
+}
+
+// OldRoot returns the Merkle root of the accounts tree kept at index idx amongst its old roots.
+// It returns false should the old root have been garbage collected.
+func (a *Accounts) OldRoot(idx uint64) (MerkleNodeID, bool) {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.tree.OldRoot(idx)
+}
+
 func (a *Accounts) Commit(new *avl.Tree) error {
+	return a.CommitWithBatch(new, a.kv.NewWriteBatch())
+}
+
+// CommitWithBatch commits the accounts tree, or new should it not be nil, atomically alongside
+// all writes staged in batch.
+func (a *Accounts) CommitWithBatch(new *avl.Tree, batch store.WriteBatch) error {
 	a.Lock()
 	defer a.Unlock()
 
@@ -79,12 +123,16 @@ func (a *Accounts) Commit(new *avl.Tree) error {
 		a.tree = new
 	}
 
-	err := a.tree.Commit()
+	err := a.tree.CommitWithBatch(batch)
 	if err != nil {
 		return errors.Wrap(err, "accounts: failed to write")
 	}
 
-	profile := a.tree.GetGCProfile(0)
+	if a.archive && a.archiveWindow == 0 {
+		return nil
+	}
+
+	profile := a.tree.GetGCProfile(a.archiveWindow)
 	if profile != nil {
 		atomic.StorePointer((*unsafe.Pointer)(unsafe.Pointer(&a.profile)), unsafe.Pointer(profile))
 	}
@@ -49,3 +49,56 @@ func TestSmartContract(t *testing.T) {
 
 	assert.NoError(t, quick.Check(fn, nil))
 }
+
+func TestAccountsArchive(t *testing.T) {
+	accounts := NewAccounts(store.NewInmem())
+	accounts.archive = true
+
+	var roots []MerkleNodeID
+
+	for i := uint64(1); i <= 3; i++ {
+		snapshot := accounts.Snapshot()
+		WriteAccountBalance(snapshot, AccountID{1}, i)
+
+		assert.NoError(t, accounts.Commit(snapshot))
+		roots = append(roots, snapshot.Checksum())
+	}
+
+	// Archives that keep all past trees never garbage collect.
+	assert.Nil(t, accounts.profile)
+
+	for i, root := range roots {
+		snapshot, err := accounts.SnapshotAt(root)
+		assert.NoError(t, err)
+
+		balance, _ := ReadAccountBalance(snapshot, AccountID{1})
+		assert.Equal(t, uint64(i+1), balance)
+	}
+
+	_, err := accounts.SnapshotAt(MerkleNodeID{1})
+	assert.Error(t, err)
+
+	// Archives that keep a window of past trees preserve the window when garbage collecting.
+	accounts.archiveWindow = 1
+
+	snapshot := accounts.Snapshot()
+	WriteAccountBalance(snapshot, AccountID{1}, 4)
+	assert.NoError(t, accounts.Commit(snapshot))
+
+	if assert.NotNil(t, accounts.profile) {
+		_, err = accounts.profile.PerformFullGC()
+		assert.NoError(t, err)
+	}
+
+	tree := NewAccounts(accounts.kv).tree
+
+	for i, root := range roots {
+		_, err := tree.SnapshotAt(root)
+
+		if i == 0 {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
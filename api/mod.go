@@ -28,6 +28,7 @@ import (
 	"github.com/buaazp/fasthttprouter"
 	"github.com/perlin-network/noise/skademlia"
 	"github.com/perlin-network/wavelet"
+	"github.com/perlin-network/wavelet/avl"
 	"github.com/perlin-network/wavelet/debounce"
 	"github.com/perlin-network/wavelet/log"
 	"github.com/perlin-network/wavelet/sys"
@@ -94,6 +95,7 @@ func (g *Gateway) setup() {
 		),
 	)
 	sinkMetrics := g.registerWebsocketSink("ws://metrics/", nil)
+	sinkEvents := g.registerWebsocketSink("ws://events/?contract=contract_id&topic=topic", nil)
 
 	log.SetWriter(log.LoggerWebsocket, g)
 
@@ -114,6 +116,7 @@ func (g *Gateway) setup() {
 	r.GET("/poll/contract", g.applyMiddleware(g.poll(sinkContracts), "/poll/contract"))
 	r.GET("/poll/tx", g.applyMiddleware(g.poll(sinkTransactions), "/poll/tx"))
 	r.GET("/poll/metrics", g.applyMiddleware(g.poll(sinkMetrics), "/poll/metrics"))
+	r.GET("/poll/events", g.applyMiddleware(g.poll(sinkEvents), "/poll/events"))
 
 	// Debug endpoint.
 	r.GET("/debug/*p", g.applyMiddleware(pprofhandler.PprofHandler, "/debug/*p"))
@@ -123,14 +126,27 @@ func (g *Gateway) setup() {
 
 	// Account endpoints.
 	r.GET("/accounts/:id", g.applyMiddleware(g.getAccount, ""))
+	r.GET("/accounts/:id/proof", g.applyMiddleware(g.getAccountProof, ""))
+	r.GET("/accounts/:id/delegations", g.applyMiddleware(g.getDelegations, ""))
+	r.GET("/accounts/:id/delegators", g.applyMiddleware(g.getDelegators, ""))
 
 	// Contract endpoints.
 	r.GET("/contract/:id/page/:index", g.applyMiddleware(g.getContractPages, "/contract/:id/page/:index", g.contractScope))
 	r.GET("/contract/:id/page", g.applyMiddleware(g.getContractPages, "/contract/:id/page", g.contractScope))
+	r.GET("/contract/:id/abi", g.applyMiddleware(g.getContractABI, "/contract/:id/abi", g.contractScope))
 	r.GET("/contract/:id", g.applyMiddleware(g.getContractCode, "/contract/:id", g.contractScope))
+	r.POST("/contract/:id/query", g.applyMiddleware(g.queryContract, "/contract/:id/query", g.contractScope))
+
+	// Event endpoints.
+	r.GET("/events", g.applyMiddleware(g.listEvents, "/events"))
+
+	// Vote endpoints.
+	r.GET("/votes", g.applyMiddleware(g.listVotes, "/votes"))
 
 	// Transaction endpoints.
 	r.POST("/tx/send", g.applyMiddleware(g.sendTransaction, ""))
+	r.POST("/tx/simulate", g.applyMiddleware(g.simulateTransaction, "/tx/simulate"))
+	r.GET("/tx/:id/receipt", g.applyMiddleware(g.getReceipt, ""))
 	r.GET("/tx/:id", g.applyMiddleware(g.getTransaction, ""))
 	r.GET("/tx", g.applyMiddleware(g.listTransactions, "/tx"))
 
@@ -220,7 +236,7 @@ func (g *Gateway) sendTransaction(ctx *fasthttp.RequestCtx) {
 
 	tx := wavelet.AttachSenderToTransaction(
 		g.keys,
-		wavelet.Transaction{Tag: sys.Tag(req.Tag), Payload: req.payload, Creator: req.creator, CreatorSignature: req.signature},
+		wavelet.Transaction{Nonce: req.Nonce, Tip: req.Tip, Tag: sys.Tag(req.Tag), Payload: req.payload, Creator: req.creator, CreatorSignature: req.signature},
 		g.ledger.Graph().FindEligibleParents()...,
 	)
 
@@ -234,6 +250,32 @@ func (g *Gateway) sendTransaction(ctx *fasthttp.RequestCtx) {
 	g.render(ctx, &sendTransactionResponse{ledger: g.ledger, tx: &tx})
 }
 
+func (g *Gateway) simulateTransaction(ctx *fasthttp.RequestCtx) {
+	req := new(sendTransactionRequest)
+
+	parser := g.parserPool.Get()
+	err := req.bind(parser, ctx.PostBody())
+	g.parserPool.Put(parser)
+
+	if err != nil {
+		g.renderError(ctx, ErrBadRequest(err))
+		return
+	}
+
+	tx := wavelet.AttachSenderToTransaction(
+		g.keys,
+		wavelet.Transaction{Nonce: req.Nonce, Tip: req.Tip, Tag: sys.Tag(req.Tag), Payload: req.payload, Creator: req.creator, CreatorSignature: req.signature},
+		g.ledger.Graph().FindEligibleParents()...,
+	)
+
+	// Apply the transaction against a throwaway snapshot, such that none of
+	// the changes made by the transaction are ever committed.
+
+	res := wavelet.SimulateTransaction(g.ledger.Graph(), g.ledger.Snapshot(), g.ledger.Rounds().Latest(), &tx)
+
+	g.render(ctx, &simulateTransactionResponse{tx: &tx, result: &res})
+}
+
 func (g *Gateway) ledgerStatus(ctx *fasthttp.RequestCtx) {
 	g.render(ctx, &ledgerStatusResponse{client: g.client, ledger: g.ledger, publicKey: g.keys.PublicKey()})
 }
@@ -314,26 +356,102 @@ func (g *Gateway) listTransactions(ctx *fasthttp.RequestCtx) {
 	g.render(ctx, transactions)
 }
 
-func (g *Gateway) getTransaction(ctx *fasthttp.RequestCtx) {
-	param, ok := ctx.UserValue("id").(string)
-	if !ok {
-		g.renderError(ctx, ErrBadRequest(errors.New("id must be a string")))
-		return
+func (g *Gateway) listEvents(ctx *fasthttp.RequestCtx) {
+	var contract *wavelet.AccountID
+	var topic *string
+	var from, to uint64
+	var err error
+
+	latest := g.ledger.Rounds().Latest().Index
+	from, to = latest, latest
+
+	queryArgs := ctx.QueryArgs()
+	if raw := string(queryArgs.Peek("contract")); len(raw) > 0 {
+		slice, err := hex.DecodeString(raw)
+		if err != nil {
+			g.renderError(ctx, ErrBadRequest(errors.Wrap(err, "contract ID must be presented as valid hex")))
+			return
+		}
+
+		if len(slice) != wavelet.SizeAccountID {
+			g.renderError(ctx, ErrBadRequest(errors.Errorf("contract ID must be %d bytes long", wavelet.SizeAccountID)))
+			return
+		}
+
+		contract = new(wavelet.AccountID)
+		copy(contract[:], slice)
 	}
 
-	slice, err := hex.DecodeString(param)
-	if err != nil {
-		g.renderError(ctx, ErrBadRequest(errors.Wrap(err, "transaction ID must be presented as valid hex")))
+	if queryArgs.Has("topic") {
+		raw := string(queryArgs.Peek("topic"))
+		topic = &raw
+	}
+
+	if raw := string(queryArgs.Peek("to")); len(raw) > 0 {
+		to, err = strconv.ParseUint(raw, 10, 64)
+
+		if err != nil {
+			g.renderError(ctx, ErrBadRequest(errors.Wrap(err, "could not parse to")))
+			return
+		}
+
+		from = to
+	}
+
+	if raw := string(queryArgs.Peek("from")); len(raw) > 0 {
+		from, err = strconv.ParseUint(raw, 10, 64)
+
+		if err != nil {
+			g.renderError(ctx, ErrBadRequest(errors.Wrap(err, "could not parse from")))
+			return
+		}
+	}
+
+	if from > to {
+		g.renderError(ctx, ErrBadRequest(errors.Errorf("from round %d must not be greater than to round %d", from, to)))
 		return
 	}
 
-	if len(slice) != wavelet.SizeTransactionID {
-		g.renderError(ctx, ErrBadRequest(errors.Errorf("transaction ID must be %d bytes long", wavelet.SizeTransactionID)))
+	if to-from >= maxEventsRoundRange {
+		g.renderError(ctx, ErrBadRequest(errors.Errorf("may only query events within at most %d rounds at a time", maxEventsRoundRange)))
 		return
 	}
 
-	var id wavelet.TransactionID
-	copy(id[:], slice)
+	if to > latest {
+		to = latest
+	}
+
+	var events eventList
+
+	for round := from; round <= to; round++ {
+		roundEvents, err := g.ledger.RoundEvents(round)
+		if err != nil {
+			g.renderError(ctx, ErrInternal(errors.Wrapf(err, "failed to load events of round %d", round)))
+			return
+		}
+
+		for i := range roundEvents {
+			if contract != nil && roundEvents[i].Contract != *contract {
+				continue
+			}
+
+			if topic != nil && roundEvents[i].Topic != *topic {
+				continue
+			}
+
+			events = append(events, &roundEvents[i])
+		}
+	}
+
+	g.render(ctx, events)
+}
+
+func (g *Gateway) getTransaction(ctx *fasthttp.RequestCtx) {
+	id, err := transactionIDParam(ctx)
+	if err != nil {
+		g.renderError(ctx, ErrBadRequest(err))
+		return
+	}
 
 	tx := g.ledger.Graph().FindTransaction(id)
 
@@ -355,28 +473,220 @@ func (g *Gateway) getTransaction(ctx *fasthttp.RequestCtx) {
 	g.render(ctx, res)
 }
 
-func (g *Gateway) getAccount(ctx *fasthttp.RequestCtx) {
-	param, ok := ctx.UserValue("id").(string)
-	if !ok {
-		g.renderError(ctx, ErrBadRequest(errors.New("id must be a string")))
+// listVotes lists the signed votes cast by validators while the round specified by the optional
+// `round` query parameter was being finalized, or the latest round should it be omitted.
+func (g *Gateway) listVotes(ctx *fasthttp.RequestCtx) {
+	round, e := g.roundParam(ctx)
+	if e != nil {
+		g.renderError(ctx, e)
 		return
 	}
 
-	slice, err := hex.DecodeString(param)
+	votes, err := g.ledger.RoundVotes(round)
 	if err != nil {
-		g.renderError(ctx, ErrBadRequest(errors.Wrap(err, "account ID must be presented as valid hex")))
+		g.renderError(ctx, ErrInternal(errors.Wrapf(err, "failed to load votes of round %d", round)))
 		return
 	}
 
-	if len(slice) != wavelet.SizeAccountID {
-		g.renderError(ctx, ErrBadRequest(errors.Errorf("account ID must be %d bytes long", wavelet.SizeAccountID)))
+	g.render(ctx, voteList(votes))
+}
+
+func (g *Gateway) getReceipt(ctx *fasthttp.RequestCtx) {
+	id, err := transactionIDParam(ctx)
+	if err != nil {
+		g.renderError(ctx, ErrBadRequest(err))
+		return
+	}
+
+	r, err := g.ledger.Receipt(id)
+	if err != nil {
+		g.renderError(ctx, ErrInternal(err))
+		return
+	}
+
+	if r == nil {
+		g.renderError(ctx, ErrNotFound(errors.Errorf("could not find receipt for transaction with ID %x", id)))
 		return
 	}
 
+	g.render(ctx, &receipt{receipt: r})
+}
+
+// transactionIDParam parses the hex-encoded transaction ID in the path of a request.
+func transactionIDParam(ctx *fasthttp.RequestCtx) (wavelet.TransactionID, error) {
+	var id wavelet.TransactionID
+
+	param, ok := ctx.UserValue("id").(string)
+	if !ok {
+		return id, errors.New("id must be a string")
+	}
+
+	slice, err := hex.DecodeString(param)
+	if err != nil {
+		return id, errors.Wrap(err, "transaction ID must be presented as valid hex")
+	}
+
+	if len(slice) != wavelet.SizeTransactionID {
+		return id, errors.Errorf("transaction ID must be %d bytes long", wavelet.SizeTransactionID)
+	}
+
+	copy(id[:], slice)
+
+	return id, nil
+}
+
+func accountIDParam(ctx *fasthttp.RequestCtx) (wavelet.AccountID, error) {
 	var id wavelet.AccountID
+
+	param, ok := ctx.UserValue("id").(string)
+	if !ok {
+		return id, errors.New("id must be a string")
+	}
+
+	slice, err := hex.DecodeString(param)
+	if err != nil {
+		return id, errors.Wrap(err, "account ID must be presented as valid hex")
+	}
+
+	if len(slice) != wavelet.SizeAccountID {
+		return id, errors.Errorf("account ID must be %d bytes long", wavelet.SizeAccountID)
+	}
+
 	copy(id[:], slice)
 
-	g.render(ctx, &account{ledger: g.ledger, id: id})
+	return id, nil
+}
+
+func (g *Gateway) getAccount(ctx *fasthttp.RequestCtx) {
+	id, err := accountIDParam(ctx)
+	if err != nil {
+		g.renderError(ctx, ErrBadRequest(err))
+		return
+	}
+
+	var snapshot *avl.Tree
+
+	// Light nodes do not keep the state of accounts, and instead fetch it from their peers.
+
+	if g.ledger.Light() {
+		round, e := g.roundParam(ctx)
+		if e != nil {
+			g.renderError(ctx, e)
+			return
+		}
+
+		if snapshot, err = g.ledger.FetchAccount(round, id); err != nil {
+			g.renderError(ctx, ErrInternal(errors.Wrap(err, "failed to fetch account from peers")))
+			return
+		}
+	} else {
+		_, s, e := g.snapshotAt(ctx)
+		if e != nil {
+			g.renderError(ctx, e)
+			return
+		}
+
+		snapshot = s
+	}
+
+	g.render(ctx, &account{snapshot: snapshot, id: id})
+}
+
+func (g *Gateway) getAccountProof(ctx *fasthttp.RequestCtx) {
+	id, err := accountIDParam(ctx)
+	if err != nil {
+		g.renderError(ctx, ErrBadRequest(err))
+		return
+	}
+
+	round, snapshot, e := g.snapshotAt(ctx)
+	if e != nil {
+		g.renderError(ctx, e)
+		return
+	}
+
+	key := wavelet.AccountBalanceKey(id)
+
+	value, exists := snapshot.Lookup(key)
+	if !exists {
+		g.renderError(ctx, ErrNotFound(errors.Errorf("account with ID %x has no balance at round %d", id, round)))
+		return
+	}
+
+	proof, err := snapshot.Prove(key)
+	if err != nil {
+		g.renderError(ctx, ErrInternal(errors.Wrap(err, "failed to prove account balance")))
+		return
+	}
+
+	g.render(ctx, &accountProof{round: round, root: snapshot.Checksum(), key: key, value: value, proof: proof})
+}
+
+// getDelegations lists all delegations of stake made by an account to validators.
+func (g *Gateway) getDelegations(ctx *fasthttp.RequestCtx) {
+	id, err := accountIDParam(ctx)
+	if err != nil {
+		g.renderError(ctx, ErrBadRequest(err))
+		return
+	}
+
+	_, snapshot, e := g.snapshotAt(ctx)
+	if e != nil {
+		g.renderError(ctx, e)
+		return
+	}
+
+	g.render(ctx, delegationList(wavelet.ReadDelegatorDelegations(snapshot, id)))
+}
+
+// getDelegators lists all delegations of stake made to an account acting as a validator.
+func (g *Gateway) getDelegators(ctx *fasthttp.RequestCtx) {
+	id, err := accountIDParam(ctx)
+	if err != nil {
+		g.renderError(ctx, ErrBadRequest(err))
+		return
+	}
+
+	_, snapshot, e := g.snapshotAt(ctx)
+	if e != nil {
+		g.renderError(ctx, e)
+		return
+	}
+
+	g.render(ctx, delegationList(wavelet.ReadValidatorDelegations(snapshot, id)))
+}
+
+// snapshotAt returns a snapshot of the ledger state at the end of the round specified by the
+// optional `round` query parameter of a request, or at the latest round should it be omitted.
+// The index of the round the snapshot was taken at is returned alongside it.
+func (g *Gateway) snapshotAt(ctx *fasthttp.RequestCtx) (uint64, *avl.Tree, *errResponse) {
+	round, e := g.roundParam(ctx)
+	if e != nil {
+		return 0, nil, e
+	}
+
+	snapshot, err := g.ledger.SnapshotAt(round)
+	if err != nil {
+		return 0, nil, ErrNotFound(err)
+	}
+
+	return round, snapshot, nil
+}
+
+// roundParam returns the round index specified by the optional `round` query parameter of a
+// request, or the index of the latest round should it be omitted.
+func (g *Gateway) roundParam(ctx *fasthttp.RequestCtx) (uint64, *errResponse) {
+	raw := string(ctx.QueryArgs().Peek("round"))
+	if len(raw) == 0 {
+		return g.ledger.Rounds().Latest().Index, nil
+	}
+
+	round, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, ErrBadRequest(errors.Wrap(err, "could not parse round"))
+	}
+
+	return round, nil
 }
 
 func (g *Gateway) contractScope(next fasthttp.RequestHandler) fasthttp.RequestHandler {
@@ -428,6 +738,77 @@ func (g *Gateway) getContractCode(ctx *fasthttp.RequestCtx) {
 	_, _ = io.Copy(ctx, bytes.NewReader(code))
 }
 
+func (g *Gateway) getContractABI(ctx *fasthttp.RequestCtx) {
+	id, ok := ctx.UserValue("contract_id").(wavelet.TransactionID)
+	if !ok {
+		g.renderError(ctx, ErrBadRequest(errors.New("id must be a TransactionID")))
+		return
+	}
+
+	snapshot := g.ledger.Snapshot()
+
+	if _, available := wavelet.ReadAccountContractCode(snapshot, id); !available {
+		g.renderError(ctx, ErrNotFound(errors.Errorf("could not find contract with ID %x", id)))
+		return
+	}
+
+	abi, available := wavelet.ReadAccountContractABI(snapshot, id)
+	if !available {
+		g.renderError(ctx, ErrNotFound(errors.Errorf("contract with ID %x does not have an ABI", id)))
+		return
+	}
+
+	ctx.Response.Header.Set("Content-Type", "application/json")
+	ctx.Response.Header.Set("Content-Length", strconv.Itoa(len(abi)))
+
+	_, _ = io.Copy(ctx, bytes.NewReader(abi))
+}
+
+func (g *Gateway) queryContract(ctx *fasthttp.RequestCtx) {
+	id, ok := ctx.UserValue("contract_id").(wavelet.TransactionID)
+	if !ok {
+		g.renderError(ctx, ErrBadRequest(errors.New("id must be a TransactionID")))
+		return
+	}
+
+	req := new(queryContractRequest)
+
+	parser := g.parserPool.Get()
+	err := req.bind(parser, ctx.PostBody())
+	g.parserPool.Put(parser)
+
+	if err != nil {
+		g.renderError(ctx, ErrBadRequest(err))
+		return
+	}
+
+	// Execute the smart contract against a throwaway snapshot, such that none of
+	// the changes made by the smart contract are ever committed.
+
+	snapshot := g.ledger.Snapshot()
+
+	code, available := wavelet.ReadAccountContractCode(snapshot, id)
+
+	if len(code) == 0 || !available {
+		g.renderError(ctx, ErrNotFound(errors.Errorf("could not find contract with ID %x", id)))
+		return
+	}
+
+	tx := &wavelet.Transaction{Sender: req.sender, Creator: req.sender}
+	executor := &wavelet.ContractExecutor{}
+
+	if req.Trace {
+		executor.Trace = new(wavelet.ContractTrace)
+	}
+
+	if err := executor.Execute(snapshot, id, g.ledger.Rounds().Latest(), tx, req.Amount, req.GasLimit, req.FuncName, req.params, code); err != nil {
+		g.renderError(ctx, ErrBadRequest(errors.Wrap(err, "failed to query smart contract")))
+		return
+	}
+
+	g.render(ctx, &queryContractResponse{executor: executor})
+}
+
 func (g *Gateway) getContractPages(ctx *fasthttp.RequestCtx) {
 	id, ok := ctx.UserValue("contract_id").(wavelet.TransactionID)
 	if !ok {
@@ -453,7 +834,11 @@ func (g *Gateway) getContractPages(ctx *fasthttp.RequestCtx) {
 		}
 	}
 
-	snapshot := g.ledger.Snapshot()
+	_, snapshot, e := g.snapshotAt(ctx)
+	if e != nil {
+		g.renderError(ctx, e)
+		return
+	}
 
 	numPages, available := wavelet.ReadAccountContractNumPages(snapshot, id)
 
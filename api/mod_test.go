@@ -29,6 +29,7 @@ import (
 	"github.com/buaazp/fasthttprouter"
 	"github.com/perlin-network/noise/skademlia"
 	"github.com/perlin-network/wavelet"
+	"github.com/perlin-network/wavelet/avl"
 	"github.com/perlin-network/wavelet/store"
 	"github.com/perlin-network/wavelet/sys"
 	"github.com/pkg/errors"
@@ -58,7 +59,7 @@ func TestListTransaction(t *testing.T) {
 	var buf [200]byte
 	_, err = rand.Read(buf[:])
 	assert.NoError(t, err)
-	_ = wavelet.NewTransaction(keys, sys.TagTransfer, buf[:])
+	_ = wavelet.NewTransaction(keys, 0, sys.TagTransfer, buf[:])
 	assert.NoError(t, err)
 
 	// Build an expected response
@@ -179,7 +180,7 @@ func TestGetTransaction(t *testing.T) {
 	var buf [200]byte
 	_, err = rand.Read(buf[:])
 	assert.NoError(t, err)
-	_ = wavelet.NewTransaction(keys, sys.TagTransfer, buf[:])
+	_ = wavelet.NewTransaction(keys, 0, sys.TagTransfer, buf[:])
 	assert.NoError(t, err)
 
 	var txId wavelet.TransactionID
@@ -276,6 +277,58 @@ func TestSendTransaction(t *testing.T) {
 	}
 }
 
+func TestSimulateTransaction(t *testing.T) {
+	gateway := New()
+	gateway.setup()
+
+	gateway.ledger = createLedger(t)
+
+	keys, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+	gateway.keys = keys
+
+	// test malformed request
+	request := httptest.NewRequest("POST", "http://localhost/tx/simulate", bytes.NewBufferString(`{}`))
+
+	w, err := serve(gateway.router, request)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, w.StatusCode, "status code")
+
+	// test transfer from an account that cannot afford its fee
+	creator, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	var recipient wavelet.AccountID
+	payload := wavelet.Transfer{Recipient: recipient, Amount: 1}.Marshal()
+	tx := wavelet.NewTransaction(creator, 0, sys.TagTransfer, payload)
+
+	reqBody, err := json.Marshal(sendTransactionRequest{
+		Sender:    hex.EncodeToString(tx.Creator[:]),
+		Tag:       byte(tx.Tag),
+		Payload:   hex.EncodeToString(tx.Payload),
+		Signature: hex.EncodeToString(tx.CreatorSignature[:]),
+	})
+	assert.NoError(t, err)
+
+	request = httptest.NewRequest("POST", "http://localhost/tx/simulate", bytes.NewReader(reqBody))
+
+	w, err = serve(gateway.router, request)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, w.StatusCode, "status code")
+
+	response, err := ioutil.ReadAll(w.Body)
+	assert.NoError(t, err)
+
+	v, err := fastjson.ParseBytes(response)
+	assert.NoError(t, err)
+
+	assert.False(t, v.GetBool("applied"))
+	assert.Contains(t, string(v.GetStringBytes("error")), "does not have enough PERLs to pay transaction fees")
+	assert.Equal(t, tx.Fee(), v.GetUint64("fee"))
+	assert.Equal(t, uint64(0), v.GetUint64("gas_used"))
+	assert.Len(t, v.GetArray("balance_deltas"), 0)
+}
+
 func TestSendTransactionRandom(t *testing.T) {
 	gateway := New()
 	gateway.setup()
@@ -401,7 +454,23 @@ func TestGetAccount(t *testing.T) {
 			name:         "valid id",
 			url:          "/accounts/" + idHex,
 			wantCode:     http.StatusOK,
-			wantResponse: &account{ledger: gateway.ledger, id: id},
+			wantResponse: &account{snapshot: gateway.ledger.Snapshot(), id: id},
+		},
+		{
+			name:     "round not a number",
+			url:      "/accounts/" + idHex + "?round=abc",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:         "latest round",
+			url:          "/accounts/" + idHex + "?round=0",
+			wantCode:     http.StatusOK,
+			wantResponse: &account{snapshot: gateway.ledger.Snapshot(), id: id},
+		},
+		{
+			name:     "round not yet finalized",
+			url:      "/accounts/" + idHex + "?round=1",
+			wantCode: http.StatusNotFound,
 		},
 	}
 
@@ -497,6 +566,602 @@ func TestGetContractCode(t *testing.T) {
 	}
 }
 
+func TestGetAccountProof(t *testing.T) {
+	gateway := New()
+	gateway.setup()
+
+	id := wavelet.AccountID{1}
+
+	// Commit the account into the state the ledger starts off from.
+
+	kv := store.NewInmem()
+
+	s := avl.New(kv)
+	wavelet.WriteAccountBalance(s, id, 100)
+	assert.NoError(t, s.Commit())
+
+	keys, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	gateway.ledger = wavelet.NewLedger(kv, skademlia.NewClient(":0", keys), nil)
+
+	tests := []struct {
+		name     string
+		url      string
+		wantCode int
+	}{
+		{
+			name:     "id not hex",
+			url:      "/accounts/-----/proof",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "account without balance",
+			url:      "/accounts/" + hex.EncodeToString(bytes.Repeat([]byte{2}, wavelet.SizeAccountID)) + "/proof",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "round not yet finalized",
+			url:      "/accounts/" + hex.EncodeToString(id[:]) + "/proof?round=1",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "valid proof",
+			url:      "/accounts/" + hex.EncodeToString(id[:]) + "/proof?round=0",
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "http://localhost"+tc.url, nil)
+
+			w, err := serve(gateway.router, request)
+			assert.NoError(t, err)
+			assert.NotNil(t, w)
+
+			response, err := ioutil.ReadAll(w.Body)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.wantCode, w.StatusCode, "status code")
+
+			if tc.wantCode != http.StatusOK {
+				return
+			}
+
+			v, err := fastjson.ParseBytes(response)
+			assert.NoError(t, err)
+
+			assert.Equal(t, uint64(100), v.GetUint64("balance"))
+
+			// The proof must verify against the merkle root committed to by the round.
+
+			merkle := gateway.ledger.Rounds().Latest().Merkle
+			assert.Equal(t, hex.EncodeToString(merkle[:]), string(v.GetStringBytes("merkle_root")))
+
+			key, err := hex.DecodeString(string(v.GetStringBytes("key")))
+			assert.NoError(t, err)
+
+			value, err := hex.DecodeString(string(v.GetStringBytes("value")))
+			assert.NoError(t, err)
+
+			buf, err := hex.DecodeString(string(v.GetStringBytes("proof")))
+			assert.NoError(t, err)
+
+			proof, err := avl.UnmarshalProof(bytes.NewReader(buf))
+			assert.NoError(t, err)
+
+			assert.True(t, avl.VerifyProof(merkle, key, value, proof))
+		})
+	}
+}
+
+func TestGetDelegations(t *testing.T) {
+	gateway := New()
+	gateway.setup()
+
+	validator := wavelet.AccountID{1}
+	delegatorA := wavelet.AccountID{2}
+	delegatorB := wavelet.AccountID{3}
+
+	// Commit the delegations into the state the ledger starts off from.
+
+	kv := store.NewInmem()
+
+	s := avl.New(kv)
+	wavelet.WriteAccountDelegation(s, delegatorA, validator, 100)
+	wavelet.WriteAccountDelegation(s, delegatorB, validator, 200)
+	wavelet.WriteAccountDelegation(s, delegatorB, delegatorA, 300)
+	assert.NoError(t, s.Commit())
+
+	keys, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	gateway.ledger = wavelet.NewLedger(kv, skademlia.NewClient(":0", keys), nil)
+
+	hexID := func(id wavelet.AccountID) string {
+		return hex.EncodeToString(id[:])
+	}
+
+	tests := []struct {
+		name     string
+		url      string
+		wantCode int
+		want     []string
+	}{
+		{
+			name:     "id not hex",
+			url:      "/accounts/-----/delegations",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "round not yet finalized",
+			url:      "/accounts/" + hexID(validator) + "/delegators?round=1",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "delegations to validator",
+			url:      "/accounts/" + hexID(validator) + "/delegators",
+			wantCode: http.StatusOK,
+			want:     []string{hexID(delegatorA) + ":" + hexID(validator) + ":100", hexID(delegatorB) + ":" + hexID(validator) + ":200"},
+		},
+		{
+			name:     "delegations by delegator",
+			url:      "/accounts/" + hexID(delegatorB) + "/delegations",
+			wantCode: http.StatusOK,
+			want:     []string{hexID(delegatorB) + ":" + hexID(validator) + ":200", hexID(delegatorB) + ":" + hexID(delegatorA) + ":300"},
+		},
+		{
+			name:     "no delegations",
+			url:      "/accounts/" + hexID(validator) + "/delegations",
+			wantCode: http.StatusOK,
+			want:     nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "http://localhost"+tc.url, nil)
+
+			w, err := serve(gateway.router, request)
+			assert.NoError(t, err)
+			assert.NotNil(t, w)
+
+			response, err := ioutil.ReadAll(w.Body)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.wantCode, w.StatusCode, "status code")
+
+			if tc.wantCode != http.StatusOK {
+				return
+			}
+
+			v, err := fastjson.ParseBytes(response)
+			assert.NoError(t, err)
+
+			var got []string
+
+			for _, item := range v.GetArray() {
+				got = append(got, fmt.Sprintf("%s:%s:%d", item.GetStringBytes("delegator"), item.GetStringBytes("validator"), item.GetUint64("amount")))
+			}
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestGetContractABI(t *testing.T) {
+	gateway := New()
+	gateway.setup()
+
+	withABI := wavelet.AccountID{1}
+	withoutABI := wavelet.AccountID{2}
+
+	abi := `{"functions":[{"name":"transfer","params":[{"name":"amount","type":"u64"}]}]}`
+
+	// Commit the smart contracts into the state the ledger starts off from.
+
+	kv := store.NewInmem()
+
+	s := avl.New(kv)
+	wavelet.WriteAccountContractCode(s, withABI, []byte("contract code"))
+	wavelet.WriteAccountContractABI(s, withABI, []byte(abi))
+	wavelet.WriteAccountContractCode(s, withoutABI, []byte("contract code"))
+	assert.NoError(t, s.Commit())
+
+	keys, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	gateway.ledger = wavelet.NewLedger(kv, skademlia.NewClient(":0", keys), nil)
+
+	tests := []struct {
+		name     string
+		id       wavelet.AccountID
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "contract not exist",
+			id:       wavelet.AccountID{3},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "contract without abi",
+			id:       withoutABI,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "contract with abi",
+			id:       withABI,
+			wantCode: http.StatusOK,
+			wantBody: abi,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "http://localhost/contract/"+hex.EncodeToString(tc.id[:])+"/abi", nil)
+
+			w, err := serve(gateway.router, request)
+			assert.NoError(t, err)
+			assert.NotNil(t, w)
+
+			response, err := ioutil.ReadAll(w.Body)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.wantCode, w.StatusCode, "status code")
+
+			if tc.wantBody != "" {
+				assert.Equal(t, tc.wantBody, string(response))
+			}
+		})
+	}
+}
+
+func TestQueryContract(t *testing.T) {
+	gateway := New()
+	gateway.setup()
+
+	gateway.ledger = createLedger(t)
+
+	tests := []struct {
+		name      string
+		url       string
+		body      string
+		wantCode  int
+		wantError marshalableJSON
+	}{
+		{
+			name:     "invalid id length",
+			url:      "/contract/1c331c1d/query",
+			body:     `{"func_name":"balance"}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "missing func name",
+			url:      "/contract/" + "3132333435363738393031323334353637383930313233343536373839303132" + "/query",
+			body:     `{}`,
+			wantCode: http.StatusBadRequest,
+			wantError: testErrResponse{
+				StatusText: "Bad request.",
+				ErrorText:  "missing func_name",
+			},
+		},
+		{
+			name:     "id not exist",
+			url:      "/contract/" + "3132333435363738393031323334353637383930313233343536373839303132" + "/query",
+			body:     `{"func_name":"balance"}`,
+			wantCode: http.StatusNotFound,
+			wantError: testErrResponse{
+				StatusText: "Bad request.",
+				ErrorText:  fmt.Sprintf("could not find contract with ID %s", "3132333435363738393031323334353637383930313233343536373839303132"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "http://localhost"+tc.url, bytes.NewBufferString(tc.body))
+
+			w, err := serve(gateway.router, request)
+			assert.NoError(t, err)
+			assert.NotNil(t, w)
+
+			response, err := ioutil.ReadAll(w.Body)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.wantCode, w.StatusCode, "status code")
+
+			if tc.wantError != nil {
+				r, err := tc.wantError.marshalJSON(new(fastjson.ArenaPool).Get())
+				assert.Nil(t, err)
+				assert.Equal(t, string(r), string(bytes.TrimSpace(response)))
+			}
+		})
+	}
+}
+
+func TestListEvents(t *testing.T) {
+	gateway := New()
+	gateway.setup()
+
+	keys, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	kv := store.NewInmem()
+	gateway.ledger = wavelet.NewLedger(kv, skademlia.NewClient(":0", keys), nil)
+
+	events := []wavelet.Event{
+		{Contract: wavelet.AccountID{1}, TxID: wavelet.TransactionID{2}, Topic: "transfer", Data: []byte("hello")},
+		{Contract: wavelet.AccountID{3}, TxID: wavelet.TransactionID{4}, Topic: "mint", Data: []byte("world")},
+	}
+	assert.NoError(t, wavelet.StoreRoundEvents(kv, 0, events))
+
+	contract := hex.EncodeToString(events[0].Contract[:])
+
+	tests := []struct {
+		name       string
+		url        string
+		wantCode   int
+		wantTopics []string
+	}{
+		{
+			name:     "invalid contract id",
+			url:      "/events?contract=1c331c1d",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "from greater than to",
+			url:      "/events?from=2&to=1",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "range too large",
+			url:      "/events?from=0&to=" + strconv.FormatUint(maxEventsRoundRange, 10),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:       "all events",
+			url:        "/events",
+			wantCode:   http.StatusOK,
+			wantTopics: []string{"transfer", "mint"},
+		},
+		{
+			name:       "by contract",
+			url:        "/events?contract=" + contract,
+			wantCode:   http.StatusOK,
+			wantTopics: []string{"transfer"},
+		},
+		{
+			name:       "by topic",
+			url:        "/events?topic=mint",
+			wantCode:   http.StatusOK,
+			wantTopics: []string{"mint"},
+		},
+		{
+			name:     "rounds without events",
+			url:      "/events?from=1&to=10",
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "http://localhost"+tc.url, nil)
+
+			w, err := serve(gateway.router, request)
+			assert.NoError(t, err)
+			assert.NotNil(t, w)
+
+			response, err := ioutil.ReadAll(w.Body)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.wantCode, w.StatusCode, "status code")
+
+			if tc.wantCode != http.StatusOK {
+				return
+			}
+
+			v, err := fastjson.ParseBytes(response)
+			assert.NoError(t, err)
+
+			var topics []string
+			for _, event := range v.GetArray() {
+				topics = append(topics, string(event.GetStringBytes("topic")))
+			}
+
+			assert.Equal(t, tc.wantTopics, topics)
+		})
+	}
+}
+
+func TestListVotes(t *testing.T) {
+	gateway := New()
+	gateway.setup()
+
+	keys, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	kv := store.NewInmem()
+	gateway.ledger = wavelet.NewLedger(kv, skademlia.NewClient(":0", keys), nil)
+
+	votes := []wavelet.Vote{
+		{Voter: wavelet.AccountID{1}, RoundIndex: 0, RoundID: wavelet.RoundID{2}, Finalized: true, Signature: wavelet.Signature{3}},
+		{Voter: wavelet.AccountID{4}, RoundIndex: 0, RoundID: wavelet.RoundID{2}, Signature: wavelet.Signature{5}},
+	}
+
+	batch := kv.NewWriteBatch()
+	wavelet.StoreRoundVotesWithBatch(batch, 0, votes)
+	assert.NoError(t, kv.CommitWriteBatch(batch))
+
+	tests := []struct {
+		name          string
+		url           string
+		wantCode      int
+		wantVoters    []string
+		wantFinalized []bool
+	}{
+		{
+			name:     "invalid round",
+			url:      "/votes?round=abc",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:          "latest round",
+			url:           "/votes",
+			wantCode:      http.StatusOK,
+			wantVoters:    []string{hex.EncodeToString(votes[0].Voter[:]), hex.EncodeToString(votes[1].Voter[:])},
+			wantFinalized: []bool{true, false},
+		},
+		{
+			name:     "round without votes",
+			url:      "/votes?round=1",
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "http://localhost"+tc.url, nil)
+
+			w, err := serve(gateway.router, request)
+			assert.NoError(t, err)
+			assert.NotNil(t, w)
+
+			response, err := ioutil.ReadAll(w.Body)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.wantCode, w.StatusCode, "status code")
+
+			if tc.wantCode != http.StatusOK {
+				return
+			}
+
+			v, err := fastjson.ParseBytes(response)
+			assert.NoError(t, err)
+
+			var voters []string
+			var finalized []bool
+
+			for _, vote := range v.GetArray() {
+				voters = append(voters, string(vote.GetStringBytes("voter")))
+				finalized = append(finalized, vote.GetBool("finalized"))
+			}
+
+			assert.Equal(t, tc.wantVoters, voters)
+			assert.Equal(t, tc.wantFinalized, finalized)
+		})
+	}
+}
+
+func TestGetReceipt(t *testing.T) {
+	gateway := New()
+	gateway.setup()
+
+	keys, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	kv := store.NewInmem()
+	gateway.ledger = wavelet.NewLedger(kv, skademlia.NewClient(":0", keys), nil)
+
+	receipts := []wavelet.Receipt{
+		{
+			TxID:   wavelet.TransactionID{1},
+			Round:  3,
+			Status: wavelet.ReceiptStatusApplied,
+			Fee:    2,
+			Gas:    100,
+			Events: []wavelet.Event{
+				{Contract: wavelet.AccountID{2}, TxID: wavelet.TransactionID{1}, Round: 3, Topic: "transfer", Data: []byte("hello")},
+			},
+		},
+		{
+			TxID:   wavelet.TransactionID{3},
+			Round:  3,
+			Status: wavelet.ReceiptStatusRejected,
+			Error:  "insufficient balance",
+		},
+	}
+	assert.NoError(t, wavelet.StoreReceipts(kv, receipts))
+
+	tests := []struct {
+		name       string
+		id         wavelet.TransactionID
+		url        string
+		wantCode   int
+		wantStatus string
+		wantError  string
+		wantGas    uint64
+		wantTopics []string
+	}{
+		{
+			name:     "invalid id",
+			url:      "/tx/1c331c1d/receipt",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "missing receipt",
+			id:       wavelet.TransactionID{4},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:       "applied",
+			id:         receipts[0].TxID,
+			wantCode:   http.StatusOK,
+			wantStatus: "applied",
+			wantGas:    100,
+			wantTopics: []string{"transfer"},
+		},
+		{
+			name:       "rejected",
+			id:         receipts[1].TxID,
+			wantCode:   http.StatusOK,
+			wantStatus: "rejected",
+			wantError:  "insufficient balance",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			url := tc.url
+			if url == "" {
+				url = "/tx/" + hex.EncodeToString(tc.id[:]) + "/receipt"
+			}
+
+			request := httptest.NewRequest("GET", "http://localhost"+url, nil)
+
+			w, err := serve(gateway.router, request)
+			assert.NoError(t, err)
+			assert.NotNil(t, w)
+
+			response, err := ioutil.ReadAll(w.Body)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.wantCode, w.StatusCode, "status code")
+
+			if tc.wantCode != http.StatusOK {
+				return
+			}
+
+			v, err := fastjson.ParseBytes(response)
+			assert.NoError(t, err)
+
+			assert.Equal(t, hex.EncodeToString(tc.id[:]), string(v.GetStringBytes("tx_id")))
+			assert.Equal(t, uint64(3), v.GetUint64("round"))
+			assert.Equal(t, tc.wantStatus, string(v.GetStringBytes("status")))
+			assert.Equal(t, tc.wantError, string(v.GetStringBytes("error")))
+			assert.Equal(t, tc.wantGas, v.GetUint64("gas_used"))
+
+			var topics []string
+			for _, event := range v.GetArray("events") {
+				topics = append(topics, string(event.GetStringBytes("topic")))
+			}
+
+			assert.Equal(t, tc.wantTopics, topics)
+		})
+	}
+}
+
 func TestGetContractPages(t *testing.T) {
 	gateway := New()
 	gateway.setup()
@@ -593,7 +1258,7 @@ func TestGetLedger(t *testing.T) {
 	publicKey := keys.PublicKey()
 
 	expectedJSON := fmt.Sprintf(
-		`{"public_key":"%s","address":"127.0.0.1:%d","num_accounts":3,"round":{"merkle_root":"613f573f0ed5d8b60d9659a3fc04ada1","start_id":"0000000000000000000000000000000000000000000000000000000000000000","end_id":"403517ca121f7638349cc92d654d20ac0f63d1958c897bc0cbcc2cdfe8bc74cc","applied":0,"depth":0,"difficulty":8},"peers":null}`,
+		`{"public_key":"%s","address":"127.0.0.1:%d","num_accounts":3,"round":{"merkle_root":"613f573f0ed5d8b60d9659a3fc04ada1","start_id":"0000000000000000000000000000000000000000000000000000000000000000","end_id":"0f2dfeb03485c703d0c8584a40d135192ecb150247e9377595ed718d84b08a85","applied":0,"depth":0,"difficulty":8},"peers":null}`,
 		hex.EncodeToString(publicKey[:]),
 		listener.Addr().(*net.TCPAddr).Port,
 	)
@@ -662,11 +1327,21 @@ func TestEndpointsRateLimit(t *testing.T) {
 			method:        "GET",
 			isRateLimited: true,
 		},
+		{
+			url:           "/poll/events",
+			method:        "GET",
+			isRateLimited: true,
+		},
 		{
 			url:           "/ledger",
 			method:        "GET",
 			isRateLimited: true,
 		},
+		{
+			url:           "/events",
+			method:        "GET",
+			isRateLimited: true,
+		},
 		{
 			url:           "/accounts/1",
 			method:        "GET",
@@ -682,21 +1357,56 @@ func TestEndpointsRateLimit(t *testing.T) {
 			method:        "GET",
 			isRateLimited: true,
 		},
+		{
+			url:           "/contract/1/abi",
+			method:        "GET",
+			isRateLimited: true,
+		},
 		{
 			url:           "/contract/1",
 			method:        "GET",
 			isRateLimited: true,
 		},
+		{
+			url:           "/contract/1/query",
+			method:        "POST",
+			isRateLimited: true,
+		},
 		{
 			url:           "/tx/send",
 			method:        "POST",
 			isRateLimited: false,
 		},
+		{
+			url:           "/tx/simulate",
+			method:        "POST",
+			isRateLimited: true,
+		},
 		{
 			url:           "/tx/1",
 			method:        "GET",
 			isRateLimited: false,
 		},
+		{
+			url:           "/tx/1/receipt",
+			method:        "GET",
+			isRateLimited: false,
+		},
+		{
+			url:           "/accounts/1/proof",
+			method:        "GET",
+			isRateLimited: false,
+		},
+		{
+			url:           "/accounts/1/delegations",
+			method:        "GET",
+			isRateLimited: false,
+		},
+		{
+			url:           "/accounts/1/delegators",
+			method:        "GET",
+			isRateLimited: false,
+		},
 		{
 			url:           "/tx",
 			method:        "GET",
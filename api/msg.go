@@ -21,6 +21,7 @@ package api
 
 import (
 	"encoding/base64"
+	"encoding/binary"
 	"encoding/hex"
 	"net/http"
 	"strconv"
@@ -28,6 +29,7 @@ import (
 	"github.com/perlin-network/noise/edwards25519"
 	"github.com/perlin-network/noise/skademlia"
 	"github.com/perlin-network/wavelet"
+	"github.com/perlin-network/wavelet/avl"
 	"github.com/perlin-network/wavelet/sys"
 	"github.com/pkg/errors"
 	"github.com/valyala/fastjson"
@@ -40,15 +42,31 @@ type marshalableJSON interface {
 var (
 	_ marshalableJSON = (*sendTransactionResponse)(nil)
 
+	_ marshalableJSON = (*simulateTransactionResponse)(nil)
+
+	_ marshalableJSON = (*queryContractResponse)(nil)
+
 	_ marshalableJSON = (*ledgerStatusResponse)(nil)
 
 	_ marshalableJSON = (*transaction)(nil)
 
 	_ marshalableJSON = (*account)(nil)
+
+	_ marshalableJSON = (*eventList)(nil)
+
+	_ marshalableJSON = (*voteList)(nil)
+
+	_ marshalableJSON = (*receipt)(nil)
+
+	_ marshalableJSON = (*accountProof)(nil)
+
+	_ marshalableJSON = (*delegationList)(nil)
 )
 
 type sendTransactionRequest struct {
 	Sender    string `json:"sender"`
+	Nonce     uint64 `json:"nonce"`
+	Tip       uint64 `json:"tip"`
 	Tag       byte   `json:"tag"`
 	Payload   string `json:"payload"`
 	Signature string `json:"signature"`
@@ -117,7 +135,35 @@ func (s *sendTransactionRequest) bind(parser *fastjson.Parser, body []byte) erro
 		return errors.Wrap(err, "invalid tag")
 	}
 
+	var nonce uint64
+
+	if nonceVal := v.Get("nonce"); nonceVal != nil {
+		if nonceVal.Type() != fastjson.TypeNumber {
+			return errors.New("nonce is not a number")
+		}
+
+		nonce, err = nonceVal.Uint64()
+		if err != nil {
+			return errors.Wrap(err, "invalid nonce")
+		}
+	}
+
+	var tip uint64
+
+	if tipVal := v.Get("tip"); tipVal != nil {
+		if tipVal.Type() != fastjson.TypeNumber {
+			return errors.New("tip is not a number")
+		}
+
+		tip, err = tipVal.Uint64()
+		if err != nil {
+			return errors.Wrap(err, "invalid tip")
+		}
+	}
+
 	s.Sender = string(senderStr)
+	s.Nonce = nonce
+	s.Tip = tip
 	s.Payload = string(payloadStr)
 	s.Signature = string(signatureStr)
 	s.Tag = byte(tag)
@@ -131,7 +177,7 @@ func (s *sendTransactionRequest) bind(parser *fastjson.Parser, body []byte) erro
 		return errors.Errorf("sender public key must be size %d", wavelet.SizeAccountID)
 	}
 
-	if sys.Tag(s.Tag) > sys.TagBatch {
+	if sys.Tag(s.Tag) > sys.TagEvidence {
 		return errors.New("unknown transaction tag specified")
 	}
 
@@ -191,6 +237,233 @@ func (s *sendTransactionResponse) marshalJSON(arena *fastjson.Arena) ([]byte, er
 	return o.MarshalTo(nil), nil
 }
 
+type simulateTransactionResponse struct {
+	// Internal fields.
+	tx     *wavelet.Transaction
+	result *wavelet.SimulationResult
+}
+
+func (s *simulateTransactionResponse) marshalJSON(arena *fastjson.Arena) ([]byte, error) {
+	if s.tx == nil || s.result == nil {
+		return nil, errors.New("insufficient parameters were provided")
+	}
+
+	o := arena.NewObject()
+
+	o.Set("tx_id", arena.NewString(hex.EncodeToString(s.tx.ID[:])))
+
+	if s.result.Applied {
+		o.Set("applied", arena.NewTrue())
+	} else {
+		o.Set("applied", arena.NewFalse())
+	}
+
+	if s.result.Error != nil {
+		o.Set("error", arena.NewString(s.result.Error.Error()))
+	} else {
+		o.Set("error", arena.NewString(""))
+	}
+
+	o.Set("fee", arena.NewNumberString(strconv.FormatUint(s.result.Fee, 10)))
+	o.Set("gas_used", arena.NewNumberString(strconv.FormatUint(s.result.Gas, 10)))
+
+	deltas := arena.NewArray()
+	for i, delta := range s.result.Deltas {
+		v := arena.NewObject()
+
+		v.Set("account_id", arena.NewString(hex.EncodeToString(delta.Account[:])))
+		v.Set("before", arena.NewNumberString(strconv.FormatUint(delta.Before, 10)))
+		v.Set("after", arena.NewNumberString(strconv.FormatUint(delta.After, 10)))
+
+		deltas.SetArrayItem(i, v)
+	}
+	o.Set("balance_deltas", deltas)
+
+	return o.MarshalTo(nil), nil
+}
+
+// Max amount of gas a read-only smart contract query may expend.
+const maxQueryGasLimit uint64 = 10000000
+
+type queryContractRequest struct {
+	Sender   string `json:"sender"`
+	FuncName string `json:"func_name"`
+	Params   string `json:"func_params"`
+	Amount   uint64 `json:"amount"`
+	GasLimit uint64 `json:"gas_limit"`
+	Trace    bool   `json:"trace"`
+
+	// Internal fields.
+	sender wavelet.AccountID
+	params []byte
+}
+
+func (q *queryContractRequest) bind(parser *fastjson.Parser, body []byte) error {
+	if err := fastjson.ValidateBytes(body); err != nil {
+		return errors.Wrap(err, "invalid json")
+	}
+
+	v, err := parser.ParseBytes(body)
+	if err != nil {
+		return err
+	}
+
+	funcNameVal := v.Get("func_name")
+	if funcNameVal == nil {
+		return errors.New("missing func_name")
+	}
+	if funcNameVal.Type() != fastjson.TypeString {
+		return errors.New("func_name is not a string")
+	}
+	funcName, err := funcNameVal.StringBytes()
+	if err != nil {
+		return errors.Wrap(err, "invalid func_name")
+	}
+
+	if len(funcName) == 0 {
+		return errors.New("func_name must not be empty")
+	}
+
+	q.FuncName = string(funcName)
+
+	if senderVal := v.Get("sender"); senderVal != nil {
+		if senderVal.Type() != fastjson.TypeString {
+			return errors.New("sender is not a string")
+		}
+
+		q.Sender = string(senderVal.GetStringBytes())
+
+		senderBuf, err := hex.DecodeString(q.Sender)
+		if err != nil {
+			return errors.Wrap(err, "sender public key provided is not hex-formatted")
+		}
+
+		if len(senderBuf) != wavelet.SizeAccountID {
+			return errors.Errorf("sender public key must be size %d", wavelet.SizeAccountID)
+		}
+
+		copy(q.sender[:], senderBuf)
+	}
+
+	if paramsVal := v.Get("func_params"); paramsVal != nil {
+		if paramsVal.Type() != fastjson.TypeString {
+			return errors.New("func_params is not a string")
+		}
+
+		q.Params = string(paramsVal.GetStringBytes())
+
+		q.params, err = hex.DecodeString(q.Params)
+		if err != nil {
+			return errors.Wrap(err, "func_params provided is not hex-formatted")
+		}
+	}
+
+	if amountVal := v.Get("amount"); amountVal != nil {
+		if amountVal.Type() != fastjson.TypeNumber {
+			return errors.New("amount is not a number")
+		}
+
+		q.Amount, err = amountVal.Uint64()
+		if err != nil {
+			return errors.Wrap(err, "invalid amount")
+		}
+	}
+
+	q.GasLimit = maxQueryGasLimit
+
+	if gasLimitVal := v.Get("gas_limit"); gasLimitVal != nil {
+		if gasLimitVal.Type() != fastjson.TypeNumber {
+			return errors.New("gas_limit is not a number")
+		}
+
+		q.GasLimit, err = gasLimitVal.Uint64()
+		if err != nil {
+			return errors.Wrap(err, "invalid gas_limit")
+		}
+
+		if q.GasLimit == 0 || q.GasLimit > maxQueryGasLimit {
+			return errors.Errorf("gas_limit must be between 1 and %d", maxQueryGasLimit)
+		}
+	}
+
+	if traceVal := v.Get("trace"); traceVal != nil {
+		if q.Trace, err = traceVal.Bool(); err != nil {
+			return errors.Wrap(err, "trace is not a boolean")
+		}
+	}
+
+	return nil
+}
+
+type queryContractResponse struct {
+	// Internal fields.
+	executor *wavelet.ContractExecutor
+}
+
+func (q *queryContractResponse) marshalJSON(arena *fastjson.Arena) ([]byte, error) {
+	if q.executor == nil {
+		return nil, errors.New("insufficient fields specified")
+	}
+
+	o := arena.NewObject()
+
+	o.Set("result", arena.NewString(hex.EncodeToString(q.executor.Error)))
+	o.Set("gas_used", arena.NewNumberString(strconv.FormatUint(q.executor.Gas, 10)))
+
+	if q.executor.GasLimitExceeded {
+		o.Set("gas_limit_exceeded", arena.NewTrue())
+	} else {
+		o.Set("gas_limit_exceeded", arena.NewFalse())
+	}
+
+	logs := arena.NewArray()
+	for i, line := range q.executor.Logs {
+		logs.SetArrayItem(i, arena.NewString(line))
+	}
+	o.Set("logs", logs)
+
+	if q.executor.Trace != nil {
+		o.Set("trace", marshalContractTrace(arena, q.executor.Trace))
+	}
+
+	return o.MarshalTo(nil), nil
+}
+
+func marshalContractTrace(arena *fastjson.Arena, trace *wavelet.ContractTrace) *fastjson.Value {
+	entries := arena.NewArray()
+
+	for i, entry := range trace.Entries {
+		o := arena.NewObject()
+
+		o.Set("kind", arena.NewString(entry.Kind))
+		o.Set("contract_id", arena.NewString(hex.EncodeToString(entry.Contract[:])))
+		o.Set("depth", arena.NewNumberInt(entry.Depth))
+		o.Set("gas", arena.NewNumberString(strconv.FormatUint(entry.Gas, 10)))
+
+		switch entry.Kind {
+		case wavelet.TraceHostCall:
+			o.Set("name", arena.NewString(entry.Name))
+
+			args := arena.NewArray()
+			for j, arg := range entry.Args {
+				args.SetArrayItem(j, arena.NewNumberString(strconv.FormatInt(arg, 10)))
+			}
+			o.Set("args", args)
+
+			o.Set("result", arena.NewNumberString(strconv.FormatInt(entry.Result, 10)))
+		case wavelet.TraceMemoryGrowth:
+			o.Set("memory_size", arena.NewNumberInt(entry.MemorySize))
+			o.Set("result", arena.NewNumberString(strconv.FormatInt(entry.Result, 10)))
+		case wavelet.TraceExit:
+			o.Set("reason", arena.NewString(entry.Reason))
+		}
+
+		entries.SetArrayItem(i, o)
+	}
+
+	return entries
+}
+
 type ledgerStatusResponse struct {
 	// Internal fields.
 
@@ -273,6 +546,7 @@ func (s *transaction) getObject(arena *fastjson.Arena) (*fastjson.Value, error)
 	o.Set("creator", arena.NewString(hex.EncodeToString(s.tx.Creator[:])))
 	o.Set("status", arena.NewString(s.status))
 	o.Set("nonce", arena.NewNumberString(strconv.FormatUint(s.tx.Nonce, 10)))
+	o.Set("tip", arena.NewNumberString(strconv.FormatUint(s.tx.Tip, 10)))
 	o.Set("depth", arena.NewNumberString(strconv.FormatUint(s.tx.Depth, 10)))
 	o.Set("tag", arena.NewNumberInt(int(s.tx.Tag)))
 	o.Set("payload", arena.NewString(base64.StdEncoding.EncodeToString(s.tx.Payload)))
@@ -309,18 +583,103 @@ func (s transactionList) marshalJSON(arena *fastjson.Arena) ([]byte, error) {
 	return list.MarshalTo(nil), nil
 }
 
+// Max number of rounds that events may be queried from at a time.
+const maxEventsRoundRange uint64 = 1000
+
+type eventList []*wavelet.Event
+
+func (s eventList) marshalJSON(arena *fastjson.Arena) ([]byte, error) {
+	list := arena.NewArray()
+
+	for i, event := range s {
+		list.SetArrayItem(i, marshalEvent(arena, event))
+	}
+
+	return list.MarshalTo(nil), nil
+}
+
+func marshalEvent(arena *fastjson.Arena, event *wavelet.Event) *fastjson.Value {
+	o := arena.NewObject()
+
+	o.Set("round", arena.NewNumberString(strconv.FormatUint(event.Round, 10)))
+	o.Set("contract_id", arena.NewString(hex.EncodeToString(event.Contract[:])))
+	o.Set("tx_id", arena.NewString(hex.EncodeToString(event.TxID[:])))
+	o.Set("topic", arena.NewString(event.Topic))
+	o.Set("data", arena.NewString(hex.EncodeToString(event.Data)))
+
+	return o
+}
+
+type voteList []wavelet.Vote
+
+func (s voteList) marshalJSON(arena *fastjson.Arena) ([]byte, error) {
+	list := arena.NewArray()
+
+	for i, vote := range s {
+		o := arena.NewObject()
+
+		o.Set("voter", arena.NewString(hex.EncodeToString(vote.Voter[:])))
+		o.Set("round", arena.NewNumberString(strconv.FormatUint(vote.RoundIndex, 10)))
+		o.Set("round_id", arena.NewString(hex.EncodeToString(vote.RoundID[:])))
+
+		if vote.Finalized {
+			o.Set("finalized", arena.NewTrue())
+		} else {
+			o.Set("finalized", arena.NewFalse())
+		}
+
+		o.Set("signature", arena.NewString(hex.EncodeToString(vote.Signature[:])))
+
+		list.SetArrayItem(i, o)
+	}
+
+	return list.MarshalTo(nil), nil
+}
+
+type receipt struct {
+	// Internal fields.
+	receipt *wavelet.Receipt
+}
+
+func (r *receipt) marshalJSON(arena *fastjson.Arena) ([]byte, error) {
+	if r.receipt == nil {
+		return nil, errors.New("insufficient fields specified")
+	}
+
+	o := arena.NewObject()
+
+	o.Set("tx_id", arena.NewString(hex.EncodeToString(r.receipt.TxID[:])))
+	o.Set("round", arena.NewNumberString(strconv.FormatUint(r.receipt.Round, 10)))
+	o.Set("status", arena.NewString(r.receipt.Status.String()))
+
+	if r.receipt.Error != "" {
+		o.Set("error", arena.NewString(r.receipt.Error))
+	}
+
+	o.Set("fee", arena.NewNumberString(strconv.FormatUint(r.receipt.Fee, 10)))
+	o.Set("gas_used", arena.NewNumberString(strconv.FormatUint(r.receipt.Gas, 10)))
+
+	events := arena.NewArray()
+	for i := range r.receipt.Events {
+		events.SetArrayItem(i, marshalEvent(arena, &r.receipt.Events[i]))
+	}
+	o.Set("events", events)
+
+	return o.MarshalTo(nil), nil
+}
+
 type account struct {
 	// Internal fields.
-	id     wavelet.AccountID
-	ledger *wavelet.Ledger
+	id       wavelet.AccountID
+	snapshot *avl.Tree
 }
 
 func (s *account) marshalJSON(arena *fastjson.Arena) ([]byte, error) {
-	if s.ledger == nil || s.id == wavelet.ZeroAccountID {
+	if s.snapshot == nil || s.id == wavelet.ZeroAccountID {
 		return nil, errors.New("insufficient fields specified")
 	}
 
-	snapshot := s.ledger.Snapshot()
+	snapshot := s.snapshot
 
 	o := arena.NewObject()
 
@@ -338,6 +697,38 @@ func (s *account) marshalJSON(arena *fastjson.Arena) ([]byte, error) {
 	reward, _ := wavelet.ReadAccountReward(snapshot, s.id)
 	o.Set("reward", arena.NewNumberString(strconv.FormatUint(reward, 10)))
 
+	delegatedStake, _ := wavelet.ReadAccountDelegatedStake(snapshot, s.id)
+	o.Set("delegated_stake", arena.NewNumberString(strconv.FormatUint(delegatedStake, 10)))
+
+	commission, _ := wavelet.ReadAccountCommission(snapshot, s.id)
+	o.Set("commission", arena.NewNumberString(strconv.FormatUint(commission, 10)))
+
+	if offences := wavelet.ReadAccountOffences(snapshot, s.id); len(offences) > 0 {
+		list := arena.NewArray()
+
+		for i, offence := range offences {
+			v := arena.NewObject()
+			v.Set("round", arena.NewNumberString(strconv.FormatUint(offence.RoundIndex, 10)))
+			v.Set("slashed", arena.NewNumberString(strconv.FormatUint(offence.Slashed, 10)))
+
+			list.SetArrayItem(i, v)
+		}
+
+		o.Set("offences", list)
+	}
+
+	unbonding := arena.NewArray()
+
+	for i, ur := range wavelet.ReadAccountUnbondingRequests(snapshot, s.id) {
+		v := arena.NewObject()
+		v.Set("round", arena.NewNumberString(strconv.FormatUint(ur.Round, 10)))
+		v.Set("amount", arena.NewNumberString(strconv.FormatUint(ur.Amount, 10)))
+
+		unbonding.SetArrayItem(i, v)
+	}
+
+	o.Set("unbonding", unbonding)
+
 	nonce, _ := wavelet.ReadAccountNonce(snapshot, s.id)
 	o.Set("nonce", arena.NewNumberString(strconv.FormatUint(nonce, 10)))
 
@@ -348,6 +739,10 @@ func (s *account) marshalJSON(arena *fastjson.Arena) ([]byte, error) {
 		o.Set("is_contract", arena.NewFalse())
 	}
 
+	if owner, exists := wavelet.ReadAccountContractOwner(snapshot, s.id); exists {
+		o.Set("owner", arena.NewString(hex.EncodeToString(owner[:])))
+	}
+
 	numPages, _ := wavelet.ReadAccountContractNumPages(snapshot, s.id)
 	if numPages != 0 {
 		o.Set("num_mem_pages", arena.NewNumberString(strconv.FormatUint(numPages, 10)))
@@ -356,6 +751,50 @@ func (s *account) marshalJSON(arena *fastjson.Arena) ([]byte, error) {
 	return o.MarshalTo(nil), nil
 }
 
+type delegationList []wavelet.Delegation
+
+func (s delegationList) marshalJSON(arena *fastjson.Arena) ([]byte, error) {
+	list := arena.NewArray()
+
+	for i, delegation := range s {
+		o := arena.NewObject()
+
+		o.Set("delegator", arena.NewString(hex.EncodeToString(delegation.Delegator[:])))
+		o.Set("validator", arena.NewString(hex.EncodeToString(delegation.Validator[:])))
+		o.Set("amount", arena.NewNumberString(strconv.FormatUint(delegation.Amount, 10)))
+
+		list.SetArrayItem(i, o)
+	}
+
+	return list.MarshalTo(nil), nil
+}
+
+type accountProof struct {
+	// Internal fields.
+	round uint64
+	root  wavelet.MerkleNodeID
+
+	key, value []byte
+	proof      avl.Proof
+}
+
+func (s *accountProof) marshalJSON(arena *fastjson.Arena) ([]byte, error) {
+	o := arena.NewObject()
+
+	o.Set("round", arena.NewNumberString(strconv.FormatUint(s.round, 10)))
+	o.Set("merkle_root", arena.NewString(hex.EncodeToString(s.root[:])))
+	o.Set("key", arena.NewString(hex.EncodeToString(s.key)))
+	o.Set("value", arena.NewString(hex.EncodeToString(s.value)))
+
+	if len(s.value) == 8 {
+		o.Set("balance", arena.NewNumberString(strconv.FormatUint(binary.LittleEndian.Uint64(s.value), 10)))
+	}
+
+	o.Set("proof", arena.NewString(hex.EncodeToString(s.proof.Marshal())))
+
+	return o.MarshalTo(nil), nil
+}
+
 type errResponse struct {
 	Err            error `json:"-"` // low-level runtime error
 	HTTPStatusCode int   `json:"-"` // http response status code
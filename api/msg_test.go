@@ -105,3 +105,97 @@ func TestSendTransactionRequestMissingFields(t *testing.T) {
 	`
 	assert.Error(t, req.bind(&fastjson.Parser{}, []byte(missingSignature)))
 }
+
+func TestSendTransactionRequestNonce(t *testing.T) {
+	req := new(sendTransactionRequest)
+
+	// test send nonce as string
+	typeMismatch := `
+		{
+			"nonce": "1",
+			"tag": 1,
+			"sender": "3132333435363738393031323334353637383930313233343536373839303132",
+			"payload": "7061796C6F6164",
+			"signature": "31323334353637383930313233343536373839303132333435363738393031323132333435363738393031323334353637383930313233343536373839303132"
+		}
+	`
+	assert.Error(t, req.bind(&fastjson.Parser{}, []byte(typeMismatch)))
+
+	// test send valid nonce
+	valid := `
+		{
+			"nonce": 42,
+			"tag": 1,
+			"sender": "3132333435363738393031323334353637383930313233343536373839303132",
+			"payload": "7061796C6F6164",
+			"signature": "31323334353637383930313233343536373839303132333435363738393031323132333435363738393031323334353637383930313233343536373839303132"
+		}
+	`
+	assert.NoError(t, req.bind(&fastjson.Parser{}, []byte(valid)))
+	assert.Equal(t, uint64(42), req.Nonce)
+}
+
+func TestSendTransactionRequestTip(t *testing.T) {
+	req := new(sendTransactionRequest)
+
+	// test send tip as string
+	typeMismatch := `
+		{
+			"tip": "1",
+			"tag": 1,
+			"sender": "3132333435363738393031323334353637383930313233343536373839303132",
+			"payload": "7061796C6F6164",
+			"signature": "31323334353637383930313233343536373839303132333435363738393031323132333435363738393031323334353637383930313233343536373839303132"
+		}
+	`
+	assert.Error(t, req.bind(&fastjson.Parser{}, []byte(typeMismatch)))
+
+	// test send valid tip
+	valid := `
+		{
+			"nonce": 42,
+			"tip": 10,
+			"tag": 1,
+			"sender": "3132333435363738393031323334353637383930313233343536373839303132",
+			"payload": "7061796C6F6164",
+			"signature": "31323334353637383930313233343536373839303132333435363738393031323132333435363738393031323334353637383930313233343536373839303132"
+		}
+	`
+	assert.NoError(t, req.bind(&fastjson.Parser{}, []byte(valid)))
+	assert.Equal(t, uint64(10), req.Tip)
+}
+
+func TestQueryContractRequest(t *testing.T) {
+	req := new(queryContractRequest)
+
+	// test missing func name
+	assert.Error(t, req.bind(&fastjson.Parser{}, []byte(`{"func_params": "01"}`)))
+
+	// test params not hex
+	assert.Error(t, req.bind(&fastjson.Parser{}, []byte(`{"func_name": "balance", "func_params": "zz"}`)))
+
+	// test gas limit exceeding the max
+	assert.Error(t, req.bind(&fastjson.Parser{}, []byte(`{"func_name": "balance", "gas_limit": 10000000000}`)))
+
+	// test trace not a boolean
+	assert.Error(t, req.bind(&fastjson.Parser{}, []byte(`{"func_name": "balance", "trace": "true"}`)))
+
+	// test opting into tracing
+	assert.NoError(t, req.bind(&fastjson.Parser{}, []byte(`{"func_name": "balance", "trace": true}`)))
+	assert.True(t, req.Trace)
+
+	// test valid request, with the gas limit defaulting to the max
+	valid := `
+		{
+			"func_name": "balance",
+			"func_params": "0102",
+			"sender": "3132333435363738393031323334353637383930313233343536373839303132",
+			"amount": 10
+		}
+	`
+	assert.NoError(t, req.bind(&fastjson.Parser{}, []byte(valid)))
+	assert.Equal(t, "balance", req.FuncName)
+	assert.Equal(t, []byte{0x01, 0x02}, req.params)
+	assert.Equal(t, uint64(10), req.Amount)
+	assert.Equal(t, maxQueryGasLimit, req.GasLimit)
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2019 Perlin
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package avl
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// Proof is the authentication path of a key-value pair within a tree. It comprises of all
+// fields necessary to recompute the Merkle ID of each node from the leaf holding the pair all
+// the way up to the root of the tree.
+type Proof struct {
+	// View ID of the leaf node holding the key-value pair.
+	LeafViewID uint64
+
+	// Non-leaf nodes along the path, ordered from the parent of the leaf node up to the root.
+	Path []ProofNode
+}
+
+// ProofNode is a non-leaf node along the authentication path of a key-value pair.
+type ProofNode struct {
+	// Merkle ID of the child of this node that is not along the path, and whether or not
+	// it is the left child of this node.
+	Sibling     [MerkleHashSize]byte
+	SiblingLeft bool
+
+	ViewID uint64
+	Key    []byte
+	Depth  byte
+	Size   uint64
+}
+
+// Prove returns the authentication path of the leaf node holding key. It returns an error
+// should the key not exist within the tree.
+func (t *Tree) Prove(key []byte) (Proof, error) {
+	var proof Proof
+
+	if t.root == nil {
+		return proof, errors.Errorf("avl: could not find key %x in empty tree", key)
+	}
+
+	n := t.root
+
+	for n.kind == NodeNonLeaf {
+		left, err := t.loadLeft(n)
+		if err != nil {
+			return proof, err
+		}
+
+		right, err := t.loadRight(n)
+		if err != nil {
+			return proof, err
+		}
+
+		step := ProofNode{ViewID: n.viewID, Key: n.key, Depth: n.depth, Size: n.size}
+
+		if bytes.Compare(key, left.key) <= 0 {
+			step.Sibling = right.id
+			n = left
+		} else {
+			step.Sibling, step.SiblingLeft = left.id, true
+			n = right
+		}
+
+		proof.Path = append(proof.Path, step)
+	}
+
+	if n.kind != NodeLeafValue || !bytes.Equal(n.key, key) {
+		return proof, errors.Errorf("avl: could not find key %x", key)
+	}
+
+	proof.LeafViewID = n.viewID
+
+	for i, j := 0, len(proof.Path)-1; i < j; i, j = i+1, j-1 {
+		proof.Path[i], proof.Path[j] = proof.Path[j], proof.Path[i]
+	}
+
+	return proof, nil
+}
+
+// VerifyProof checks whether or not a key-value pair is held within a tree whose root has
+// the Merkle ID root, given the authentication path of the pair.
+func VerifyProof(root [MerkleHashSize]byte, key, value []byte, proof Proof) bool {
+	n := &node{
+		key:   key,
+		value: value,
+
+		kind: NodeLeafValue,
+
+		depth: 0,
+		size:  1,
+
+		viewID: proof.LeafViewID,
+	}
+
+	n.rehash()
+
+	for _, step := range proof.Path {
+		parent := &node{
+			key: step.Key,
+
+			kind: NodeNonLeaf,
+
+			depth: step.Depth,
+			size:  step.Size,
+
+			viewID: step.ViewID,
+		}
+
+		if step.SiblingLeft {
+			parent.left, parent.right = step.Sibling, n.id
+		} else {
+			parent.left, parent.right = n.id, step.Sibling
+		}
+
+		parent.rehash()
+
+		n = parent
+	}
+
+	return n.id == root
+}
+
+func (p Proof) Marshal() []byte {
+	var w bytes.Buffer
+
+	var buf [8]byte
+
+	binary.LittleEndian.PutUint64(buf[:], p.LeafViewID)
+	w.Write(buf[:])
+
+	binary.LittleEndian.PutUint32(buf[:4], uint32(len(p.Path)))
+	w.Write(buf[:4])
+
+	for _, step := range p.Path {
+		w.Write(step.Sibling[:])
+
+		if step.SiblingLeft {
+			w.WriteByte(1)
+		} else {
+			w.WriteByte(0)
+		}
+
+		binary.LittleEndian.PutUint64(buf[:], step.ViewID)
+		w.Write(buf[:])
+
+		if len(step.Key) > math.MaxUint32 {
+			panic("avl: key is too long")
+		}
+
+		binary.LittleEndian.PutUint32(buf[:4], uint32(len(step.Key)))
+		w.Write(buf[:4])
+		w.Write(step.Key)
+
+		w.WriteByte(step.Depth)
+
+		binary.LittleEndian.PutUint64(buf[:], step.Size)
+		w.Write(buf[:])
+	}
+
+	return w.Bytes()
+}
+
+func UnmarshalProof(r io.Reader) (Proof, error) {
+	var p Proof
+
+	var buf [8]byte
+
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return p, errors.Wrap(err, "failed to decode leaf view id")
+	}
+
+	p.LeafViewID = binary.LittleEndian.Uint64(buf[:])
+
+	if _, err := io.ReadFull(r, buf[:4]); err != nil {
+		return p, errors.Wrap(err, "failed to decode path length")
+	}
+
+	p.Path = make([]ProofNode, binary.LittleEndian.Uint32(buf[:4]))
+
+	for i := range p.Path {
+		step := &p.Path[i]
+
+		if _, err := io.ReadFull(r, step.Sibling[:]); err != nil {
+			return p, errors.Wrap(err, "failed to decode sibling id")
+		}
+
+		if _, err := io.ReadFull(r, buf[:1]); err != nil {
+			return p, errors.Wrap(err, "failed to decode sibling side")
+		}
+
+		step.SiblingLeft = buf[0] == 1
+
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return p, errors.Wrap(err, "failed to decode view id")
+		}
+
+		step.ViewID = binary.LittleEndian.Uint64(buf[:])
+
+		if _, err := io.ReadFull(r, buf[:4]); err != nil {
+			return p, errors.Wrap(err, "failed to decode key length")
+		}
+
+		step.Key = make([]byte, binary.LittleEndian.Uint32(buf[:4]))
+
+		if _, err := io.ReadFull(r, step.Key); err != nil {
+			return p, errors.Wrap(err, "failed to decode key")
+		}
+
+		if _, err := io.ReadFull(r, buf[:1]); err != nil {
+			return p, errors.Wrap(err, "failed to decode depth")
+		}
+
+		step.Depth = buf[0]
+
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return p, errors.Wrap(err, "failed to decode size")
+		}
+
+		step.Size = binary.LittleEndian.Uint64(buf[:])
+	}
+
+	return p, nil
+}
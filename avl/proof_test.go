@@ -0,0 +1,77 @@
+// Copyright (c) 2019 Perlin
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package avl
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/perlin-network/wavelet/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree_Prove(t *testing.T) {
+	kv, cleanup := store.NewTestKV(t, "inmem", "db")
+	defer cleanup()
+
+	tree := New(kv)
+
+	_, err := tree.Prove([]byte("missing"))
+	assert.Error(t, err)
+
+	for i := uint64(0); i < 100; i++ {
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], i)
+
+		tree.SetViewID(i)
+		tree.Insert(buf[:], []byte{byte(i)})
+	}
+
+	assert.NoError(t, tree.Commit())
+
+	root := tree.Checksum()
+
+	tree = New(kv)
+
+	for i := uint64(0); i < 100; i++ {
+		var key [8]byte
+		binary.BigEndian.PutUint64(key[:], i)
+
+		proof, err := tree.Prove(key[:])
+		assert.NoError(t, err)
+
+		assert.True(t, VerifyProof(root, key[:], []byte{byte(i)}, proof))
+
+		// Proofs must not verify against another value or another root.
+		assert.False(t, VerifyProof(root, key[:], []byte{byte(i + 1)}, proof))
+		assert.False(t, VerifyProof([MerkleHashSize]byte{1}, key[:], []byte{byte(i)}, proof))
+
+		unmarshaled, err := UnmarshalProof(bytes.NewReader(proof.Marshal()))
+		assert.NoError(t, err)
+		assert.Equal(t, proof, unmarshaled)
+	}
+
+	_, err = tree.Prove([]byte("missing"))
+	assert.Error(t, err)
+
+	_, err = UnmarshalProof(bytes.NewReader([]byte{1, 2, 3}))
+	assert.Error(t, err)
+}
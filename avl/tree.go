@@ -113,6 +113,26 @@ func (t *Tree) Snapshot() *Tree {
 	return &Tree{kv: t.kv, cache: t.cache, maxWriteBatchSize: t.maxWriteBatchSize, root: t.root}
 }
 
+// SnapshotAt returns a snapshot of the tree as it was when its root was the node with the given
+// Merkle ID. It returns an error should the nodes of the tree at the given root have already been
+// garbage collected.
+func (t *Tree) SnapshotAt(root [MerkleHashSize]byte) (*Tree, error) {
+	snapshot := &Tree{kv: t.kv, cache: t.cache, maxWriteBatchSize: t.maxWriteBatchSize}
+
+	if root == [MerkleHashSize]byte{} {
+		return snapshot, nil
+	}
+
+	n, err := t.loadNode(root)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot.root = n
+
+	return snapshot, nil
+}
+
 func (t *Tree) Revert(snapshot *Tree) {
 	t.root = snapshot.root
 }
@@ -176,7 +196,20 @@ func (t *Tree) doPrintContents(n *node, depth int) {
 }
 
 func (t *Tree) Commit() error {
+	return t.CommitWithBatch(t.kv.NewWriteBatch())
+}
+
+// CommitWithBatch writes all nodes of the tree that have not yet been written, alongside the
+// new root of the tree, into batch and commits it. Any writes already staged in batch by the
+// caller are thus committed atomically with the tree.
+func (t *Tree) CommitWithBatch(batch store.WriteBatch) error {
 	if t.root == nil {
+		if batch.Count() > 0 {
+			if err := t.kv.CommitWriteBatch(batch); err != nil {
+				return errors.Wrap(err, "failed to commit write batch to db")
+			}
+		}
+
 		// Tree is empty, so just delete the root.
 		// If deleting the root fails because it doesn't exist, ignore the error.
 		_ = t.kv.Delete(RootKey)
@@ -184,8 +217,6 @@ func (t *Tree) Commit() error {
 		return nil
 	}
 
-	batch := t.kv.NewWriteBatch()
-
 	err := t.root.dfs(t, false, func(n *node) (bool, error) {
 		if n.wroteBack {
 			return false, nil
@@ -202,23 +233,25 @@ func (t *Tree) Commit() error {
 		return err
 	}
 
-	err = t.kv.CommitWriteBatch(batch)
-	if err != nil {
-		return errors.Wrap(err, "failed to commit write batch to db")
-	}
-
 	{
 		oldRootID, err := t.kv.Get(RootKey)
 
 		// If we want to include null roots here, getOldRoot() also needs to be fixed.
 		if err == nil && len(oldRootID) == MerkleHashSize {
 			nextOldRootIndex := t.getNextOldRootIndex()
-			t.setOldRoot(nextOldRootIndex, oldRootID)
-			t.setNextOldRootIndex(nextOldRootIndex + 1)
+			t.setOldRoot(batch, nextOldRootIndex, oldRootID)
+			t.setNextOldRootIndex(batch, nextOldRootIndex+1)
 		}
 	}
 
-	return t.kv.Put(RootKey, t.root.id[:])
+	batch.Put(RootKey, t.root.id[:])
+
+	err = t.kv.CommitWriteBatch(batch)
+	if err != nil {
+		return errors.Wrap(err, "failed to commit write batch to db")
+	}
+
+	return nil
 }
 
 func (t *Tree) getNextOldRootIndex() uint64 {
@@ -230,10 +263,10 @@ func (t *Tree) getNextOldRootIndex() uint64 {
 	}
 }
 
-func (t *Tree) setNextOldRootIndex(x uint64) {
+func (t *Tree) setNextOldRootIndex(batch store.WriteBatch, x uint64) {
 	var buf [8]byte
 	binary.LittleEndian.PutUint64(buf[:], x)
-	_ = t.kv.Put(NextOldRootIndexKey, buf[:])
+	batch.Put(NextOldRootIndexKey, buf[:])
 }
 
 func (t *Tree) getOldRoot(idx uint64) ([MerkleHashSize]byte, bool) {
@@ -250,11 +283,24 @@ func (t *Tree) getOldRoot(idx uint64) ([MerkleHashSize]byte, bool) {
 	}
 }
 
-func (t *Tree) setOldRoot(idx uint64, value []byte) {
+func (t *Tree) setOldRoot(batch store.WriteBatch, idx uint64, value []byte) {
 	var buf [8]byte
 	binary.LittleEndian.PutUint64(buf[:], idx)
 
-	_ = t.kv.Put(append(OldRootsPrefix, buf[:]...), value)
+	batch.Put(append(OldRootsPrefix, buf[:]...), value)
+}
+
+// NextOldRootIndex returns the index at which the root the tree was last committed with is kept
+// amongst the old roots of the tree, once the tree is committed with a new root.
+func (t *Tree) NextOldRootIndex() uint64 {
+	return t.getNextOldRootIndex()
+}
+
+// OldRoot returns the ID of the old root of the tree kept at index idx. It returns false should
+// the old root have been garbage collected, or should the tree never have been committed that
+// many times.
+func (t *Tree) OldRoot(idx uint64) ([MerkleHashSize]byte, bool) {
+	return t.getOldRoot(idx)
 }
 
 func (t *Tree) deleteOldRoot(idx uint64) {
@@ -141,6 +141,46 @@ func TestTree_Snapshot(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func TestTree_SnapshotAt(t *testing.T) {
+	kv, cleanup := store.NewTestKV(t, "level", "db")
+	defer cleanup()
+
+	tree := New(kv)
+
+	var roots [][MerkleHashSize]byte
+
+	for i := 1; i <= 3; i++ {
+		tree.Insert([]byte("k"), []byte{byte(i)})
+		assert.NoError(t, tree.Commit())
+
+		roots = append(roots, tree.Checksum())
+	}
+
+	// Garbage collect all but the most recent old root.
+	_, err := tree.GetGCProfile(0).PerformFullGC()
+	assert.NoError(t, err)
+
+	tree = New(kv)
+
+	for i, root := range roots[1:] {
+		ss, err := tree.SnapshotAt(root)
+		assert.NoError(t, err)
+
+		v, ok := ss.Lookup([]byte("k"))
+		assert.True(t, ok)
+		assert.EqualValues(t, []byte{byte(i + 2)}, v)
+	}
+
+	_, err = tree.SnapshotAt(roots[0])
+	assert.Error(t, err)
+
+	ss, err := tree.SnapshotAt([MerkleHashSize]byte{})
+	assert.NoError(t, err)
+
+	_, ok := ss.Lookup([]byte("k"))
+	assert.False(t, ok)
+}
+
 func TestTree_Diff_Randomized(t *testing.T) {
 	kv, cleanup := store.NewTestKV(t, "level", "db")
 	defer cleanup()
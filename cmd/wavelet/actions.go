@@ -28,6 +28,7 @@ import (
 	"strconv"
 
 	"github.com/perlin-network/wavelet"
+	"github.com/perlin-network/wavelet/avl"
 	"github.com/perlin-network/wavelet/sys"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
@@ -120,8 +121,9 @@ func (cli *CLI) pay(ctx *cli.Context) {
 
 	snapshot := cli.ledger.Snapshot()
 	balance, _ := wavelet.ReadAccountBalance(snapshot, cli.keys.PublicKey())
+	fee := wavelet.Transaction{Tag: sys.TagTransfer, Payload: payload.Marshal()}.Fee()
 
-	if balance < amount+sys.TransactionFeeAmount {
+	if balance < amount+fee {
 		cli.logger.Error().
 			Uint64("your_balance", balance).
 			Uint64("amount_to_send", amount).
@@ -134,12 +136,12 @@ func (cli *CLI) pay(ctx *cli.Context) {
 	)
 	if codeAvailable {
 		// Set gas limit by default to the balance the user has.
-		payload.GasLimit = balance - amount - sys.TransactionFeeAmount
+		payload.GasLimit = balance - amount - fee
 		payload.FuncName = []byte("on_money_received")
 	}
 
 	tx, err := cli.sendTransaction(wavelet.NewTransaction(
-		cli.keys, sys.TagTransfer, payload.Marshal(),
+		cli.keys, cli.ledger.NextNonce(), sys.TagTransfer, payload.Marshal(),
 	))
 
 	if err != nil {
@@ -155,7 +157,7 @@ func (cli *CLI) call(ctx *cli.Context) {
 
 	if len(cmd) < 4 {
 		cli.logger.Error().
-			Msg("Invalid usage: call <smart-contract-address> <amount> <gas-limit> <function> [function parameters]")
+			Msg("Invalid usage: call <smart-contract-address> <amount> <gas-limit> <function> [function parameters | name=value...]")
 		return
 	}
 
@@ -178,7 +180,7 @@ func (cli *CLI) call(ctx *cli.Context) {
 	snapshot := cli.ledger.Snapshot()
 
 	balance, _ := wavelet.ReadAccountBalance(snapshot, cli.keys.PublicKey())
-	_, codeAvailable := wavelet.ReadAccountContractCode(snapshot, payload.Recipient)
+	code, codeAvailable := wavelet.ReadAccountContractCode(snapshot, payload.Recipient)
 
 	if !codeAvailable {
 		cli.logger.Error().
@@ -200,7 +202,9 @@ func (cli *CLI) call(ctx *cli.Context) {
 		return
 	}
 
-	if balance < amount+gasLimit {
+	trace := ctx.Bool("trace")
+
+	if !trace && balance < amount+gasLimit {
 		cli.logger.Error().
 			Uint64("your_balance", balance).
 			Uint64("cost", amount+gasLimit).
@@ -214,61 +218,80 @@ func (cli *CLI) call(ctx *cli.Context) {
 	payload.GasLimit = gasLimit
 	payload.FuncName = []byte(funcName)
 
-	var intBuf [8]byte
-	params := bytes.NewBuffer(nil)
-	for i := 4; i < len(cmd); i++ {
-		arg := cmd[i]
-
-		switch arg[0] {
-		case 'S':
-			params.WriteString(arg[1:])
-			params.WriteByte(0)
-		case 'B':
-			binary.LittleEndian.PutUint32(intBuf[:4], uint32(len(arg[1:])))
-			params.Write(intBuf[:4])
-			params.Write([]byte(arg[1:]))
-		case '1', '2', '4', '8':
-			var val uint64
-			_, err := fmt.Sscanf(arg[1:], "%d", &val)
-			if err != nil {
-				cli.logger.Error().Err(err).
-					Msgf("Got an error parsing integer: %+v", arg[1:])
-			}
+	// Should the smart contract ship an ABI, function parameters are specified by name and
+	// encoded according to the ABI.
+
+	if rawABI, exists := wavelet.ReadAccountContractABI(snapshot, payload.Recipient); exists {
+		params, err := encodeABIParams(rawABI, funcName, cmd[4:])
+		if err != nil {
+			cli.logger.Error().Err(err).
+				Msg("Failed to encode function parameters according to the ABI of the smart contract.")
+			return
+		}
+
+		payload.FuncParams = params
+	} else {
+		var intBuf [8]byte
+		params := bytes.NewBuffer(nil)
+		for i := 4; i < len(cmd); i++ {
+			arg := cmd[i]
 
 			switch arg[0] {
-			case '1':
-				params.WriteByte(byte(val))
-			case '2':
-				binary.LittleEndian.PutUint16(intBuf[:2], uint16(val))
-				params.Write(intBuf[:2])
-			case '4':
-				binary.LittleEndian.PutUint32(intBuf[:4], uint32(val))
+			case 'S':
+				params.WriteString(arg[1:])
+				params.WriteByte(0)
+			case 'B':
+				binary.LittleEndian.PutUint32(intBuf[:4], uint32(len(arg[1:])))
 				params.Write(intBuf[:4])
-			case '8':
-				binary.LittleEndian.PutUint64(intBuf[:8], uint64(val))
-				params.Write(intBuf[:8])
-			}
-		case 'H':
-			buf, err := hex.DecodeString(arg[1:])
-
-			if err != nil {
-				cli.logger.Error().Err(err).
-					Msgf("Cannot decode hex: %s", arg[1:])
+				params.Write([]byte(arg[1:]))
+			case '1', '2', '4', '8':
+				var val uint64
+				_, err := fmt.Sscanf(arg[1:], "%d", &val)
+				if err != nil {
+					cli.logger.Error().Err(err).
+						Msgf("Got an error parsing integer: %+v", arg[1:])
+				}
+
+				switch arg[0] {
+				case '1':
+					params.WriteByte(byte(val))
+				case '2':
+					binary.LittleEndian.PutUint16(intBuf[:2], uint16(val))
+					params.Write(intBuf[:2])
+				case '4':
+					binary.LittleEndian.PutUint32(intBuf[:4], uint32(val))
+					params.Write(intBuf[:4])
+				case '8':
+					binary.LittleEndian.PutUint64(intBuf[:8], uint64(val))
+					params.Write(intBuf[:8])
+				}
+			case 'H':
+				buf, err := hex.DecodeString(arg[1:])
+
+				if err != nil {
+					cli.logger.Error().Err(err).
+						Msgf("Cannot decode hex: %s", arg[1:])
+					return
+				}
+
+				params.Write(buf)
+			default:
+				cli.logger.Error().
+					Msgf("Invalid argument specified: %s", arg)
 				return
 			}
-
-			params.Write(buf)
-		default:
-			cli.logger.Error().
-				Msgf("Invalid argument specified: %s", arg)
-			return
 		}
+
+		payload.FuncParams = params.Bytes()
 	}
 
-	payload.FuncParams = params.Bytes()
+	if trace {
+		cli.traceCall(snapshot, payload, code)
+		return
+	}
 
 	tx, err := cli.sendTransaction(wavelet.NewTransaction(
-		cli.keys, sys.TagTransfer, payload.Marshal(),
+		cli.keys, cli.ledger.NextNonce(), sys.TagTransfer, payload.Marshal(),
 	))
 
 	if err != nil {
@@ -279,6 +302,34 @@ func (cli *CLI) call(ctx *cli.Context) {
 		Msgf("Success! Your smart contract invocation transaction ID: %x", tx.ID)
 }
 
+// traceCall executes a smart contract function against a snapshot of the ledger without
+// sending a transaction, and prints out a trace of the functions execution.
+func (cli *CLI) traceCall(snapshot *avl.Tree, payload wavelet.Transfer, code []byte) {
+	tx := &wavelet.Transaction{Sender: cli.keys.PublicKey(), Creator: cli.keys.PublicKey()}
+	executor := &wavelet.ContractExecutor{Trace: new(wavelet.ContractTrace)}
+
+	err := executor.Execute(
+		snapshot, payload.Recipient, cli.ledger.Rounds().Latest(), tx,
+		payload.Amount, payload.GasLimit, string(payload.FuncName), payload.FuncParams, code,
+	)
+
+	for _, entry := range executor.Trace.Entries {
+		cli.logger.Info().Msg(entry.String())
+	}
+
+	if err != nil {
+		cli.logger.Error().Err(err).
+			Msg("Failed to execute the smart contract function.")
+		return
+	}
+
+	cli.logger.Info().
+		Uint64("gas_used", executor.Gas).
+		Bool("gas_limit_exceeded", executor.GasLimitExceeded).
+		Str("result", string(executor.Error)).
+		Msg("Traced the smart contract function. No transaction was sent.")
+}
+
 func (cli *CLI) find(ctx *cli.Context) {
 	var cmd = ctx.Args()
 
@@ -380,7 +431,7 @@ func (cli *CLI) spawn(ctx *cli.Context) {
 		Code:     code,
 	}
 
-	tx, err := cli.sendTransaction(wavelet.NewTransaction(cli.keys, sys.TagContract, payload.Marshal()))
+	tx, err := cli.sendTransaction(wavelet.NewTransaction(cli.keys, cli.ledger.NextNonce(), sys.TagContract, payload.Marshal()))
 	if err != nil {
 		return
 	}
@@ -388,6 +439,137 @@ func (cli *CLI) spawn(ctx *cli.Context) {
 	cli.logger.Info().Msgf("Success! Your smart contracts ID: %x", tx.ID)
 }
 
+func (cli *CLI) upgrade(ctx *cli.Context) {
+	var cmd = ctx.Args()
+
+	if len(cmd) < 2 {
+		cli.logger.Error().
+			Msg("Invalid usage: upgrade <smart-contract-address> <path-to-smart-contract>")
+		return
+	}
+
+	contract, ok := cli.parseOwnedContract(cmd[0])
+	if !ok {
+		return
+	}
+
+	code, err := ioutil.ReadFile(cmd[1])
+	if err != nil {
+		cli.logger.Error().
+			Err(err).
+			Str("path", cmd[1]).
+			Msg("Failed to find/load the smart contract code from the given path.")
+		return
+	}
+
+	payload := wavelet.ContractLifecycle{
+		Opcode:   sys.UpgradeContract,
+		Contract: contract,
+		Code:     code,
+	}
+
+	tx, err := cli.sendTransaction(wavelet.NewTransaction(cli.keys, cli.ledger.NextNonce(), sys.TagContractLifecycle, payload.Marshal()))
+	if err != nil {
+		return
+	}
+
+	cli.logger.Info().
+		Msgf("Success! Your smart contract upgrade transaction ID: %x", tx.ID)
+}
+
+func (cli *CLI) destruct(ctx *cli.Context) {
+	var cmd = ctx.Args()
+
+	if len(cmd) < 2 {
+		cli.logger.Error().
+			Msg("Invalid usage: destruct <smart-contract-address> <beneficiary>")
+		return
+	}
+
+	contract, ok := cli.parseOwnedContract(cmd[0])
+	if !ok {
+		return
+	}
+
+	beneficiary, err := hex.DecodeString(cmd[1])
+	if err != nil {
+		cli.logger.Error().Err(err).
+			Msg("The beneficiary you specified is invalid.")
+		return
+	}
+
+	if len(beneficiary) != wavelet.SizeAccountID {
+		cli.logger.Error().Int("length", len(beneficiary)).
+			Msg("You have specified an invalid beneficiary account ID.")
+		return
+	}
+
+	payload := wavelet.ContractLifecycle{
+		Opcode:   sys.DestructContract,
+		Contract: contract,
+	}
+	copy(payload.Beneficiary[:], beneficiary)
+
+	tx, err := cli.sendTransaction(wavelet.NewTransaction(cli.keys, cli.ledger.NextNonce(), sys.TagContractLifecycle, payload.Marshal()))
+	if err != nil {
+		return
+	}
+
+	cli.logger.Info().
+		Msgf("Success! Your smart contract destruction transaction ID: %x", tx.ID)
+}
+
+// encodeABIParams encodes named function parameters of the form name=value according to
+// the ABI of a smart contract.
+func encodeABIParams(rawABI []byte, funcName string, args []string) ([]byte, error) {
+	abi, err := wavelet.ParseContractABI(rawABI)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, exists := abi.Function(funcName)
+	if !exists {
+		return nil, errors.Errorf("function %q is not declared in the ABI", funcName)
+	}
+
+	parsed, err := wavelet.ParseABIArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn.EncodeParams(parsed)
+}
+
+// parseOwnedContract parses the address of a smart contract, and checks that it is owned by this node.
+func (cli *CLI) parseOwnedContract(raw string) (wavelet.AccountID, bool) {
+	var contract wavelet.AccountID
+
+	buf, err := hex.DecodeString(raw)
+	if err != nil {
+		cli.logger.Error().Err(err).
+			Msg("The smart contract address you specified is invalid.")
+		return contract, false
+	}
+
+	if len(buf) != wavelet.SizeAccountID {
+		cli.logger.Error().Int("length", len(buf)).
+			Msg("You have specified an invalid account ID to find.")
+		return contract, false
+	}
+
+	copy(contract[:], buf)
+
+	owner, exists := wavelet.ReadAccountContractOwner(cli.ledger.Snapshot(), contract)
+
+	if !exists || owner != cli.keys.PublicKey() {
+		cli.logger.Error().
+			Msg("The smart contract address you specified does not belong to a smart contract owned by you.")
+		return contract, false
+	}
+
+	return contract, true
+}
+
 func (cli *CLI) depositGas(ctx *cli.Context) {
 	var cmd = ctx.Args()
 
@@ -433,7 +615,9 @@ func (cli *CLI) depositGas(ctx *cli.Context) {
 	_, codeAvailable := wavelet.ReadAccountContractCode(snapshot, payload.Recipient)
 
 	// Check balance
-	if balance < amount+sys.TransactionFeeAmount {
+	fee := wavelet.Transaction{Tag: sys.TagTransfer, Payload: payload.Marshal()}.Fee()
+
+	if balance < amount+fee {
 		cli.logger.Error().
 			Uint64("your_balance", balance).
 			Uint64("amount_to_send", amount).
@@ -449,7 +633,7 @@ func (cli *CLI) depositGas(ctx *cli.Context) {
 	}
 
 	tx, err := cli.sendTransaction(
-		wavelet.NewTransaction(cli.keys, sys.TagTransfer, payload.Marshal()),
+		wavelet.NewTransaction(cli.keys, cli.ledger.NextNonce(), sys.TagTransfer, payload.Marshal()),
 	)
 
 	if err != nil {
@@ -482,7 +666,7 @@ func (cli *CLI) placeStake(ctx *cli.Context) {
 	}
 
 	tx, err := cli.sendTransaction(wavelet.NewTransaction(
-		cli.keys, sys.TagStake, payload.Marshal(),
+		cli.keys, cli.ledger.NextNonce(), sys.TagStake, payload.Marshal(),
 	))
 
 	if err != nil {
@@ -516,7 +700,7 @@ func (cli *CLI) withdrawStake(ctx *cli.Context) {
 	payload.Write(intBuf[:8])
 
 	tx, err := cli.sendTransaction(wavelet.NewTransaction(
-		cli.keys, sys.TagStake, payload.Bytes(),
+		cli.keys, cli.ledger.NextNonce(), sys.TagStake, payload.Bytes(),
 	))
 
 	if err != nil {
@@ -554,7 +738,7 @@ func (cli *CLI) withdrawReward(ctx *cli.Context) {
 	}
 
 	tx, err := cli.sendTransaction(wavelet.NewTransaction(
-		cli.keys, sys.TagStake, payload.Marshal(),
+		cli.keys, cli.ledger.NextNonce(), sys.TagStake, payload.Marshal(),
 	))
 
 	if err != nil {
@@ -565,6 +749,141 @@ func (cli *CLI) withdrawReward(ctx *cli.Context) {
 		Msgf("Success! Your reward withdrawal transaction ID: %x", tx.ID)
 }
 
+func (cli *CLI) delegateStake(ctx *cli.Context) {
+	var cmd = ctx.Args()
+
+	if len(cmd) < 2 {
+		cli.logger.Error().
+			Msg("Invalid usage: delegate-stake <validator> <amount>")
+		return
+	}
+
+	validator, err := hex.DecodeString(cmd[0])
+	if err != nil {
+		cli.logger.Error().Err(err).
+			Msg("The validator you specified is invalid.")
+		return
+	}
+
+	if len(validator) != wavelet.SizeAccountID {
+		cli.logger.Error().Int("length", len(validator)).
+			Msg("You have specified an invalid account ID to find.")
+		return
+	}
+
+	amount, err := strconv.ParseUint(cmd[1], 10, 64)
+	if err != nil {
+		cli.logger.Error().Err(err).
+			Msg("Failed to convert delegation amount to a uint64.")
+		return
+	}
+
+	payload := wavelet.Stake{
+		Opcode: sys.DelegateStake,
+		Amount: amount,
+	}
+
+	copy(payload.Validator[:], validator)
+
+	tx, err := cli.sendTransaction(wavelet.NewTransaction(
+		cli.keys, cli.ledger.NextNonce(), sys.TagStake, payload.Marshal(),
+	))
+
+	if err != nil {
+		return
+	}
+
+	cli.logger.Info().
+		Msgf("Success! Your delegation transaction ID: %x", tx.ID)
+}
+
+func (cli *CLI) undelegateStake(ctx *cli.Context) {
+	var cmd = ctx.Args()
+
+	if len(cmd) < 2 {
+		cli.logger.Error().
+			Msg("Invalid usage: undelegate-stake <validator> <amount>")
+		return
+	}
+
+	validator, err := hex.DecodeString(cmd[0])
+	if err != nil {
+		cli.logger.Error().Err(err).
+			Msg("The validator you specified is invalid.")
+		return
+	}
+
+	if len(validator) != wavelet.SizeAccountID {
+		cli.logger.Error().Int("length", len(validator)).
+			Msg("You have specified an invalid account ID to find.")
+		return
+	}
+
+	amount, err := strconv.ParseUint(cmd[1], 10, 64)
+	if err != nil {
+		cli.logger.Error().Err(err).
+			Msg("Failed to convert undelegation amount to a uint64.")
+		return
+	}
+
+	payload := wavelet.Stake{
+		Opcode: sys.UndelegateStake,
+		Amount: amount,
+	}
+
+	copy(payload.Validator[:], validator)
+
+	tx, err := cli.sendTransaction(wavelet.NewTransaction(
+		cli.keys, cli.ledger.NextNonce(), sys.TagStake, payload.Marshal(),
+	))
+
+	if err != nil {
+		return
+	}
+
+	cli.logger.Info().
+		Msgf("Success! Your undelegation transaction ID: %x", tx.ID)
+}
+
+func (cli *CLI) setCommission(ctx *cli.Context) {
+	var cmd = ctx.Args()
+
+	if len(cmd) < 1 {
+		cli.logger.Error().
+			Msg("Invalid usage: set-commission <basis points>")
+		return
+	}
+
+	commission, err := strconv.ParseUint(cmd[0], 10, 64)
+	if err != nil {
+		cli.logger.Error().Err(err).
+			Msg("Failed to convert commission to a uint64.")
+		return
+	}
+
+	if commission > sys.MaxCommission {
+		cli.logger.Error().Uint64("max_commission", sys.MaxCommission).
+			Msg("Commission may not exceed 10000 basis points.")
+		return
+	}
+
+	payload := wavelet.Stake{
+		Opcode: sys.SetCommission,
+		Amount: commission,
+	}
+
+	tx, err := cli.sendTransaction(wavelet.NewTransaction(
+		cli.keys, cli.ledger.NextNonce(), sys.TagStake, payload.Marshal(),
+	))
+
+	if err != nil {
+		return
+	}
+
+	cli.logger.Info().
+		Msgf("Success! Your commission transaction ID: %x", tx.ID)
+}
+
 func (cli *CLI) sendTransaction(tx wavelet.Transaction) (wavelet.Transaction, error) {
 	tx = wavelet.AttachSenderToTransaction(
 		cli.keys, tx, cli.ledger.Graph().FindEligibleParents()...,
@@ -580,6 +899,8 @@ func (cli *CLI) sendTransaction(tx wavelet.Transaction) (wavelet.Transaction, er
 				Hex("tx_id", tx.ID[:]).
 				Msg("Failed to create your transaction.")
 
+			cli.ledger.ReleaseNonce(tx.Nonce)
+
 			return tx, err
 		}
 	}
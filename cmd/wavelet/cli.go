@@ -87,6 +87,12 @@ func NewCLI(client *skademlia.Client, ledger *wavelet.Ledger, keys *skademlia.Ke
 			Aliases:     []string{"c"},
 			Action:      a(c.call),
 			Description: "invoke a function on a smart contract",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "trace",
+					Usage: "execute the function locally without sending a transaction, and print a trace of its execution",
+				},
+			},
 		},
 		{
 			Name:        "find",
@@ -100,6 +106,18 @@ func NewCLI(client *skademlia.Client, ledger *wavelet.Ledger, keys *skademlia.Ke
 			Action:      a(c.spawn),
 			Description: "test deploy a smart contract",
 		},
+		{
+			Name:        "upgrade",
+			Aliases:     []string{"u"},
+			Action:      a(c.upgrade),
+			Description: "upgrade the code of a smart contract you own",
+		},
+		{
+			Name:        "destruct",
+			Aliases:     []string{"d"},
+			Action:      a(c.destruct),
+			Description: "destruct a smart contract you own, refunding its PERLs to a beneficiary",
+		},
 		{
 			Name:        "deposit-gas",
 			Aliases:     []string{"g"},
@@ -118,6 +136,24 @@ func NewCLI(client *skademlia.Client, ledger *wavelet.Ledger, keys *skademlia.Ke
 			Action:      a(c.withdrawStake),
 			Description: "withdraw stake and diminish voting power",
 		},
+		{
+			Name:        "delegate-stake",
+			Aliases:     []string{"ds"},
+			Action:      a(c.delegateStake),
+			Description: "delegate PERLs to a validator as stake",
+		},
+		{
+			Name:        "undelegate-stake",
+			Aliases:     []string{"us"},
+			Action:      a(c.undelegateStake),
+			Description: "withdraw PERLs delegated to a validator",
+		},
+		{
+			Name:        "set-commission",
+			Aliases:     []string{"sc"},
+			Action:      a(c.setCommission),
+			Description: "set the commission taken from rewards of delegators, in basis points",
+		},
 		{
 			Name:        "withdraw-reward",
 			Aliases:     []string{"wr"},
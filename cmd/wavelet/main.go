@@ -58,6 +58,12 @@ type Config struct {
 	APIPort  uint
 	Peers    []string
 	Database string
+	Engine   string
+
+	Archive       bool
+	ArchiveWindow uint64
+
+	Light bool
 }
 
 func main() {
@@ -122,6 +128,28 @@ func main() {
 			Usage:  "Directory path to the database. If empty, a temporary in-memory database will be used instead.",
 			EnvVar: "WAVELET_DB_PATH",
 		}),
+		altsrc.NewStringFlag(cli.StringFlag{
+			Name:   "db.engine",
+			Value:  store.EngineLevelDB,
+			Usage:  "Storage engine of the database, which may either be \"leveldb\" or \"bolt\". BoltDB requires --db to be specified.",
+			EnvVar: "WAVELET_DB_ENGINE",
+		}),
+		altsrc.NewBoolFlag(cli.BoolFlag{
+			Name:   "archive",
+			Usage:  "Keep the state of all accounts at past rounds, such that it may be queried through the HTTP API.",
+			EnvVar: "WAVELET_ARCHIVE",
+		}),
+		altsrc.NewUint64Flag(cli.Uint64Flag{
+			Name:   "archive.window",
+			Value:  0,
+			Usage:  "Number of past rounds to keep the state of accounts for when running as an archive node. If zero, the state of all past rounds are kept forever.",
+			EnvVar: "WAVELET_ARCHIVE_WINDOW",
+		}),
+		altsrc.NewBoolFlag(cli.BoolFlag{
+			Name:   "light",
+			Usage:  "Run as a light client which only follows the headers of finalized rounds, and fetches the state of accounts from peers on demand.",
+			EnvVar: "WAVELET_LIGHT",
+		}),
 		altsrc.NewIntFlag(cli.IntFlag{
 			Name:  "sys.query_timeout",
 			Value: int(sys.QueryTimeout.Seconds()),
@@ -135,12 +163,23 @@ func main() {
 		altsrc.NewUint64Flag(cli.Uint64Flag{
 			Name:  "sys.transaction_fee_amount",
 			Value: sys.TransactionFeeAmount,
+			Usage: "fee paid per logical unit of a transaction",
+		}),
+		altsrc.NewUint64Flag(cli.Uint64Flag{
+			Name:  "sys.transaction_fee_per_kib",
+			Value: sys.TransactionFeePerKiB,
+			Usage: "fee paid per KiB of a transactions payload",
 		}),
 		altsrc.NewUint64Flag(cli.Uint64Flag{
 			Name:  "sys.min_stake",
 			Value: sys.MinimumStake,
 			Usage: "minimum stake to garner validator rewards and have importance in consensus",
 		}),
+		altsrc.NewUint64Flag(cli.Uint64Flag{
+			Name:  "sys.unbonding_rounds",
+			Value: sys.StakeUnbondingRounds,
+			Usage: "number of rounds withdrawn stake is held for before being released into an accounts balance",
+		}),
 		altsrc.NewIntFlag(cli.IntFlag{
 			Name:   "sys.snowball.k",
 			Value:  sys.SnowballK,
@@ -203,6 +242,12 @@ func main() {
 			APIPort:  c.Uint("api.port"),
 			Peers:    c.Args(),
 			Database: c.String("db"),
+			Engine:   c.String("db.engine"),
+
+			Archive:       c.Bool("archive"),
+			ArchiveWindow: c.Uint64("archive.window"),
+
+			Light: c.Bool("light"),
 		}
 
 		if genesis := c.String("genesis"); len(genesis) > 0 {
@@ -218,13 +263,59 @@ func main() {
 		sys.MinDifficulty = byte(c.Int("sys.difficulty.min"))
 		sys.DifficultyScaleFactor = c.Float64("sys.difficulty.scale")
 		sys.TransactionFeeAmount = c.Uint64("sys.transaction_fee_amount")
+		sys.TransactionFeePerKiB = c.Uint64("sys.transaction_fee_per_kib")
 		sys.MinimumStake = c.Uint64("sys.min_stake")
+		sys.StakeUnbondingRounds = c.Uint64("sys.unbonding_rounds")
 
 		start(config)
 
 		return nil
 	}
 
+	app.Commands = []cli.Command{
+		{
+			Name:  "snapshot",
+			Usage: "export or import the state of the ledger at a finalized round to/from a file",
+			Subcommands: []cli.Command{
+				{
+					Name:      "export",
+					Usage:     "export the state of the ledger at a finalized round to a file",
+					ArgsUsage: "<snapshot file>",
+					Flags: []cli.Flag{
+						cli.Uint64Flag{
+							Name:  "round",
+							Usage: "Index of the round to export. If unspecified, the latest round is exported. Past rounds may only be exported from the database of an archive node.",
+						},
+					},
+					Action: func(c *cli.Context) error {
+						var round *uint64
+
+						if c.IsSet("round") {
+							index := c.Uint64("round")
+							round = &index
+						}
+
+						return exportSnapshot(c.GlobalString("db.engine"), c.GlobalString("db"), round, c.Args().Get(0))
+					},
+				},
+				{
+					Name:      "import",
+					Usage:     "seed an empty or outdated database with the state of the ledger held within a snapshot file",
+					ArgsUsage: "<snapshot file>",
+					Action: func(c *cli.Context) error {
+						var genesis *string
+
+						if g := c.GlobalString("genesis"); len(g) > 0 {
+							genesis = &g
+						}
+
+						return importSnapshot(c.GlobalString("db.engine"), c.GlobalString("db"), genesis, c.Args().Get(0))
+					},
+				},
+			},
+		},
+	}
+
 	sort.Sort(cli.FlagsByName(app.Flags))
 	sort.Sort(cli.CommandsByName(app.Commands))
 
@@ -311,12 +402,26 @@ func start(cfg *Config) {
 			Msg("Peer has left.")
 	})
 
-	kv, err := store.NewLevelDB(cfg.Database)
+	kv, err := store.NewKV(cfg.Engine, cfg.Database)
 	if err != nil {
-		logger.Fatal().Err(err).Msgf("Failed to create/open database located at %q.", cfg.Database)
+		logger.Fatal().Err(err).Msgf("Failed to create/open %s database located at %q.", cfg.Engine, cfg.Database)
+	}
+
+	if cfg.Light && cfg.Archive {
+		logger.Fatal().Msg("A node may either run as a light client, or as an archive node, but not both.")
+	}
+
+	var opts []wavelet.LedgerOption
+
+	if cfg.Archive {
+		opts = append(opts, wavelet.WithArchive(cfg.ArchiveWindow))
 	}
 
-	ledger := wavelet.NewLedger(kv, client, cfg.Genesis)
+	if cfg.Light {
+		opts = append(opts, wavelet.WithLight())
+	}
+
+	ledger := wavelet.NewLedger(kv, client, cfg.Genesis, opts...)
 
 	go func() {
 		server := client.Listen()
@@ -356,6 +461,82 @@ func start(cfg *Config) {
 	shell.Start()
 }
 
+func exportSnapshot(engine, db string, round *uint64, path string) error {
+	if len(db) == 0 {
+		return errors.New("a path to the database to export a snapshot from must be specified with --db")
+	}
+
+	if len(path) == 0 {
+		return errors.New("a path to the snapshot file to export to must be specified")
+	}
+
+	kv, err := store.NewKV(engine, db)
+	if err != nil {
+		return fmt.Errorf("failed to open %s database located at %q: %v", engine, db, err)
+	}
+
+	defer kv.Close()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file %q: %v", path, err)
+	}
+
+	defer file.Close()
+
+	header, err := wavelet.ExportSnapshot(kv, round, file)
+	if err != nil {
+		return fmt.Errorf("failed to export snapshot: %v", err)
+	}
+
+	logger := log.Node()
+	logger.Info().
+		Uint64("round", header.Index).
+		Hex("merkle_root", header.Merkle[:]).
+		Str("path", path).
+		Msg("Exported snapshot.")
+
+	return nil
+}
+
+func importSnapshot(engine, db string, genesis *string, path string) error {
+	if len(db) == 0 {
+		return errors.New("a path to the database to import a snapshot into must be specified with --db")
+	}
+
+	if len(path) == 0 {
+		return errors.New("a path to the snapshot file to import must be specified")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot file %q: %v", path, err)
+	}
+
+	defer file.Close()
+
+	kv, err := store.NewKV(engine, db)
+	if err != nil {
+		return fmt.Errorf("failed to create/open %s database located at %q: %v", engine, db, err)
+	}
+
+	defer kv.Close()
+
+	header, err := wavelet.ImportSnapshot(kv, genesis, file)
+	if err != nil {
+		return fmt.Errorf("failed to import snapshot: %v", err)
+	}
+
+	logger := log.Node()
+	logger.Info().
+		Uint64("round", header.Index).
+		Hex("merkle_root", header.Merkle[:]).
+		Str("path", path).
+		Msg("Imported snapshot.")
+
+	return nil
+}
+
 func keys(wallet string) (*skademlia.Keypair, error) {
 	var keys *skademlia.Keypair
 
@@ -207,6 +207,50 @@ func main() {
 				return nil
 			},
 		},
+		{
+			Name:  "poll_events",
+			Usage: "continuously receive events emitted by smart contracts",
+			Flags: append(commonFlags,
+				[]cli.Flag{
+					cli.StringFlag{
+						Name:  "contract_id",
+						Usage: "contract id of events to list (default: all)",
+					},
+					cli.StringFlag{
+						Name:  "topic",
+						Usage: "topic of events to list (default: all)",
+					},
+				}...,
+			),
+			Action: func(c *cli.Context) error {
+				client, err := setup(c)
+				if err != nil {
+					return err
+				}
+
+				// get these optional variables
+				var contractID *string
+				var topic *string
+				if len(c.String("contract_id")) > 0 {
+					tmp := c.String("contract_id")
+					contractID = &tmp
+				}
+				if c.IsSet("topic") {
+					tmp := c.String("topic")
+					topic = &tmp
+				}
+
+				evChan, err := client.PollEvents(nil, contractID, topic)
+				if err != nil {
+					return err
+				}
+
+				for ev := range evChan {
+					output(ev)
+				}
+				return nil
+			},
+		},
 		{
 			Name:  "poll_transactions",
 			Usage: "continuously receive transaction updates",
@@ -366,6 +410,103 @@ func main() {
 				return nil
 			},
 		},
+		{
+			Name:      "get_account_proof",
+			Usage:     "get the balance of an account alongside a merkle proof of it at a round",
+			ArgsUsage: "<account ID>",
+			Flags: append(commonFlags,
+				[]cli.Flag{
+					cli.StringFlag{
+						Name:  "round",
+						Usage: "index of the round to prove the balance at, defaulting to the latest round",
+					},
+				}...,
+			),
+			Action: func(c *cli.Context) error {
+				client, err := setup(c)
+				if err != nil {
+					return err
+				}
+				acctID := c.Args().Get(0)
+
+				var round *uint64
+				if raw := c.String("round"); len(raw) > 0 {
+					tmp, err := strconv.ParseUint(raw, 10, 64)
+					if err != nil {
+						return errors.Wrap(err, "round must be a number")
+					}
+					round = &tmp
+				}
+
+				res, err := client.GetAccountProof(acctID, round)
+				if err != nil {
+					return err
+				}
+
+				buf, err := json.Marshal(res)
+				if err != nil {
+					fmt.Println(err)
+				} else {
+					output(buf)
+				}
+
+				return nil
+			},
+		},
+		{
+			Name:      "get_delegations",
+			Usage:     "get all stake an account has delegated to validators",
+			ArgsUsage: "<account ID>",
+			Flags:     commonFlags,
+			Action: func(c *cli.Context) error {
+				client, err := setup(c)
+				if err != nil {
+					return err
+				}
+				acctID := c.Args().Get(0)
+
+				res, err := client.GetDelegations(acctID)
+				if err != nil {
+					return err
+				}
+
+				buf, err := json.Marshal(res)
+				if err != nil {
+					fmt.Println(err)
+				} else {
+					output(buf)
+				}
+
+				return nil
+			},
+		},
+		{
+			Name:      "get_delegators",
+			Usage:     "get all stake delegated to a validator",
+			ArgsUsage: "<account ID>",
+			Flags:     commonFlags,
+			Action: func(c *cli.Context) error {
+				client, err := setup(c)
+				if err != nil {
+					return err
+				}
+				acctID := c.Args().Get(0)
+
+				res, err := client.GetDelegators(acctID)
+				if err != nil {
+					return err
+				}
+
+				buf, err := json.Marshal(res)
+				if err != nil {
+					fmt.Println(err)
+				} else {
+					output(buf)
+				}
+
+				return nil
+			},
+		},
 		{
 			Name:      "get_contract_code",
 			Usage:     "get the payload of a contract",
@@ -388,6 +529,28 @@ func main() {
 				return nil
 			},
 		},
+		{
+			Name:      "get_contract_abi",
+			Usage:     "get the ABI of a contract",
+			ArgsUsage: "<contract ID>",
+			Flags:     commonFlags,
+			Action: func(c *cli.Context) error {
+				client, err := setup(c)
+				if err != nil {
+					return err
+				}
+				contractID := c.Args().Get(0)
+
+				res, err := client.GetContractABI(contractID)
+				if err != nil {
+					return err
+				}
+
+				output([]byte(res))
+
+				return nil
+			},
+		},
 		{
 			Name:      "get_contract_pages",
 			Usage:     "get the page of a contract",
@@ -424,6 +587,131 @@ func main() {
 				return nil
 			},
 		},
+		{
+			Name:      "call_contract",
+			Usage:     "invoke a smart contract function with named arguments encoded according to its ABI",
+			ArgsUsage: "<contract ID> <function name> [name=value...]",
+			Flags: append(commonFlags,
+				[]cli.Flag{
+					cli.Uint64Flag{
+						Name:  "amount",
+						Usage: "amount of PERLs to send to the contract",
+					},
+					cli.Uint64Flag{
+						Name:  "gas_limit",
+						Usage: "max amount of PERLs to spend on gas",
+					},
+					cli.Uint64Flag{
+						Name:  "gas_deposit",
+						Usage: "amount of PERLs to deposit into the gas balance of the contract",
+					},
+				}...,
+			),
+			Action: func(c *cli.Context) error {
+				client, err := setup(c)
+				if err != nil {
+					return err
+				}
+
+				contractID := c.Args().Get(0)
+				funcName := c.Args().Get(1)
+
+				if funcName == "" {
+					return errors.New("function name is missing")
+				}
+
+				recipient, err := hex.DecodeString(contractID)
+				if err != nil || len(recipient) != wavelet.SizeAccountID {
+					return errors.New("contract ID must be a hex-encoded 32-byte account ID")
+				}
+
+				params, err := encodeABIParams(client, contractID, funcName, c.Args()[2:])
+				if err != nil {
+					return err
+				}
+
+				payload := wavelet.Transfer{
+					Amount:     c.Uint64("amount"),
+					GasLimit:   c.Uint64("gas_limit"),
+					GasDeposit: c.Uint64("gas_deposit"),
+					FuncName:   []byte(funcName),
+					FuncParams: params,
+				}
+				copy(payload.Recipient[:], recipient)
+
+				res, err := client.SendTransaction(byte(sys.TagTransfer), payload.Marshal())
+				if err != nil {
+					return err
+				}
+
+				buf, err := json.Marshal(res)
+				if err != nil {
+					fmt.Println(err)
+				} else {
+					output(buf)
+				}
+
+				return nil
+			},
+		},
+		{
+			Name:      "query_contract",
+			Usage:     "invoke a smart contract function without committing any of its changes",
+			ArgsUsage: "<contract ID> <function name>",
+			Flags: append(commonFlags,
+				[]cli.Flag{
+					cli.StringFlag{
+						Name:  "params",
+						Usage: "hex-encoded function parameters",
+					},
+					cli.Uint64Flag{
+						Name:  "amount",
+						Usage: "amount of PERLs to pretend to send to the contract",
+					},
+					cli.BoolFlag{
+						Name:  "trace",
+						Usage: "trace every host function the contract calls",
+					},
+				}...,
+			),
+			Action: func(c *cli.Context) error {
+				client, err := setup(c)
+				if err != nil {
+					return err
+				}
+
+				contractID := c.Args().Get(0)
+				funcName := c.Args().Get(1)
+
+				if funcName == "" {
+					return errors.New("function name is missing")
+				}
+
+				params, err := hex.DecodeString(c.String("params"))
+				if err != nil {
+					return errors.Wrap(err, "params must be hex-encoded")
+				}
+
+				query := client.QueryContract
+				if c.Bool("trace") {
+					query = client.QueryContractWithTrace
+				}
+
+				res, err := query(contractID, funcName, params, c.Uint64("amount"))
+				if err != nil {
+					return err
+				}
+
+				buf, err := json.Marshal(res)
+				if err != nil {
+					fmt.Println(err)
+				} else {
+					output(buf)
+				}
+
+				return nil
+			},
+		},
 		{
 			Name:      "send_transaction",
 			Usage:     "send a transaction",
@@ -478,6 +766,64 @@ func main() {
 				return nil
 			},
 		},
+		{
+			Name:      "simulate_transaction",
+			Usage:     "simulate applying a transaction on the latest round without sending it",
+			ArgsUsage: "<tag> <json payload>",
+			Flags: append(commonFlags,
+				[]cli.Flag{
+					cli.StringFlag{
+						Name:  "payload",
+						Usage: "the path to the payload file",
+					},
+					cli.Uint64Flag{
+						Name:  "tip",
+						Usage: "the priority tip to pay on top of the transaction fee",
+					},
+				}...,
+			),
+			Action: func(c *cli.Context) error {
+				client, err := setup(c)
+				if err != nil {
+					return err
+				}
+
+				tag, err := strconv.Atoi(c.Args().Get(0))
+				if err != nil {
+					return err
+				}
+
+				payload := bytes.NewBuffer(nil)
+
+				if c.String("payload") != "" {
+					payloadFile, err := ioutil.ReadFile(c.String("payload"))
+					if err != nil {
+						return err
+					}
+
+					parsedPayload, err := wavelet.ParseJSON(payloadFile, c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					payload.Write(parsedPayload)
+				}
+
+				res, err := client.SimulateTransaction(byte(tag), c.Uint64("tip"), payload.Bytes())
+				if err != nil {
+					return err
+				}
+
+				buf, err := json.Marshal(res)
+				if err != nil {
+					fmt.Println(err)
+				} else {
+					output(buf)
+				}
+
+				return nil
+			},
+		},
 		{
 			Name:      "get_transaction",
 			Usage:     "get a transaction",
@@ -505,6 +851,33 @@ func main() {
 				return nil
 			},
 		},
+		{
+			Name:      "get_receipt",
+			Usage:     "get the receipt of a finalized transaction",
+			ArgsUsage: "<transaction ID>",
+			Flags:     commonFlags,
+			Action: func(c *cli.Context) error {
+				client, err := setup(c)
+				if err != nil {
+					return err
+				}
+				txID := c.Args().Get(0)
+
+				res, err := client.GetReceipt(txID)
+				if err != nil {
+					return err
+				}
+
+				buf, err := json.Marshal(res)
+				if err != nil {
+					fmt.Println(err)
+				} else {
+					output(buf)
+				}
+
+				return nil
+			},
+		},
 		{
 			Name:  "list_transactions",
 			Usage: "list recent transactions",
@@ -571,6 +944,72 @@ func main() {
 				return nil
 			},
 		},
+		{
+			Name:  "list_events",
+			Usage: "list events emitted by smart contracts within a range of rounds",
+			Flags: append(commonFlags,
+				[]cli.Flag{
+					cli.StringFlag{
+						Name:  "contract_id",
+						Usage: "contract id of events to list (default: all)",
+					},
+					cli.StringFlag{
+						Name:  "topic",
+						Usage: "topic of events to list (default: all)",
+					},
+					cli.Uint64Flag{
+						Name:  "from",
+						Usage: "index of the first round to list events from (default: to)",
+					},
+					cli.Uint64Flag{
+						Name:  "to",
+						Usage: "index of the last round to list events from (default: latest round)",
+					},
+				}...,
+			),
+			Action: func(c *cli.Context) error {
+				client, err := setup(c)
+				if err != nil {
+					return err
+				}
+
+				// get these optional variables
+				var contractID *string
+				var topic *string
+				var from *uint64
+				var to *uint64
+				if len(c.String("contract_id")) > 0 {
+					tmp := c.String("contract_id")
+					contractID = &tmp
+				}
+				if c.IsSet("topic") {
+					tmp := c.String("topic")
+					topic = &tmp
+				}
+				if c.IsSet("from") {
+					tmp := c.Uint64("from")
+					from = &tmp
+				}
+				if c.IsSet("to") {
+					tmp := c.Uint64("to")
+					to = &tmp
+				}
+
+				res, err := client.ListEvents(contractID, topic, from, to)
+				if err != nil {
+					return err
+				}
+
+				buf, err := json.Marshal(res)
+				if err != nil {
+					fmt.Println(err)
+				} else {
+					output(buf)
+				}
+
+				return nil
+			},
+		},
 		{
 			Name:  "poll_metrics",
 			Usage: "continuously receive metrics",
@@ -648,6 +1087,31 @@ func setup(c *cli.Context) (*wctl.Client, error) {
 	return client, nil
 }
 
+// Encode named arguments into a function payload according to the ABI of a smart contract.
+func encodeABIParams(client *wctl.Client, contractID string, funcName string, args []string) ([]byte, error) {
+	raw, err := client.GetContractABI(contractID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the ABI of the contract")
+	}
+
+	abi, err := wavelet.ParseContractABI([]byte(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	fn, exists := abi.Function(funcName)
+	if !exists {
+		return nil, errors.Errorf("function %q is not declared in the ABI of the contract", funcName)
+	}
+
+	parsed, err := wavelet.ParseABIArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn.EncodeParams(parsed)
+}
+
 // Write bytes to stdout; do JSON indent if possible.
 func output(buf []byte) {
 	var out bytes.Buffer
@@ -20,6 +20,7 @@
 package wavelet
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
@@ -29,8 +30,27 @@ import (
 	queue2 "github.com/phf/go-queue/queue"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/blake2b"
+	"math"
+	"math/bits"
 )
 
+// processUnbondingRequests releases all stake withdrawn at least sys.StakeUnbondingRounds rounds
+// ago into the balances of the accounts that withdrew it.
+func processUnbondingRequests(round uint64, snapshot *avl.Tree) {
+	if round < sys.StakeUnbondingRounds {
+		return
+	}
+
+	urs := GetUnbondingRequests(snapshot, round-sys.StakeUnbondingRounds)
+
+	for _, ur := range urs {
+		balance, _ := ReadAccountBalance(snapshot, ur.Account)
+		WriteAccountBalance(snapshot, ur.Account, balance+ur.Amount)
+
+		DeleteUnbondingRequest(snapshot, ur)
+	}
+}
+
 func processRewardWithdrawals(round uint64, snapshot *avl.Tree) {
 	rws := GetRewardWithdrawalRequests(snapshot, round-uint64(sys.RewardWithdrawalsRoundLimit))
 
@@ -42,15 +62,48 @@ func processRewardWithdrawals(round uint64, snapshot *avl.Tree) {
 	}
 }
 
-// rewardValidators deducts a transaction fee from a transactions creator, and transfers the fee
-// to a rewardee which is determined by a validator reward scheme given the selected ancestry
+// applyCreatorNonce checks that the nonce signed by the creator of a transaction is its
+// account nonce, and bumps the creators account nonce by one. An account nonce denotes the
+// nonce the creator must sign their next transaction with, such that a transaction may never
+// be re-wrapped by a different sender and applied twice, and such that the transactions of
+// a creator are applied in the order they were signed without gaps.
+func applyCreatorNonce(snapshot *avl.Tree, tx *Transaction) error {
+	nonce, exists := ReadAccountNonce(snapshot, tx.Creator)
+
+	if tx.Nonce < nonce {
+		return errors.Errorf("nonce: creator %x signed transaction with nonce %d, but its account nonce is already %d", tx.Creator, tx.Nonce, nonce)
+	}
+
+	if tx.Nonce > nonce {
+		return errors.Errorf("nonce: creator %x signed transaction with nonce %d, but its account nonce is only %d", tx.Creator, tx.Nonce, nonce)
+	}
+
+	if tx.Nonce == math.MaxUint64 {
+		return errors.Errorf("nonce: creator %x signed transaction with the max nonce %d", tx.Creator, tx.Nonce)
+	}
+
+	if !exists {
+		WriteAccountsLen(snapshot, ReadAccountsLen(snapshot)+1)
+	}
+
+	WriteAccountNonce(snapshot, tx.Creator, tx.Nonce+1)
+
+	return nil
+}
+
+// rewardValidators deducts a transaction fee alongside any priority tip from a transactions creator,
+// and transfers the fee to a rewardee which is determined by a validator reward scheme given the selected ancestry
 // of the transaction.
 //
 // If no rewardee is selected, then the transaction fee is simply burned. A reference to
 // a transaction is expected when calling this function to prevent any additional requirements
 // of looking up said transaction within the graph.
 func rewardValidators(g *Graph, snapshot *avl.Tree, tx *Transaction, logging bool) error {
-	fee := sys.TransactionFeeAmount
+	fee := tx.Fee() + tx.Tip
+
+	if fee < tx.Tip {
+		return errors.Errorf("stake: creator %x specified a tip of %d PERLs which overflows its transaction fee", tx.Creator, tx.Tip)
+	}
 
 	creatorBalance, _ := ReadAccountBalance(snapshot, tx.Creator)
 
@@ -101,7 +154,7 @@ func rewardValidators(g *Graph, snapshot *avl.Tree, tx *Transaction, logging boo
 		// and within the desired graph depth.
 
 		if popped.Sender != tx.Sender {
-			stake, _ := ReadAccountStake(snapshot, popped.Sender)
+			stake := ReadAccountTotalStake(snapshot, popped.Sender)
 
 			if stake > sys.MinimumStake {
 				candidates = append(candidates, popped)
@@ -157,8 +210,7 @@ func rewardValidators(g *Graph, snapshot *avl.Tree, tx *Transaction, logging boo
 		rewardee = candidates[len(candidates)-1]
 	}
 
-	rewardeeBalance, _ := ReadAccountReward(snapshot, rewardee.Sender)
-	WriteAccountReward(snapshot, rewardee.Sender, rewardeeBalance+fee)
+	distributeReward(snapshot, rewardee.Sender, fee)
 
 	if logging {
 		logger := log.Stake("reward_validator")
@@ -175,6 +227,111 @@ func rewardValidators(g *Graph, snapshot *avl.Tree, tx *Transaction, logging boo
 	return nil
 }
 
+// distributeReward credits a reward earned by a validator to the validator and its delegators. The
+// portion of the reward earned by stake delegated to the validator is split pro rata amongst its
+// delegators, after the validator takes its commission out of it. The validator is credited with
+// the remainder of the reward.
+func distributeReward(snapshot *avl.Tree, validator AccountID, reward uint64) {
+	remainder := reward
+
+	if delegated, _ := ReadAccountDelegatedStake(snapshot, validator); delegated > 0 {
+		commission, _ := ReadAccountCommission(snapshot, validator)
+
+		share := mulDiv(reward, delegated, ReadAccountTotalStake(snapshot, validator))
+		share -= mulDiv(share, commission, sys.MaxCommission)
+
+		for _, delegation := range ReadValidatorDelegations(snapshot, validator) {
+			amount := mulDiv(share, delegation.Amount, delegated)
+			if amount == 0 {
+				continue
+			}
+
+			delegatorReward, _ := ReadAccountReward(snapshot, delegation.Delegator)
+			WriteAccountReward(snapshot, delegation.Delegator, delegatorReward+amount)
+
+			remainder -= amount
+		}
+	}
+
+	validatorReward, _ := ReadAccountReward(snapshot, validator)
+	WriteAccountReward(snapshot, validator, validatorReward+remainder)
+}
+
+// mulDiv computes x * y / z without overflowing, given that y <= z.
+func mulDiv(x, y, z uint64) uint64 {
+	hi, lo := bits.Mul64(x, y)
+	quo, _ := bits.Div64(hi, lo, z)
+
+	return quo
+}
+
+// BalanceDelta denotes the PERL balance of an account before and after a transaction was applied.
+type BalanceDelta struct {
+	Account AccountID
+	Before  uint64
+	After   uint64
+}
+
+// SimulationResult denotes the outcome of simulating the application of a transaction.
+type SimulationResult struct {
+	Applied bool
+	Error   error
+
+	Fee uint64
+	Gas uint64
+
+	Deltas []BalanceDelta
+}
+
+// SimulateTransaction applies a transaction on top of a snapshot of the ledgers state the exact
+// same way it would be applied should it be finalized in the round succeeding round, and reports
+// whether or not it was applied, the fees and gas it expended, and every change in account
+// balances it made. The snapshot is modified, and is expected to be discarded afterwards.
+func SimulateTransaction(g *Graph, snapshot *avl.Tree, round *Round, tx *Transaction) SimulationResult {
+	original := snapshot.Snapshot()
+	snapshot.SetViewID(round.Index + 1)
+
+	res := SimulationResult{Fee: tx.Fee() + tx.Tip}
+
+	state := &contractExecutorState{GasPayer: tx.Creator}
+
+	res.Error = applyCreatorNonce(snapshot, tx)
+
+	// FIXME(kenta): FOR TESTNET ONLY. FAUCET DOES NOT GET ANY PERLs DEDUCTED.
+	if res.Error == nil && hex.EncodeToString(tx.Creator[:]) != sys.FaucetAddress {
+		res.Error = rewardValidators(g, snapshot, tx, false)
+	}
+
+	if res.Error == nil {
+		res.Error = applyTransaction(round, snapshot, tx, state)
+	}
+
+	res.Applied = res.Error == nil
+	res.Gas = state.GasConsumed
+
+	balanceKey := append(keyAccounts[:], keyAccountBalance[:]...)
+
+	snapshot.IterateLeafDiff(round.Index, func(key, value []byte) bool {
+		if !bytes.HasPrefix(key, balanceKey) {
+			return true
+		}
+
+		var id AccountID
+		copy(id[:], key[len(balanceKey):])
+
+		before, _ := ReadAccountBalance(original, id)
+		after := binary.LittleEndian.Uint64(value)
+
+		if before != after {
+			res.Deltas = append(res.Deltas, BalanceDelta{Account: id, Before: before, After: after})
+		}
+
+		return true
+	})
+
+	return res
+}
+
 func collapseTransactions(g *Graph, accounts *Accounts, round uint64, current *Round, start, end Transaction, logging bool) (*collapseResults, error) {
 	res := &collapseResults{snapshot: accounts.Snapshot()}
 	res.snapshot.SetViewID(round)
@@ -216,6 +373,21 @@ func collapseTransactions(g *Graph, accounts *Accounts, round uint64, current *R
 	res.applied = make([]*Transaction, 0, order.Len())
 	res.rejected = make([]*Transaction, 0, order.Len())
 	res.rejectedErrors = make([]error, 0, order.Len())
+	res.receipts = make([]Receipt, 0, order.Len())
+
+	reject := func(tx *Transaction, err error, fee uint64) {
+		res.rejected = append(res.rejected, tx)
+		res.rejectedErrors = append(res.rejectedErrors, err)
+		res.rejectedCount += tx.LogicalUnits()
+
+		res.receipts = append(res.receipts, Receipt{
+			TxID:   tx.ID,
+			Round:  round,
+			Status: ReceiptStatusRejected,
+			Error:  err.Error(),
+			Fee:    fee,
+		})
+	}
 
 	// Apply transactions in reverse order from the end of the round
 	// all the way down to the beginning of the round.
@@ -225,33 +397,48 @@ func collapseTransactions(g *Graph, accounts *Accounts, round uint64, current *R
 
 		// Update nonce.
 
-		nonce, exists := ReadAccountNonce(res.snapshot, popped.Creator)
-		if !exists {
-			WriteAccountsLen(res.snapshot, ReadAccountsLen(res.snapshot)+1)
+		if err := applyCreatorNonce(res.snapshot, popped); err != nil {
+			reject(popped, err, 0)
+			continue
 		}
-		WriteAccountNonce(res.snapshot, popped.Creator, nonce+1)
+
+		var fee uint64
 
 		// FIXME(kenta): FOR TESTNET ONLY. FAUCET DOES NOT GET ANY PERLs DEDUCTED.
 		if hex.EncodeToString(popped.Creator[:]) != sys.FaucetAddress {
 			if err := rewardValidators(g, res.snapshot, popped, logging); err != nil {
-				res.rejected = append(res.rejected, popped)
-				res.rejectedErrors = append(res.rejectedErrors, err)
-				res.rejectedCount += popped.LogicalUnits()
-
+				reject(popped, err, 0)
 				continue
 			}
+
+			fee = popped.Fee() + popped.Tip
 		}
 
-		if err := ApplyTransaction(current, res.snapshot, popped); err != nil {
-			res.rejected = append(res.rejected, popped)
-			res.rejectedErrors = append(res.rejectedErrors, err)
-			res.rejectedCount += popped.LogicalUnits()
+		state := &contractExecutorState{GasPayer: popped.Creator}
+
+		if err := applyTransaction(current, res.snapshot, popped, state); err != nil {
+			reject(popped, err, fee)
 
 			fmt.Println(err)
 
 			continue
 		}
 
+		for i := range state.Events {
+			state.Events[i].Round = round
+		}
+
+		res.events = append(res.events, state.Events...)
+
+		res.receipts = append(res.receipts, Receipt{
+			TxID:   popped.ID,
+			Round:  round,
+			Status: ReceiptStatusApplied,
+			Fee:    fee,
+			Gas:    state.GasConsumed,
+			Events: state.Events,
+		})
+
 		// Update statistics.
 
 		res.applied = append(res.applied, popped)
@@ -270,5 +457,7 @@ func collapseTransactions(g *Graph, accounts *Accounts, round uint64, current *R
 		processRewardWithdrawals(round, res.snapshot)
 	}
 
+	processUnbondingRequests(round, res.snapshot)
+
 	return res, nil
 }
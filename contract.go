@@ -23,6 +23,7 @@ import (
 	"crypto/sha256"
 	"crypto/sha512"
 	"encoding/binary"
+	"fmt"
 	"github.com/perlin-network/life/compiler"
 	"github.com/perlin-network/life/exec"
 	"github.com/perlin-network/life/utils"
@@ -56,21 +57,71 @@ type ContractExecutor struct {
 	Payload []byte
 	Error   []byte
 
+	// Data returned by the smart contract through _return, which is handed back to the smart
+	// contract that synchronously called it.
+	Return []byte
+
 	Queue []*Transaction
+
+	// Gas schedule the smart contract is charged by.
+	Schedule sys.GasSchedule
+
+	// Lines logged by the smart contract through _log.
+	Logs []string
+
+	// Events emitted by the smart contract through _emit_event, alongside those emitted by
+	// the smart contracts it has successfully called.
+	Events []Event
+
+	// Data returned by the last smart contract synchronously called by this smart contract
+	// through _return, or the error it reported through _result should the call have failed.
+	CallResult []byte
+
+	// Trace of the smart contracts execution, recorded only should it be non-nil.
+	Trace *ContractTrace
+
+	round  *Round
+	tx     *Transaction
+	amount uint64
+	caller *ContractExecutor
+	failed bool
 }
 
 func (e *ContractExecutor) GetCost(key string) int64 {
-	// FIXME(kenta): Remove for testnet.
-	return 1
-	//cost, ok := sys.GasTable[key]
-	//if !ok {
-	//	return 1
-	//}
-	//
-	//return int64(cost)
+	return int64(e.Schedule.Cost(key))
 }
 
 func (e *ContractExecutor) ResolveFunc(module, field string) exec.FunctionImport {
+	f := e.resolveFunc(module, field)
+
+	if e.Trace == nil {
+		return f
+	}
+
+	return func(vm *exec.VirtualMachine) int64 {
+		idx := e.Trace.record(ContractTraceEntry{
+			Kind:     TraceHostCall,
+			Contract: e.ID,
+			Depth:    e.depth(),
+			Name:     field,
+			Args:     append([]int64{}, vm.GetCurrentFrame().Locals...),
+		})
+
+		// Host functions panic should they run out of gas, in which case the gas
+		// recorded is the gas used right before the panic.
+
+		defer func() {
+			e.Trace.Entries[idx].Gas = vm.Gas
+		}()
+
+		result := f(vm)
+		e.Trace.Entries[idx].Result = result
+
+		return result
+	}
+}
+
+func (e *ContractExecutor) resolveFunc(module, field string) exec.FunctionImport {
 	switch module {
 	case "env":
 		switch field {
@@ -80,6 +131,8 @@ func (e *ContractExecutor) ResolveFunc(module, field string) exec.FunctionImport
 			}
 		case "_send_transaction":
 			return func(vm *exec.VirtualMachine) int64 {
+				vm.AddAndCheckGas(uint64(e.GetCost("wavelet.send_transaction")))
+
 				frame := vm.GetCurrentFrame()
 
 				tag := byte(uint32(frame.Locals[0]))
@@ -99,20 +152,197 @@ func (e *ContractExecutor) ResolveFunc(module, field string) exec.FunctionImport
 
 				return 0
 			}
+		case "_call_contract":
+			return func(vm *exec.VirtualMachine) int64 {
+				vm.AddAndCheckGas(uint64(e.GetCost("wavelet.call_contract")))
+
+				frame := vm.GetCurrentFrame()
+				idPtr, idLen := int(uint32(frame.Locals[0])), int(uint32(frame.Locals[1]))
+				namePtr, nameLen := int(uint32(frame.Locals[2])), int(uint32(frame.Locals[3]))
+				paramsPtr, paramsLen := int(uint32(frame.Locals[4])), int(uint32(frame.Locals[5]))
+
+				if idLen != SizeAccountID {
+					return 1
+				}
+
+				var id AccountID
+				copy(id[:], vm.Memory[idPtr:idPtr+idLen])
+
+				name := string(vm.Memory[namePtr : namePtr+nameLen])
+
+				params := make([]byte, paramsLen)
+				copy(params, vm.Memory[paramsPtr:paramsPtr+paramsLen])
+
+				if err := e.callContract(vm, id, name, params); err != nil {
+					logger := log.Contracts("call")
+					logger.Debug().
+						Hex("contract_id", e.ID[:]).
+						Hex("callee_id", id[:]).
+						Err(err).
+						Msg("Failed to call smart contract.")
+
+					return 1
+				}
+
+				return 0
+			}
+		case "_call_result_len":
+			return func(vm *exec.VirtualMachine) int64 {
+				vm.AddAndCheckGas(e.dataCost("wavelet.call_result_len", "wavelet.call_result_byte", 0))
+
+				return int64(len(e.CallResult))
+			}
+		case "_call_result":
+			return func(vm *exec.VirtualMachine) int64 {
+				vm.AddAndCheckGas(e.dataCost("wavelet.call_result", "wavelet.call_result_byte", len(e.CallResult)))
+
+				frame := vm.GetCurrentFrame()
+
+				outPtr := int(uint32(frame.Locals[0]))
+				copy(vm.Memory[outPtr:], e.CallResult)
+				return 0
+			}
+		case "_storage_get":
+			return func(vm *exec.VirtualMachine) int64 {
+				frame := vm.GetCurrentFrame()
+				keyPtr, keyLen := int(uint32(frame.Locals[0])), int(uint32(frame.Locals[1]))
+				outPtr, outLen := int(uint32(frame.Locals[2])), int(uint32(frame.Locals[3]))
+
+				key := vm.Memory[keyPtr : keyPtr+keyLen]
+				value, exists := ReadAccountContractStorage(e.Snapshot, e.ID, key)
+
+				vm.AddAndCheckGas(e.dataCost("wavelet.storage.get", "wavelet.storage.read_byte", len(key)+len(value)))
+
+				if !exists {
+					return -1
+				}
+
+				copy(vm.Memory[outPtr:outPtr+outLen], value)
+				return int64(len(value))
+			}
+		case "_storage_set":
+			return func(vm *exec.VirtualMachine) int64 {
+				frame := vm.GetCurrentFrame()
+				keyPtr, keyLen := int(uint32(frame.Locals[0])), int(uint32(frame.Locals[1]))
+				valuePtr, valueLen := int(uint32(frame.Locals[2])), int(uint32(frame.Locals[3]))
+
+				vm.AddAndCheckGas(e.dataCost("wavelet.storage.set", "wavelet.storage.write_byte", keyLen+valueLen))
+
+				key := make([]byte, keyLen)
+				copy(key, vm.Memory[keyPtr:keyPtr+keyLen])
+
+				value := make([]byte, valueLen)
+				copy(value, vm.Memory[valuePtr:valuePtr+valueLen])
+
+				WriteAccountContractStorage(e.Snapshot, e.ID, key, value)
+				return 0
+			}
+		case "_storage_delete":
+			return func(vm *exec.VirtualMachine) int64 {
+				frame := vm.GetCurrentFrame()
+				keyPtr, keyLen := int(uint32(frame.Locals[0])), int(uint32(frame.Locals[1]))
+
+				vm.AddAndCheckGas(e.dataCost("wavelet.storage.delete", "wavelet.storage.write_byte", keyLen))
+
+				if !DeleteAccountContractStorage(e.Snapshot, e.ID, vm.Memory[keyPtr:keyPtr+keyLen]) {
+					return 1
+				}
+
+				return 0
+			}
+		case "_storage_iter":
+			return func(vm *exec.VirtualMachine) int64 {
+				frame := vm.GetCurrentFrame()
+				startPtr, startLen := int(uint32(frame.Locals[0])), int(uint32(frame.Locals[1]))
+				outPtr, outLen := int(uint32(frame.Locals[2])), int(uint32(frame.Locals[3]))
+
+				start := vm.Memory[startPtr : startPtr+startLen]
+				key, exists := NextAccountContractStorageKey(e.Snapshot, e.ID, start)
+
+				vm.AddAndCheckGas(e.dataCost("wavelet.storage.iter", "wavelet.storage.read_byte", len(start)+len(key)))
+
+				if !exists {
+					return -1
+				}
+
+				copy(vm.Memory[outPtr:outPtr+outLen], key)
+				return int64(len(key))
+			}
 		case "_payload_len":
 			return func(vm *exec.VirtualMachine) int64 {
+				vm.AddAndCheckGas(uint64(e.GetCost("wavelet.payload_len")))
+
 				return int64(len(e.Payload))
 			}
 		case "_payload":
 			return func(vm *exec.VirtualMachine) int64 {
+				vm.AddAndCheckGas(uint64(e.GetCost("wavelet.payload")))
+
 				frame := vm.GetCurrentFrame()
 
 				outPtr := int(uint32(frame.Locals[0]))
 				copy(vm.Memory[outPtr:], e.Payload)
 				return 0
 			}
+		case "_round_index":
+			return func(vm *exec.VirtualMachine) int64 {
+				vm.AddAndCheckGas(uint64(e.GetCost("wavelet.round_index")))
+
+				if e.round == nil {
+					return 0
+				}
+
+				return int64(e.round.Index)
+			}
+		case "_round_id":
+			return func(vm *exec.VirtualMachine) int64 {
+				vm.AddAndCheckGas(uint64(e.GetCost("wavelet.round_id")))
+
+				var id RoundID
+				if e.round != nil {
+					id = e.round.ID
+				}
+
+				outPtr := int(uint32(vm.GetCurrentFrame().Locals[0]))
+				copy(vm.Memory[outPtr:], id[:])
+				return 0
+			}
+		case "_tx_id":
+			return func(vm *exec.VirtualMachine) int64 {
+				vm.AddAndCheckGas(uint64(e.GetCost("wavelet.tx_id")))
+
+				var id TransactionID
+				if e.tx != nil {
+					id = e.tx.ID
+				}
+
+				outPtr := int(uint32(vm.GetCurrentFrame().Locals[0]))
+				copy(vm.Memory[outPtr:], id[:])
+				return 0
+			}
+		case "_sender_id":
+			return func(vm *exec.VirtualMachine) int64 {
+				vm.AddAndCheckGas(uint64(e.GetCost("wavelet.sender_id")))
+
+				var id AccountID
+				if e.tx != nil {
+					id = e.tx.Creator
+				}
+
+				outPtr := int(uint32(vm.GetCurrentFrame().Locals[0]))
+				copy(vm.Memory[outPtr:], id[:])
+				return 0
+			}
+		case "_amount":
+			return func(vm *exec.VirtualMachine) int64 {
+				vm.AddAndCheckGas(uint64(e.GetCost("wavelet.amount")))
+
+				return int64(e.amount)
+			}
 		case "_result":
 			return func(vm *exec.VirtualMachine) int64 {
+				vm.AddAndCheckGas(uint64(e.GetCost("wavelet.result")))
+
 				frame := vm.GetCurrentFrame()
 				dataPtr := int(uint32(frame.Locals[0]))
 				dataLen := int(uint32(frame.Locals[1]))
@@ -121,22 +351,62 @@ func (e *ContractExecutor) ResolveFunc(module, field string) exec.FunctionImport
 				copy(e.Error, vm.Memory[dataPtr:dataPtr+dataLen])
 				return 0
 			}
+		case "_return":
+			return func(vm *exec.VirtualMachine) int64 {
+				frame := vm.GetCurrentFrame()
+				dataPtr := int(uint32(frame.Locals[0]))
+				dataLen := int(uint32(frame.Locals[1]))
+
+				vm.AddAndCheckGas(e.dataCost("wavelet.return", "wavelet.return_byte", dataLen))
+
+				e.Return = make([]byte, dataLen)
+				copy(e.Return, vm.Memory[dataPtr:dataPtr+dataLen])
+				return 0
+			}
 		case "_log":
 			return func(vm *exec.VirtualMachine) int64 {
+				vm.AddAndCheckGas(uint64(e.GetCost("wavelet.log")))
+
 				frame := vm.GetCurrentFrame()
 				dataPtr := int(uint32(frame.Locals[0]))
 				dataLen := int(uint32(frame.Locals[1]))
 
+				line := string(vm.Memory[dataPtr : dataPtr+dataLen])
+				e.Logs = append(e.Logs, line)
+
 				logger := log.Contracts("log")
 				logger.Debug().
 					Hex("contract_id", e.ID[:]).
-					Msg(string(vm.Memory[dataPtr : dataPtr+dataLen]))
+					Msg(line)
 
 				return 0
 			}
+		case "_emit_event":
+			return func(vm *exec.VirtualMachine) int64 {
+				frame := vm.GetCurrentFrame()
+				topicPtr, topicLen := int(uint32(frame.Locals[0])), int(uint32(frame.Locals[1]))
+				dataPtr, dataLen := int(uint32(frame.Locals[2])), int(uint32(frame.Locals[3]))
+
+				vm.AddAndCheckGas(e.dataCost("wavelet.emit_event", "wavelet.event_byte", topicLen+dataLen))
+
+				event := Event{
+					Contract: e.ID,
+					Topic:    string(vm.Memory[topicPtr : topicPtr+topicLen]),
+					Data:     make([]byte, dataLen),
+				}
+
+				if e.tx != nil {
+					event.TxID = e.tx.ID
+				}
+
+				copy(event.Data, vm.Memory[dataPtr:dataPtr+dataLen])
+
+				e.Events = append(e.Events, event)
+				return 0
+			}
 		case "_verify_ed25519":
 			return func(vm *exec.VirtualMachine) int64 {
-				vm.Gas += uint64(e.GetCost("wavelet.verify.ed25519"))
+				vm.AddAndCheckGas(uint64(e.GetCost("wavelet.verify.ed25519")))
 
 				frame := vm.GetCurrentFrame()
 				keyPtr, keyLen := int(uint32(frame.Locals[0])), int(uint32(frame.Locals[1]))
@@ -225,18 +495,45 @@ func (e *ContractExecutor) Execute(snapshot *avl.Tree, id AccountID, round *Roun
 		GasLimit:          gasLimit,
 	}
 
+	// Select the gas schedule before the contract gets compiled, as instruction costs
+	// are baked into the contracts code upon compilation. The round provided is the
+	// latest finalized round, and so the contract is being executed within the round
+	// that follows it.
+
+	if round != nil {
+		e.Schedule = sys.GasScheduleAt(round.Index + 1)
+	} else {
+		e.Schedule = sys.GasScheduleAt(0)
+	}
+
 	vm, err := exec.NewVirtualMachine(code, config, e, e)
 	if err != nil {
 		return errors.Wrap(err, "could not init vm")
 	}
 
-	if mem := LoadContractMemorySnapshot(snapshot, id); mem != nil {
-		vm.Memory = mem
+	if err := e.meterMemoryGrowth(vm); err != nil {
+		return errors.Wrap(err, "could not init vm")
+	}
+
+	// Smart contracts only have their memory persisted in between invocations should they opt
+	// in by exporting a function named "_memory_snapshot". Otherwise, smart contracts are
+	// expected to persist their state through their storage.
+
+	_, persistMemory := vm.GetFunctionExport("_memory_snapshot")
+
+	if persistMemory {
+		if mem := LoadContractMemorySnapshot(snapshot, id); mem != nil {
+			vm.Memory = mem
+		}
 	}
 
 	e.ID = id
 	e.Snapshot = snapshot
 
+	e.round = round
+	e.tx = tx
+	e.amount = amount
+
 	e.Payload = buildContractPayload(round, tx, amount, params)
 
 	entry, exists := vm.GetFunctionExport("_contract_" + name)
@@ -259,10 +556,24 @@ func (e *ContractExecutor) Execute(snapshot *avl.Tree, id AccountID, round *Roun
 		}
 	}
 
-	if vm.ExitError == nil && len(e.Error) == 0 {
+	if e.Trace != nil {
+		reason := "ok"
+
+		if vm.ExitError != nil {
+			reason = utils.UnifyError(vm.ExitError).Error()
+		} else if len(e.Error) != 0 {
+			reason = fmt.Sprintf("result: %s", e.Error)
+		}
+
+		e.Trace.record(ContractTraceEntry{Kind: TraceExit, Contract: id, Depth: e.depth(), Gas: vm.Gas, Reason: reason})
+	}
+
+	if persistMemory && vm.ExitError == nil && len(e.Error) == 0 {
 		SaveContractMemorySnapshot(snapshot, id, vm.Memory)
 	}
 
+	e.failed = vm.ExitError != nil
+
 	if vm.ExitError != nil && utils.UnifyError(vm.ExitError).Error() == "gas limit exceeded" {
 		e.Gas = gasLimit
 		e.GasLimitExceeded = true
@@ -274,6 +585,94 @@ func (e *ContractExecutor) Execute(snapshot *avl.Tree, id AccountID, round *Roun
 	return nil
 }
 
+// dataCost returns the gas charged for a host function that reads or writes some
+// number of bytes.
+func (e *ContractExecutor) dataCost(op, perByte string, n int) uint64 {
+	return uint64(e.GetCost(op)) + uint64(n)*uint64(e.GetCost(perByte))
+}
+
+// callContract synchronously invokes the function name exported by the smart contract id
+// against the same snapshot as the calling smart contract, with the callee sharing whatever
+// gas the caller has remaining. Should the callee fail or report an error through _result, all
+// changes it has made to the snapshot are rolled back, and the error it reported is left for
+// the caller to read through _call_result. Otherwise, whatever data the callee returned through
+// _return is left for the caller to read instead.
+func (e *ContractExecutor) callContract(vm *exec.VirtualMachine, id AccountID, name string, params []byte) error {
+	depth := 1
+
+	for caller := e; caller != nil; caller = caller.caller {
+		if caller.ID == id {
+			return errors.Errorf("contract: re-entrant call into smart contract %x", id)
+		}
+
+		depth++
+	}
+
+	if depth > sys.MaxContractCallDepth {
+		return errors.Errorf("contract: exceeded max call depth of %d", sys.MaxContractCallDepth)
+	}
+
+	code, available := ReadAccountContractCode(e.Snapshot, id)
+	if !available {
+		return errors.Wrapf(ErrNotSmartContract, "%x", id)
+	}
+
+	if vm.Config.GasLimit <= vm.Gas {
+		return errors.New("contract: no gas remaining to call smart contract with")
+	}
+
+	// The callee sees the calling smart contract as the sender of the transaction.
+
+	var tx Transaction
+	if e.tx != nil {
+		tx = *e.tx
+	}
+	tx.Creator = e.ID
+
+	callee := &ContractExecutor{caller: e, Trace: e.Trace}
+	snapshotBeforeCall := e.Snapshot.Snapshot()
+
+	err := callee.Execute(e.Snapshot, id, e.round, &tx, 0, vm.Config.GasLimit-vm.Gas, name, params, code)
+
+	vm.AddAndCheckGas(callee.Gas)
+
+	if err == nil && callee.GasLimitExceeded {
+		err = errors.New("contract: callee exceeded gas limit")
+	}
+
+	if err == nil && callee.failed {
+		err = errors.New("contract: callee failed to execute")
+	}
+
+	if err == nil && len(callee.Error) != 0 {
+		err = errors.Errorf("contract: callee returned an error: %s", callee.Error)
+	}
+
+	if err != nil {
+		e.Snapshot.Revert(snapshotBeforeCall)
+		e.CallResult = callee.Error
+
+		return err
+	}
+
+	e.CallResult = callee.Return
+	e.Queue = append(e.Queue, callee.Queue...)
+	e.Events = append(e.Events, callee.Events...)
+
+	return nil
+}
+
+// depth returns how deep the smart contract is nested within calls made to other smart contracts.
+func (e *ContractExecutor) depth() int {
+	depth := 0
+
+	for caller := e.caller; caller != nil; caller = caller.caller {
+		depth++
+	}
+
+	return depth
+}
+
 func LoadContractMemorySnapshot(snapshot *avl.Tree, id AccountID) []byte {
 	numPages, exists := ReadAccountContractNumPages(snapshot, id)
 	if !exists {
@@ -374,7 +773,7 @@ func buildContractPayload(round *Round, tx *Transaction, amount uint64, params [
 
 func buildHashImpl(gas uint64, size int, f func(data, out []byte)) func(vm *exec.VirtualMachine) int64 {
 	return func(vm *exec.VirtualMachine) int64 {
-		vm.Gas += gas
+		vm.AddAndCheckGas(gas)
 
 		frame := vm.GetCurrentFrame()
 		dataPtr, dataLen := int(uint32(frame.Locals[0])), int(uint32(frame.Locals[1]))
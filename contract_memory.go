@@ -0,0 +1,196 @@
+// Copyright (c) 2019 Perlin
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package wavelet
+
+import (
+	"encoding/binary"
+
+	"github.com/perlin-network/life/compiler/opcodes"
+	"github.com/perlin-network/life/exec"
+	"github.com/pkg/errors"
+)
+
+// meterMemoryGrowth rewrites every grow_memory instruction within the compiled code of a smart
+// contract into an invocation of a host function that charges gas for the pages of memory being
+// grown before growing them. The interpreter otherwise grows memory without ever yielding back
+// to the executor, which would leave a smart contract free to compute with memory it has not
+// yet paid for.
+//
+// Both instructions take a single 4-byte operand, and so the offsets of all instructions, and
+// all jumps between them, are left untouched.
+func (e *ContractExecutor) meterMemoryGrowth(vm *exec.VirtualMachine) error {
+	for i := range vm.FunctionCode {
+		code := vm.FunctionCode[i].Bytes
+
+		for ip := 0; ip < len(code); {
+			// Instructions are encoded as a 4-byte value ID, a 1-byte opcode, and its operands.
+			if ip+5 > len(code) {
+				return errors.Errorf("contract: truncated instruction at offset %d of function %d", ip, i)
+			}
+
+			op := opcodes.Opcode(code[ip+4])
+			operands := code[ip+5:]
+
+			size, err := operandSize(op, operands)
+			if err != nil {
+				return errors.Wrapf(err, "contract: failed to decode instruction at offset %d of function %d", ip, i)
+			}
+
+			if size > len(operands) {
+				return errors.Errorf("contract: truncated instruction at offset %d of function %d", ip, i)
+			}
+
+			if op == opcodes.GrowMemory {
+				reg := int(binary.LittleEndian.Uint32(operands[:4]))
+
+				code[ip+4] = byte(opcodes.InvokeImport)
+				binary.LittleEndian.PutUint32(operands[:4], uint32(len(vm.FunctionImports)))
+
+				vm.FunctionImports = append(vm.FunctionImports, exec.FunctionImportInfo{
+					ModuleName: "wavelet",
+					FieldName:  "grow_memory",
+					F:          e.growMemory(reg),
+				})
+			}
+
+			ip += 5 + size
+		}
+	}
+
+	return nil
+}
+
+// growMemory returns a host function that grows the memory of a smart contract by the number
+// of pages held in the register reg, charging gas for every page grown. Like grow_memory, it
+// returns the number of pages of memory prior to growing it, or -1 should the smart contract
+// not be allowed to grow its memory any further.
+//
+// Every attempt to grow memory is traced, including attempts that are refused or that run out
+// of gas, in which case the result traced is -1.
+func (e *ContractExecutor) growMemory(reg int) exec.FunctionImport {
+	return func(vm *exec.VirtualMachine) (result int64) {
+		pages := int(uint32(vm.GetCurrentFrame().Regs[reg]))
+		current := len(vm.Memory) / PageSize
+
+		result = -1
+
+		if e.Trace != nil {
+			idx := e.Trace.record(ContractTraceEntry{
+				Kind:       TraceMemoryGrowth,
+				Contract:   e.ID,
+				Depth:      e.depth(),
+				MemorySize: (current + pages) * PageSize,
+			})
+
+			// Growing memory panics should the smart contract run out of gas, in which case the
+			// gas recorded is the gas used right before the panic.
+
+			defer func() {
+				e.Trace.Entries[idx].Gas = vm.Gas
+				e.Trace.Entries[idx].Result = result
+			}()
+		}
+
+		if vm.Config.MaxMemoryPages != 0 && (current+pages < current || current+pages > vm.Config.MaxMemoryPages) {
+			return -1
+		}
+
+		vm.AddAndCheckGas(uint64(pages) * e.Schedule.MemoryPageCost)
+
+		vm.Memory = append(vm.Memory, make([]byte, pages*PageSize)...)
+
+		return int64(current)
+	}
+}
+
+// operandSize returns the number of bytes taken up by the operands of an instruction compiled
+// by the interpreter, as laid out by the interpreters serializer.
+func operandSize(op opcodes.Opcode, operands []byte) (int, error) {
+	switch op {
+	case opcodes.Nop, opcodes.Unreachable, opcodes.ReturnVoid, opcodes.CurrentMemory, opcodes.Phi, opcodes.FPDisabledError:
+		return 0, nil
+
+	case opcodes.I32Const, opcodes.GetLocal, opcodes.GetGlobal, opcodes.InvokeImport, opcodes.GrowMemory, opcodes.ReturnValue,
+		opcodes.I32Clz, opcodes.I32Ctz, opcodes.I32PopCnt, opcodes.I32EqZ,
+		opcodes.I64Clz, opcodes.I64Ctz, opcodes.I64PopCnt, opcodes.I64EqZ,
+		opcodes.F32Sqrt, opcodes.F32Ceil, opcodes.F32Floor, opcodes.F32Trunc, opcodes.F32Nearest, opcodes.F32Abs, opcodes.F32Neg,
+		opcodes.F64Sqrt, opcodes.F64Ceil, opcodes.F64Floor, opcodes.F64Trunc, opcodes.F64Nearest, opcodes.F64Abs, opcodes.F64Neg,
+		opcodes.I32WrapI64, opcodes.I32TruncSF32, opcodes.I32TruncUF32, opcodes.I32TruncSF64, opcodes.I32TruncUF64,
+		opcodes.I64TruncSF32, opcodes.I64TruncUF32, opcodes.I64TruncSF64, opcodes.I64TruncUF64,
+		opcodes.F32DemoteF64, opcodes.F64PromoteF32,
+		opcodes.F32ConvertSI32, opcodes.F32ConvertUI32, opcodes.F32ConvertSI64, opcodes.F32ConvertUI64,
+		opcodes.F64ConvertSI32, opcodes.F64ConvertUI32, opcodes.F64ConvertSI64, opcodes.F64ConvertUI64,
+		opcodes.I64ExtendUI32, opcodes.I64ExtendSI32:
+		return 4, nil
+
+	case opcodes.I64Const, opcodes.Jmp, opcodes.SetLocal, opcodes.SetGlobal, opcodes.AddGas,
+		opcodes.I32Add, opcodes.I32Sub, opcodes.I32Mul, opcodes.I32DivS, opcodes.I32DivU, opcodes.I32RemS, opcodes.I32RemU,
+		opcodes.I32And, opcodes.I32Or, opcodes.I32Xor, opcodes.I32Shl, opcodes.I32ShrS, opcodes.I32ShrU, opcodes.I32Rotl, opcodes.I32Rotr,
+		opcodes.I32Eq, opcodes.I32Ne, opcodes.I32LtS, opcodes.I32LtU, opcodes.I32LeS, opcodes.I32LeU,
+		opcodes.I32GtS, opcodes.I32GtU, opcodes.I32GeS, opcodes.I32GeU,
+		opcodes.I64Add, opcodes.I64Sub, opcodes.I64Mul, opcodes.I64DivS, opcodes.I64DivU, opcodes.I64RemS, opcodes.I64RemU,
+		opcodes.I64And, opcodes.I64Or, opcodes.I64Xor, opcodes.I64Shl, opcodes.I64ShrS, opcodes.I64ShrU, opcodes.I64Rotl, opcodes.I64Rotr,
+		opcodes.I64Eq, opcodes.I64Ne, opcodes.I64LtS, opcodes.I64LtU, opcodes.I64LeS, opcodes.I64LeU,
+		opcodes.I64GtS, opcodes.I64GtU, opcodes.I64GeS, opcodes.I64GeU,
+		opcodes.F32Add, opcodes.F32Sub, opcodes.F32Mul, opcodes.F32Div, opcodes.F32Min, opcodes.F32Max, opcodes.F32CopySign,
+		opcodes.F32Eq, opcodes.F32Ne, opcodes.F32Lt, opcodes.F32Le, opcodes.F32Gt, opcodes.F32Ge,
+		opcodes.F64Add, opcodes.F64Sub, opcodes.F64Mul, opcodes.F64Div, opcodes.F64Min, opcodes.F64Max, opcodes.F64CopySign,
+		opcodes.F64Eq, opcodes.F64Ne, opcodes.F64Lt, opcodes.F64Le, opcodes.F64Gt, opcodes.F64Ge:
+		return 8, nil
+
+	case opcodes.Select, opcodes.JmpIf,
+		opcodes.I32Load, opcodes.I32Load8S, opcodes.I32Load8U, opcodes.I32Load16S, opcodes.I32Load16U,
+		opcodes.I64Load, opcodes.I64Load8S, opcodes.I64Load8U, opcodes.I64Load16S, opcodes.I64Load16U,
+		opcodes.I64Load32S, opcodes.I64Load32U:
+		return 12, nil
+
+	case opcodes.JmpEither,
+		opcodes.I32Store, opcodes.I32Store8, opcodes.I32Store16,
+		opcodes.I64Store, opcodes.I64Store8, opcodes.I64Store16, opcodes.I64Store32:
+		return 16, nil
+
+	case opcodes.Call:
+		// Function ID, the number of arguments, and a register per argument.
+		if len(operands) < 8 {
+			return 0, errors.New("truncated call")
+		}
+
+		return 8 + 4*int(binary.LittleEndian.Uint32(operands[4:8])), nil
+
+	case opcodes.CallIndirect:
+		// Type ID, the number of arguments including the table index, and a register per argument.
+		if len(operands) < 8 {
+			return 0, errors.New("truncated indirect call")
+		}
+
+		return 8 + 4*int(binary.LittleEndian.Uint32(operands[4:8])), nil
+
+	case opcodes.JmpTable:
+		// Number of targets, the targets, the default target, the condition, and the yielded value.
+		if len(operands) < 4 {
+			return 0, errors.New("truncated jump table")
+		}
+
+		return 16 + 4*int(binary.LittleEndian.Uint32(operands[:4])), nil
+
+	default:
+		return 0, errors.Errorf("unknown opcode %d", op)
+	}
+}
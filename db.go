@@ -40,16 +40,30 @@ var (
 	keyRoundOldestIx     = [...]byte{0x5}
 	keyRoundStoredCount  = [...]byte{0x6}
 	keyRewardWithdrawals = [...]byte{0x7}
+	keyRoundEvents       = [...]byte{0x8}
+	keyReceipts          = [...]byte{0x9}
+	keyArchivedRoots     = [...]byte{0xa}
+	keyUnbondingRequests = [...]byte{0xb}
+	keyRoundVotes        = [...]byte{0xc}
 
 	// Account-local prefixes.
-	keyAccountNonce              = [...]byte{0x1}
-	keyAccountBalance            = [...]byte{0x2}
-	keyAccountStake              = [...]byte{0x3}
-	keyAccountReward             = [...]byte{0x4}
-	keyAccountContractCode       = [...]byte{0x5}
-	keyAccountContractNumPages   = [...]byte{0x6}
-	keyAccountContractPages      = [...]byte{0x7}
-	keyAccountContractGasBalance = [...]byte{0x8}
+	keyAccountNonce                = [...]byte{0x1}
+	keyAccountBalance              = [...]byte{0x2}
+	keyAccountStake                = [...]byte{0x3}
+	keyAccountReward               = [...]byte{0x4}
+	keyAccountContractCode         = [...]byte{0x5}
+	keyAccountContractNumPages     = [...]byte{0x6}
+	keyAccountContractPages        = [...]byte{0x7}
+	keyAccountContractGasBalance   = [...]byte{0x8}
+	keyAccountContractStorage      = [...]byte{0x9}
+	keyAccountContractOwner        = [...]byte{0xa}
+	keyAccountContractABI          = [...]byte{0xb}
+	keyAccountDelegatedStake       = [...]byte{0xc}
+	keyAccountCommission           = [...]byte{0xd}
+	keyAccountValidatorDelegations = [...]byte{0xe}
+	keyAccountDelegatorDelegations = [...]byte{0xf}
+	keyAccountOffences             = [...]byte{0x10}
+	keyAccountUnbonding            = [...]byte{0x11}
 )
 
 type RewardWithdrawalRequest struct {
@@ -128,6 +142,16 @@ func WriteAccountNonce(tree *avl.Tree, id AccountID, nonce uint64) {
 	writeUnderAccounts(tree, id, keyAccountNonce[:], buf[:])
 }
 
+// AccountBalanceKey returns the key under which the balance of an account is stored within the
+// accounts tree, such that the balance may be proven to be held by the tree.
+func AccountBalanceKey(id AccountID) []byte {
+	return accountKey(id, keyAccountBalance[:])
+}
+
+func accountKey(id AccountID, key []byte) []byte {
+	return append(keyAccounts[:], append(key, id[:]...)...)
+}
+
 func ReadAccountBalance(tree *avl.Tree, id AccountID) (uint64, bool) {
 	buf, exists := readUnderAccounts(tree, id, keyAccountBalance[:])
 	if !exists || len(buf) == 0 {
@@ -175,6 +199,220 @@ func WriteAccountReward(tree *avl.Tree, id AccountID, reward uint64) {
 	writeUnderAccounts(tree, id, keyAccountReward[:], buf[:])
 }
 
+// ReadAccountDelegatedStake returns the total amount of stake delegated to a validator.
+func ReadAccountDelegatedStake(tree *avl.Tree, id AccountID) (uint64, bool) {
+	buf, exists := readUnderAccounts(tree, id, keyAccountDelegatedStake[:])
+	if !exists || len(buf) == 0 {
+		return 0, false
+	}
+
+	return binary.LittleEndian.Uint64(buf), true
+}
+
+func WriteAccountDelegatedStake(tree *avl.Tree, id AccountID, stake uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], stake)
+
+	writeUnderAccounts(tree, id, keyAccountDelegatedStake[:], buf[:])
+}
+
+// ReadAccountTotalStake returns the stake of an account alongside all stake delegated to it, which
+// altogether weighs its votes and its odds of being rewarded as a validator.
+func ReadAccountTotalStake(tree *avl.Tree, id AccountID) uint64 {
+	stake, _ := ReadAccountStake(tree, id)
+	delegated, _ := ReadAccountDelegatedStake(tree, id)
+
+	return stake + delegated
+}
+
+// ReadStakedAccounts returns the total stake of every account that has either placed stake, or has
+// had stake delegated to it.
+func ReadStakedAccounts(tree *avl.Tree) map[AccountID]uint64 {
+	stakes := make(map[AccountID]uint64)
+
+	for _, field := range [][1]byte{keyAccountStake, keyAccountDelegatedStake} {
+		prefix := append(keyAccounts[:], field[:]...)
+
+		tree.IteratePrefix(prefix, func(key, value []byte) {
+			if len(key) != len(prefix)+SizeAccountID || len(value) != 8 {
+				return
+			}
+
+			var id AccountID
+			copy(id[:], key[len(prefix):])
+
+			if stake := binary.LittleEndian.Uint64(value); stake > 0 {
+				stakes[id] += stake
+			}
+		})
+	}
+
+	return stakes
+}
+
+// ReadAccountCommission returns the commission, in basis points, that a validator takes out of
+// the rewards of its delegators.
+func ReadAccountCommission(tree *avl.Tree, id AccountID) (uint64, bool) {
+	buf, exists := readUnderAccounts(tree, id, keyAccountCommission[:])
+	if !exists || len(buf) == 0 {
+		return 0, false
+	}
+
+	return binary.LittleEndian.Uint64(buf), true
+}
+
+func WriteAccountCommission(tree *avl.Tree, id AccountID, commission uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], commission)
+
+	writeUnderAccounts(tree, id, keyAccountCommission[:], buf[:])
+}
+
+// Delegation denotes an amount of stake delegated by a delegator to a validator.
+type Delegation struct {
+	Delegator AccountID
+	Validator AccountID
+	Amount    uint64
+}
+
+// ReadAccountDelegation returns the amount of stake a delegator has delegated to a validator.
+func ReadAccountDelegation(tree *avl.Tree, delegator, validator AccountID) (uint64, bool) {
+	buf, exists := tree.Lookup(delegationKey(keyAccountValidatorDelegations, validator, delegator))
+	if !exists || len(buf) == 0 {
+		return 0, false
+	}
+
+	return binary.LittleEndian.Uint64(buf), true
+}
+
+// WriteAccountDelegation writes the amount of stake a delegator has delegated to a validator. The
+// delegation is indexed under both the validator and the delegator, and is deleted should the
+// amount be zero.
+func WriteAccountDelegation(tree *avl.Tree, delegator, validator AccountID, amount uint64) {
+	byValidator := delegationKey(keyAccountValidatorDelegations, validator, delegator)
+	byDelegator := delegationKey(keyAccountDelegatorDelegations, delegator, validator)
+
+	if amount == 0 {
+		tree.Delete(byValidator)
+		tree.Delete(byDelegator)
+
+		return
+	}
+
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], amount)
+
+	tree.Insert(byValidator, buf[:])
+	tree.Insert(byDelegator, buf[:])
+}
+
+// ReadValidatorDelegations returns all delegations made to a validator, ordered by delegator.
+func ReadValidatorDelegations(tree *avl.Tree, validator AccountID) []Delegation {
+	var delegations []Delegation
+
+	prefix := delegationsPrefix(keyAccountValidatorDelegations, validator)
+
+	tree.IteratePrefix(prefix, func(key, value []byte) {
+		delegation := Delegation{Validator: validator, Amount: binary.LittleEndian.Uint64(value)}
+		copy(delegation.Delegator[:], key[len(prefix):])
+
+		delegations = append(delegations, delegation)
+	})
+
+	return delegations
+}
+
+// ReadDelegatorDelegations returns all delegations made by a delegator, ordered by validator.
+func ReadDelegatorDelegations(tree *avl.Tree, delegator AccountID) []Delegation {
+	var delegations []Delegation
+
+	prefix := delegationsPrefix(keyAccountDelegatorDelegations, delegator)
+
+	tree.IteratePrefix(prefix, func(key, value []byte) {
+		delegation := Delegation{Delegator: delegator, Amount: binary.LittleEndian.Uint64(value)}
+		copy(delegation.Validator[:], key[len(prefix):])
+
+		delegations = append(delegations, delegation)
+	})
+
+	return delegations
+}
+
+// delegationKey places the ID of the account a delegation is indexed under before the ID of the
+// other party of the delegation, such that all delegations indexed under an account share the
+// same prefix.
+func delegationKey(index [1]byte, id, other AccountID) []byte {
+	return append(delegationsPrefix(index, id), other[:]...)
+}
+
+func delegationsPrefix(index [1]byte, id AccountID) []byte {
+	buf := make([]byte, 0, len(keyAccounts)+len(index)+SizeAccountID*2)
+
+	buf = append(buf, keyAccounts[:]...)
+	buf = append(buf, index[:]...)
+	buf = append(buf, id[:]...)
+
+	return buf
+}
+
+// Offence denotes a validator having been proven to equivocate at a round, and the amount of its
+// stake that was slashed for it.
+type Offence struct {
+	Validator  AccountID
+	RoundIndex uint64
+	Slashed    uint64
+}
+
+// ReadAccountOffence returns the amount of stake slashed from a validator for equivocating at a
+// round, and whether or not the validator was punished for equivocating at the round at all.
+func ReadAccountOffence(tree *avl.Tree, id AccountID, roundIndex uint64) (uint64, bool) {
+	buf, exists := tree.Lookup(offenceKey(id, roundIndex))
+	if !exists || len(buf) == 0 {
+		return 0, false
+	}
+
+	return binary.LittleEndian.Uint64(buf), true
+}
+
+func WriteAccountOffence(tree *avl.Tree, id AccountID, roundIndex uint64, slashed uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], slashed)
+
+	tree.Insert(offenceKey(id, roundIndex), buf[:])
+}
+
+// ReadAccountOffences returns all offences a validator has been punished for, ordered by round.
+func ReadAccountOffences(tree *avl.Tree, id AccountID) []Offence {
+	var offences []Offence
+
+	prefix := append(append(keyAccounts[:], keyAccountOffences[:]...), id[:]...)
+
+	tree.IteratePrefix(prefix, func(key, value []byte) {
+		offences = append(offences, Offence{
+			Validator:  id,
+			RoundIndex: binary.BigEndian.Uint64(key[len(prefix):]),
+			Slashed:    binary.LittleEndian.Uint64(value),
+		})
+	})
+
+	return offences
+}
+
+// offenceKey big-endian encodes the round index after the ID of the validator, such that the
+// offences of a validator are iterated in order of round.
+func offenceKey(id AccountID, roundIndex uint64) []byte {
+	buf := make([]byte, 0, len(keyAccounts)+len(keyAccountOffences)+SizeAccountID+8)
+
+	buf = append(buf, keyAccounts[:]...)
+	buf = append(buf, keyAccountOffences[:]...)
+	buf = append(buf, id[:]...)
+
+	var index [8]byte
+	binary.BigEndian.PutUint64(index[:], roundIndex)
+
+	return append(buf, index[:]...)
+}
+
 func ReadAccountContractCode(tree *avl.Tree, id TransactionID) ([]byte, bool) {
 	buf, exists := readUnderAccounts(tree, id, keyAccountContractCode[:])
 	if !exists || len(buf) == 0 {
@@ -245,6 +483,122 @@ func WriteAccountContractGasBalance(tree *avl.Tree, id TransactionID, gasBalance
 	writeUnderAccounts(tree, id, keyAccountContractGasBalance[:], buf[:])
 }
 
+func ReadAccountContractOwner(tree *avl.Tree, id AccountID) (AccountID, bool) {
+	var owner AccountID
+
+	buf, exists := readUnderAccounts(tree, id, keyAccountContractOwner[:])
+	if !exists || len(buf) != SizeAccountID {
+		return owner, false
+	}
+
+	copy(owner[:], buf)
+
+	return owner, true
+}
+
+func WriteAccountContractOwner(tree *avl.Tree, id AccountID, owner AccountID) {
+	writeUnderAccounts(tree, id, keyAccountContractOwner[:], owner[:])
+}
+
+func ReadAccountContractABI(tree *avl.Tree, id AccountID) ([]byte, bool) {
+	return readUnderAccounts(tree, id, keyAccountContractABI[:])
+}
+
+func WriteAccountContractABI(tree *avl.Tree, id AccountID, abi []byte) {
+	writeUnderAccounts(tree, id, keyAccountContractABI[:], abi)
+}
+
+func DeleteAccountContractABI(tree *avl.Tree, id AccountID) {
+	deleteUnderAccounts(tree, id, keyAccountContractABI[:])
+}
+
+func ReadAccountContractStorage(tree *avl.Tree, id AccountID, key []byte) ([]byte, bool) {
+	return tree.Lookup(accountContractStorageKey(id, key))
+}
+
+func WriteAccountContractStorage(tree *avl.Tree, id AccountID, key, value []byte) {
+	tree.Insert(accountContractStorageKey(id, key), value)
+}
+
+func DeleteAccountContractStorage(tree *avl.Tree, id AccountID, key []byte) bool {
+	return tree.Delete(accountContractStorageKey(id, key))
+}
+
+// NextAccountContractStorageKey returns the smallest key stored within a smart contracts storage
+// that is greater than or equal to start.
+func NextAccountContractStorageKey(tree *avl.Tree, id AccountID, start []byte) ([]byte, bool) {
+	prefix := accountContractStorageKey(id, nil)
+
+	var next []byte
+	var found bool
+
+	tree.IterateFrom(accountContractStorageKey(id, start), func(key, value []byte) bool {
+		if bytes.HasPrefix(key, prefix) {
+			next, found = key[len(prefix):], true
+		}
+
+		return false
+	})
+
+	return next, found
+}
+
+// accountContractStorageKey places the smart contracts ID before the storage key, such that all
+// keys within a smart contracts storage share the same prefix.
+func accountContractStorageKey(id AccountID, key []byte) []byte {
+	buf := make([]byte, 0, len(keyAccounts)+len(keyAccountContractStorage)+SizeAccountID+len(key))
+
+	buf = append(buf, keyAccounts[:]...)
+	buf = append(buf, keyAccountContractStorage[:]...)
+	buf = append(buf, id[:]...)
+	buf = append(buf, key...)
+
+	return buf
+}
+
+// DeleteAccount deletes all account-local keys of an account, including the code, memory pages,
+// and storage of a smart contract.
+func DeleteAccount(tree *avl.Tree, id AccountID) {
+	if _, exists := ReadAccountNonce(tree, id); exists {
+		WriteAccountsLen(tree, ReadAccountsLen(tree)-1)
+	}
+
+	numPages, _ := ReadAccountContractNumPages(tree, id)
+
+	for idx := uint64(0); idx < numPages; idx++ {
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], idx)
+
+		deleteUnderAccounts(tree, id, append(keyAccountContractPages[:], buf[:]...))
+	}
+
+	var storage [][]byte
+
+	tree.IteratePrefix(accountContractStorageKey(id, nil), func(key, _ []byte) {
+		storage = append(storage, key)
+	})
+
+	for _, key := range storage {
+		tree.Delete(key)
+	}
+
+	for _, key := range [][1]byte{
+		keyAccountNonce,
+		keyAccountBalance,
+		keyAccountStake,
+		keyAccountReward,
+		keyAccountContractCode,
+		keyAccountContractNumPages,
+		keyAccountContractGasBalance,
+		keyAccountContractOwner,
+		keyAccountContractABI,
+		keyAccountDelegatedStake,
+		keyAccountCommission,
+	} {
+		deleteUnderAccounts(tree, id, key[:])
+	}
+}
+
 func readUnderAccounts(tree *avl.Tree, id AccountID, key []byte) ([]byte, bool) {
 	buf, exists := tree.Lookup(append(keyAccounts[:], append(key, id[:]...)...))
 
@@ -259,6 +613,10 @@ func writeUnderAccounts(tree *avl.Tree, id AccountID, key, value []byte) {
 	tree.Insert(append(keyAccounts[:], append(key, id[:]...)...), value[:])
 }
 
+func deleteUnderAccounts(tree *avl.Tree, id AccountID, key []byte) {
+	tree.Delete(append(keyAccounts[:], append(key, id[:]...)...))
+}
+
 func ReadAccountsLen(tree *avl.Tree) uint64 {
 	buf, exists := tree.Lookup(keyAccountsLen[:])
 	if !exists {
@@ -276,27 +634,31 @@ func WriteAccountsLen(tree *avl.Tree, size uint64) {
 }
 
 func StoreRound(kv store.KV, round Round, currentIx, oldestIx uint32, storedCount uint8) error {
-	if err := kv.Put(keyRoundStoredCount[:], []byte{byte(storedCount)}); err != nil {
-		return errors.Wrap(err, "error storing stored rounds count")
+	batch := kv.NewWriteBatch()
+
+	storeRound(batch, round, currentIx, oldestIx, storedCount)
+
+	if err := kv.CommitWriteBatch(batch); err != nil {
+		return errors.Wrap(err, "error storing round")
 	}
 
+	return nil
+}
+
+// storeRound stages a round, alongside the indices of the latest and oldest round and the
+// number of rounds stored, into batch.
+func storeRound(batch store.WriteBatch, round Round, currentIx, oldestIx uint32, storedCount uint8) {
+	batch.Put(keyRoundStoredCount[:], []byte{byte(storedCount)})
+
 	var oldestIxBuf [4]byte
 	binary.BigEndian.PutUint32(oldestIxBuf[:], oldestIx)
-	if err := kv.Put(keyRoundOldestIx[:], oldestIxBuf[:]); err != nil {
-		return errors.Wrap(err, "error storing oldest round index")
-	}
+	batch.Put(keyRoundOldestIx[:], oldestIxBuf[:])
 
 	var currentIxBuf [4]byte
 	binary.BigEndian.PutUint32(currentIxBuf[:], currentIx)
-	if err := kv.Put(keyRoundLatestIx[:], currentIxBuf[:]); err != nil {
-		return errors.Wrap(err, "error storing latest round index")
-	}
+	batch.Put(keyRoundLatestIx[:], currentIxBuf[:])
 
-	if err := kv.Put(append(keyRounds[:], strconv.Itoa(int(currentIx))...), round.Marshal()); err != nil {
-		return errors.Wrap(err, "error storing round")
-	}
-
-	return nil
+	batch.Put(append(keyRounds[:], strconv.Itoa(int(currentIx))...), round.Marshal())
 }
 
 func LoadRounds(kv store.KV) ([]*Round, uint32, uint32, error) {
@@ -339,6 +701,200 @@ func LoadRounds(kv store.KV) ([]*Round, uint32, uint32, error) {
 	return rounds, latestIx, oldestIx, nil
 }
 
+// StoreRoundEvents stores all events emitted by smart contracts within a finalized round.
+func StoreRoundEvents(kv store.KV, round uint64, events []Event) error {
+	if err := kv.Put(roundEventsKey(round), marshalRoundEvents(events)); err != nil {
+		return errors.Wrap(err, "error storing round events")
+	}
+
+	return nil
+}
+
+// StoreRoundEventsWithBatch stages all events emitted by smart contracts within a finalized round
+// into batch, such that they are written atomically alongside the round.
+func StoreRoundEventsWithBatch(batch store.WriteBatch, round uint64, events []Event) {
+	batch.Put(roundEventsKey(round), marshalRoundEvents(events))
+}
+
+func marshalRoundEvents(events []Event) []byte {
+	var w bytes.Buffer
+
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(len(events)))
+	w.Write(buf[:])
+
+	for _, event := range events {
+		w.Write(event.Marshal())
+	}
+
+	return w.Bytes()
+}
+
+// LoadRoundEvents loads all events emitted by smart contracts within a finalized round. No
+// events are returned for rounds whose events were never stored.
+func LoadRoundEvents(kv store.KV, round uint64) ([]Event, error) {
+	b, err := kv.Get(roundEventsKey(round))
+	if errors.Cause(err) == store.ErrNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "error loading round events")
+	}
+
+	r := bytes.NewReader(b)
+
+	var buf [4]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return nil, errors.Wrap(err, "error loading number of round events")
+	}
+
+	events := make([]Event, binary.BigEndian.Uint32(buf[:]))
+
+	for i := range events {
+		if events[i], err = UnmarshalEvent(r); err != nil {
+			return nil, errors.Wrap(err, "error unmarshaling round event")
+		}
+	}
+
+	return events, nil
+}
+
+func roundEventsKey(round uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], round)
+
+	return append(keyRoundEvents[:], buf[:]...)
+}
+
+// StoreRoundVotesWithBatch stages the signed votes cast while a round was being finalized into
+// batch, such that they are written atomically alongside the round.
+func StoreRoundVotesWithBatch(batch store.WriteBatch, round uint64, votes []Vote) {
+	var w bytes.Buffer
+
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(len(votes)))
+	w.Write(buf[:])
+
+	for _, vote := range votes {
+		w.Write(vote.Marshal())
+	}
+
+	batch.Put(roundVotesKey(round), w.Bytes())
+}
+
+// LoadRoundVotes loads the signed votes cast while a round was being finalized. No votes are
+// returned for rounds whose votes were never stored, such as rounds the node synced from its
+// peers rather than finalized itself.
+func LoadRoundVotes(kv store.KV, round uint64) ([]Vote, error) {
+	b, err := kv.Get(roundVotesKey(round))
+	if errors.Cause(err) == store.ErrNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "error loading round votes")
+	}
+
+	r := bytes.NewReader(b)
+
+	var buf [4]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return nil, errors.Wrap(err, "error loading number of round votes")
+	}
+
+	votes := make([]Vote, binary.BigEndian.Uint32(buf[:]))
+
+	for i := range votes {
+		if votes[i], err = UnmarshalVote(r); err != nil {
+			return nil, errors.Wrap(err, "error unmarshaling round vote")
+		}
+	}
+
+	return votes, nil
+}
+
+func roundVotesKey(round uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], round)
+
+	return append(keyRoundVotes[:], buf[:]...)
+}
+
+// StoreReceipts stores the receipts of transactions finalized within a round, indexed by the ID
+// of each transaction.
+func StoreReceipts(kv store.KV, receipts []Receipt) error {
+	batch := kv.NewWriteBatch()
+	StoreReceiptsWithBatch(batch, receipts)
+
+	if err := kv.CommitWriteBatch(batch); err != nil {
+		return errors.Wrap(err, "error storing receipts")
+	}
+
+	return nil
+}
+
+// LoadReceipt loads the receipt of a finalized transaction. It returns nil should the transaction
+// not have been finalized yet.
+func LoadReceipt(kv store.KV, id TransactionID) (*Receipt, error) {
+	b, err := kv.Get(receiptKey(id))
+	if errors.Cause(err) == store.ErrNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "error loading receipt")
+	}
+
+	receipt, err := UnmarshalReceipt(bytes.NewReader(b))
+	if err != nil {
+		return nil, errors.Wrap(err, "error loading receipt")
+	}
+
+	return &receipt, nil
+}
+
+// StoreReceiptsWithBatch stages the receipts of transactions finalized within a round into batch,
+// such that they are written atomically alongside the round.
+func StoreReceiptsWithBatch(batch store.WriteBatch, receipts []Receipt) {
+	for _, receipt := range receipts {
+		batch.Put(receiptKey(receipt.TxID), receipt.Marshal())
+	}
+}
+
+func receiptKey(id TransactionID) []byte {
+	return append(keyReceipts[:], id[:]...)
+}
+
+// StoreArchivedRootIndexWithBatch stages into batch the index at which the Merkle root of the
+// accounts tree at the end of a finalized round is kept amongst the old roots of the accounts
+// tree, such that the state of all accounts at the round may later be loaded by archive nodes.
+func StoreArchivedRootIndexWithBatch(batch store.WriteBatch, round uint64, idx uint64) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], idx)
+
+	batch.Put(archivedRootKey(round), buf[:])
+}
+
+// LoadArchivedRootIndex loads the index at which the Merkle root of the accounts tree at the end
+// of a finalized round is kept amongst the old roots of the accounts tree. It returns false should
+// the root of the round never have been archived.
+func LoadArchivedRootIndex(kv store.KV, round uint64) (uint64, bool) {
+	b, err := kv.Get(archivedRootKey(round))
+	if err != nil || len(b) != 8 {
+		return 0, false
+	}
+
+	return binary.BigEndian.Uint64(b), true
+}
+
+func archivedRootKey(round uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], round)
+
+	return append(keyArchivedRoots[:], buf[:]...)
+}
+
 func GetRewardWithdrawalRequests(tree *avl.Tree, roundLimit uint64) []RewardWithdrawalRequest {
 	var rws []RewardWithdrawalRequest
 
@@ -361,3 +917,144 @@ func GetRewardWithdrawalRequests(tree *avl.Tree, roundLimit uint64) []RewardWith
 func StoreRewardWithdrawalRequest(tree *avl.Tree, rw RewardWithdrawalRequest) {
 	tree.Insert(rw.Key(), rw.Marshal())
 }
+
+// UnbondingRequest denotes an amount of stake withdrawn by an account at a round, which is only
+// released into the balance of the account after sys.StakeUnbondingRounds rounds have passed.
+type UnbondingRequest struct {
+	Account AccountID
+	Amount  uint64
+	Round   uint64
+}
+
+func (ur UnbondingRequest) Key() []byte {
+	var w bytes.Buffer
+	w.Write(keyUnbondingRequests[:])
+
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], ur.Round)
+	w.Write(buf[:8])
+
+	w.Write(ur.Account[:])
+
+	return w.Bytes()
+}
+
+func (ur UnbondingRequest) Marshal() []byte {
+	var w bytes.Buffer
+
+	w.Write(ur.Account[:])
+
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], ur.Amount)
+	w.Write(buf[:8])
+
+	binary.BigEndian.PutUint64(buf[:], ur.Round)
+	w.Write(buf[:8])
+
+	return w.Bytes()
+}
+
+func UnmarshalUnbondingRequest(r io.Reader) (UnbondingRequest, error) {
+	var ur UnbondingRequest
+	if _, err := io.ReadFull(r, ur.Account[:]); err != nil {
+		err = errors.Wrap(err, "failed to decode unbonding account ID")
+		return ur, err
+	}
+
+	var buf [8]byte
+
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		err = errors.Wrap(err, "failed to decode unbonding amount")
+		return ur, err
+	}
+
+	ur.Amount = binary.BigEndian.Uint64(buf[:8])
+
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		err = errors.Wrap(err, "failed to decode unbonding round")
+		return ur, err
+	}
+
+	ur.Round = binary.BigEndian.Uint64(buf[:8])
+
+	return ur, nil
+}
+
+// GetUnbondingRequests returns all unbonding requests made at or before roundLimit, ordered by round.
+func GetUnbondingRequests(tree *avl.Tree, roundLimit uint64) []UnbondingRequest {
+	var urs []UnbondingRequest
+
+	cb := func(k, v []byte) {
+		ur, err := UnmarshalUnbondingRequest(bytes.NewReader(v))
+		if err != nil {
+			return
+		}
+
+		if ur.Round <= roundLimit {
+			urs = append(urs, ur)
+		}
+	}
+
+	tree.IteratePrefix(keyUnbondingRequests[:], cb)
+
+	return urs
+}
+
+// StoreUnbondingRequest stores an unbonding request, indexing it both by round and under its
+// account. Any request made by the same account at the same round is replaced.
+func StoreUnbondingRequest(tree *avl.Tree, ur UnbondingRequest) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], ur.Amount)
+
+	tree.Insert(ur.Key(), ur.Marshal())
+	tree.Insert(accountUnbondingKey(ur.Account, ur.Round), buf[:])
+}
+
+func DeleteUnbondingRequest(tree *avl.Tree, ur UnbondingRequest) {
+	tree.Delete(ur.Key())
+	tree.Delete(accountUnbondingKey(ur.Account, ur.Round))
+}
+
+// ReadAccountUnbonding returns the amount of stake an account has withdrawn at a round that is
+// still pending to be released into its balance.
+func ReadAccountUnbonding(tree *avl.Tree, id AccountID, round uint64) (uint64, bool) {
+	buf, exists := tree.Lookup(accountUnbondingKey(id, round))
+	if !exists || len(buf) == 0 {
+		return 0, false
+	}
+
+	return binary.LittleEndian.Uint64(buf), true
+}
+
+// ReadAccountUnbondingRequests returns all pending unbonding requests of an account, ordered by round.
+func ReadAccountUnbondingRequests(tree *avl.Tree, id AccountID) []UnbondingRequest {
+	var urs []UnbondingRequest
+
+	prefix := accountUnbondingKey(id, 0)
+	prefix = prefix[:len(prefix)-8]
+
+	tree.IteratePrefix(prefix, func(key, value []byte) {
+		urs = append(urs, UnbondingRequest{
+			Account: id,
+			Amount:  binary.LittleEndian.Uint64(value),
+			Round:   binary.BigEndian.Uint64(key[len(prefix):]),
+		})
+	})
+
+	return urs
+}
+
+// accountUnbondingKey big-endian encodes the round after the ID of the account, such that the
+// unbonding requests of an account are iterated in order of round.
+func accountUnbondingKey(id AccountID, round uint64) []byte {
+	buf := make([]byte, 0, len(keyAccounts)+len(keyAccountUnbonding)+SizeAccountID+8)
+
+	buf = append(buf, keyAccounts[:]...)
+	buf = append(buf, keyAccountUnbonding[:]...)
+	buf = append(buf, id[:]...)
+
+	var index [8]byte
+	binary.BigEndian.PutUint64(index[:], round)
+
+	return append(buf, index[:]...)
+}
@@ -56,3 +56,232 @@ func TestRewardWithdrawals(t *testing.T) {
 	assert.Equal(t, 7, len(rws))
 	assert.True(t, sort.SliceIsSorted(rws, func(i, j int) bool { return rws[i].round < rws[j].round }))
 }
+
+func TestUnbondingRequests(t *testing.T) {
+	tree := avl.New(store.NewInmem())
+
+	a, b := AccountID{1}, AccountID{2}
+
+	StoreUnbondingRequest(tree, UnbondingRequest{Account: a, Amount: 10, Round: 5})
+	StoreUnbondingRequest(tree, UnbondingRequest{Account: b, Amount: 20, Round: 3})
+	StoreUnbondingRequest(tree, UnbondingRequest{Account: a, Amount: 30, Round: 1})
+
+	assert.Equal(t, []UnbondingRequest{
+		{Account: a, Amount: 30, Round: 1},
+		{Account: b, Amount: 20, Round: 3},
+	}, GetUnbondingRequests(tree, 4))
+
+	assert.Equal(t, []UnbondingRequest{
+		{Account: a, Amount: 30, Round: 1},
+		{Account: a, Amount: 10, Round: 5},
+	}, ReadAccountUnbondingRequests(tree, a))
+
+	amount, exists := ReadAccountUnbonding(tree, b, 3)
+	assert.True(t, exists)
+	assert.Equal(t, uint64(20), amount)
+
+	// Deleted requests are removed from both indices.
+	DeleteUnbondingRequest(tree, UnbondingRequest{Account: a, Round: 1})
+
+	assert.Len(t, GetUnbondingRequests(tree, 4), 1)
+	assert.Len(t, ReadAccountUnbondingRequests(tree, a), 1)
+
+	_, exists = ReadAccountUnbonding(tree, a, 1)
+	assert.False(t, exists)
+}
+
+func TestAccountContractStorage(t *testing.T) {
+	tree := avl.New(store.NewInmem())
+
+	var a, b AccountID
+	a[0], b[0] = 1, 2
+
+	WriteAccountContractStorage(tree, a, []byte("b"), []byte("2"))
+	WriteAccountContractStorage(tree, a, []byte("a"), []byte("1"))
+	WriteAccountContractStorage(tree, b, []byte("c"), []byte("3"))
+
+	value, exists := ReadAccountContractStorage(tree, a, []byte("a"))
+	assert.True(t, exists)
+	assert.Equal(t, []byte("1"), value)
+
+	_, exists = ReadAccountContractStorage(tree, b, []byte("a"))
+	assert.False(t, exists)
+
+	// Keys are iterated in order, and never cross over into another contracts storage.
+
+	var keys []string
+
+	for key, exists := NextAccountContractStorageKey(tree, a, nil); exists; key, exists = NextAccountContractStorageKey(tree, a, append(key, 0)) {
+		keys = append(keys, string(key))
+	}
+
+	assert.Equal(t, []string{"a", "b"}, keys)
+
+	assert.True(t, DeleteAccountContractStorage(tree, a, []byte("a")))
+	assert.False(t, DeleteAccountContractStorage(tree, a, []byte("a")))
+
+	key, exists := NextAccountContractStorageKey(tree, a, nil)
+	assert.True(t, exists)
+	assert.Equal(t, []byte("b"), key)
+}
+
+func TestDeleteAccount(t *testing.T) {
+	tree := avl.New(store.NewInmem())
+
+	var a, b AccountID
+	a[0], b[0] = 1, 2
+
+	for _, id := range []AccountID{a, b} {
+		WriteAccountNonce(tree, id, 1)
+		WriteAccountBalance(tree, id, 100)
+		WriteAccountContractCode(tree, id, []byte("code"))
+		WriteAccountContractOwner(tree, id, b)
+		WriteAccountContractABI(tree, id, []byte("{}"))
+		WriteAccountContractNumPages(tree, id, 2)
+		WriteAccountContractPage(tree, id, 0, []byte("page 0"))
+		WriteAccountContractPage(tree, id, 1, []byte("page 1"))
+		WriteAccountContractGasBalance(tree, id, 10)
+		WriteAccountContractStorage(tree, id, []byte("key"), []byte("value"))
+	}
+
+	owner, exists := ReadAccountContractOwner(tree, a)
+	assert.True(t, exists)
+	assert.Equal(t, b, owner)
+
+	DeleteAccount(tree, a)
+
+	_, exists = ReadAccountNonce(tree, a)
+	assert.False(t, exists)
+	_, exists = ReadAccountBalance(tree, a)
+	assert.False(t, exists)
+	_, exists = ReadAccountContractCode(tree, a)
+	assert.False(t, exists)
+	_, exists = ReadAccountContractOwner(tree, a)
+	assert.False(t, exists)
+	_, exists = ReadAccountContractABI(tree, a)
+	assert.False(t, exists)
+	_, exists = ReadAccountContractNumPages(tree, a)
+	assert.False(t, exists)
+	_, exists = ReadAccountContractPage(tree, a, 0)
+	assert.False(t, exists)
+	_, exists = ReadAccountContractGasBalance(tree, a)
+	assert.False(t, exists)
+	_, exists = NextAccountContractStorageKey(tree, a, nil)
+	assert.False(t, exists)
+
+	// Other accounts are left untouched.
+
+	_, exists = ReadAccountContractCode(tree, b)
+	assert.True(t, exists)
+	_, exists = ReadAccountContractPage(tree, b, 1)
+	assert.True(t, exists)
+	_, exists = ReadAccountContractStorage(tree, b, []byte("key"))
+	assert.True(t, exists)
+}
+
+func TestAccountDelegations(t *testing.T) {
+	tree := avl.New(store.NewInmem())
+
+	validatorA := AccountID{1}
+	validatorB := AccountID{2}
+	delegator := AccountID{3}
+
+	_, exists := ReadAccountDelegation(tree, delegator, validatorA)
+	assert.False(t, exists)
+
+	WriteAccountDelegation(tree, delegator, validatorA, 10)
+	WriteAccountDelegation(tree, delegator, validatorB, 20)
+
+	amount, exists := ReadAccountDelegation(tree, delegator, validatorA)
+	assert.True(t, exists)
+	assert.Equal(t, uint64(10), amount)
+
+	assert.Equal(t, []Delegation{
+		{Delegator: delegator, Validator: validatorA, Amount: 10},
+		{Delegator: delegator, Validator: validatorB, Amount: 20},
+	}, ReadDelegatorDelegations(tree, delegator))
+
+	assert.Equal(t, []Delegation{
+		{Delegator: delegator, Validator: validatorB, Amount: 20},
+	}, ReadValidatorDelegations(tree, validatorB))
+
+	// Delegations of zero PERLs are removed from both indices.
+	WriteAccountDelegation(tree, delegator, validatorA, 0)
+
+	_, exists = ReadAccountDelegation(tree, delegator, validatorA)
+	assert.False(t, exists)
+
+	assert.Empty(t, ReadValidatorDelegations(tree, validatorA))
+	assert.Len(t, ReadDelegatorDelegations(tree, delegator), 1)
+}
+
+func TestReadStakedAccounts(t *testing.T) {
+	tree := avl.New(store.NewInmem())
+
+	WriteAccountStake(tree, AccountID{1}, 10)
+	WriteAccountDelegatedStake(tree, AccountID{1}, 5)
+	WriteAccountDelegatedStake(tree, AccountID{2}, 20)
+	WriteAccountStake(tree, AccountID{3}, 0)
+	WriteAccountBalance(tree, AccountID{4}, 100)
+
+	assert.Equal(t, map[AccountID]uint64{{1}: 15, {2}: 20}, ReadStakedAccounts(tree))
+}
+
+func TestRoundEvents(t *testing.T) {
+	kv := store.NewInmem()
+
+	events := []Event{
+		{Round: 1, Contract: AccountID{1}, TxID: TransactionID{2}, Topic: "transfer", Data: []byte("hello")},
+		{Round: 1, Contract: AccountID{3}, TxID: TransactionID{4}, Topic: "", Data: []byte{}},
+	}
+
+	assert.NoError(t, StoreRoundEvents(kv, 1, events))
+
+	loaded, err := LoadRoundEvents(kv, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, events, loaded)
+
+	// Rounds whose events were never stored have no events.
+	loaded, err = LoadRoundEvents(kv, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, loaded)
+}
+
+func TestReceipts(t *testing.T) {
+	kv := store.NewInmem()
+
+	receipts := []Receipt{
+		{
+			TxID:   TransactionID{1},
+			Round:  1,
+			Status: ReceiptStatusApplied,
+			Fee:    2,
+			Gas:    300,
+			Events: []Event{
+				{Round: 1, Contract: AccountID{2}, TxID: TransactionID{1}, Topic: "transfer", Data: []byte("hello")},
+			},
+		},
+		{
+			TxID:   TransactionID{3},
+			Round:  1,
+			Status: ReceiptStatusRejected,
+			Error:  "insufficient balance",
+		},
+	}
+
+	assert.NoError(t, StoreReceipts(kv, receipts))
+
+	for _, receipt := range receipts {
+		loaded, err := LoadReceipt(kv, receipt.TxID)
+		assert.NoError(t, err)
+
+		if assert.NotNil(t, loaded) {
+			assert.Equal(t, receipt, *loaded)
+		}
+	}
+
+	// Transactions that were never finalized have no receipt.
+	loaded, err := LoadReceipt(kv, TransactionID{4})
+	assert.NoError(t, err)
+	assert.Nil(t, loaded)
+}
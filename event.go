@@ -0,0 +1,110 @@
+// Copyright (c) 2019 Perlin
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package wavelet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/pkg/errors"
+	"io"
+)
+
+// Event is a structured event emitted by a smart contract through the _emit_event host
+// function. Events are only recorded should the transaction that emitted them be applied,
+// and are stored by the index of the round in which they were finalized.
+type Event struct {
+	Round    uint64
+	Contract AccountID
+	TxID     TransactionID
+
+	Topic string
+	Data  []byte
+}
+
+func (e Event) Marshal() []byte {
+	var w bytes.Buffer
+
+	var buf [8]byte
+
+	binary.BigEndian.PutUint64(buf[:], e.Round)
+	w.Write(buf[:8])
+
+	w.Write(e.Contract[:])
+	w.Write(e.TxID[:])
+
+	binary.BigEndian.PutUint32(buf[:4], uint32(len(e.Topic)))
+	w.Write(buf[:4])
+	w.WriteString(e.Topic)
+
+	binary.BigEndian.PutUint32(buf[:4], uint32(len(e.Data)))
+	w.Write(buf[:4])
+	w.Write(e.Data)
+
+	return w.Bytes()
+}
+
+func UnmarshalEvent(r io.Reader) (e Event, err error) {
+	var buf [8]byte
+
+	if _, err = io.ReadFull(r, buf[:]); err != nil {
+		err = errors.Wrap(err, "failed to decode event round index")
+		return
+	}
+
+	e.Round = binary.BigEndian.Uint64(buf[:8])
+
+	if _, err = io.ReadFull(r, e.Contract[:]); err != nil {
+		err = errors.Wrap(err, "failed to decode event contract ID")
+		return
+	}
+
+	if _, err = io.ReadFull(r, e.TxID[:]); err != nil {
+		err = errors.Wrap(err, "failed to decode event transaction ID")
+		return
+	}
+
+	if _, err = io.ReadFull(r, buf[:4]); err != nil {
+		err = errors.Wrap(err, "failed to decode event topic length")
+		return
+	}
+
+	topic := make([]byte, binary.BigEndian.Uint32(buf[:4]))
+
+	if _, err = io.ReadFull(r, topic); err != nil {
+		err = errors.Wrap(err, "failed to decode event topic")
+		return
+	}
+
+	e.Topic = string(topic)
+
+	if _, err = io.ReadFull(r, buf[:4]); err != nil {
+		err = errors.Wrap(err, "failed to decode event data length")
+		return
+	}
+
+	e.Data = make([]byte, binary.BigEndian.Uint32(buf[:4]))
+
+	if _, err = io.ReadFull(r, e.Data); err != nil {
+		err = errors.Wrap(err, "failed to decode event data")
+		return
+	}
+
+	return
+}
@@ -20,10 +20,12 @@
 package wavelet
 
 import (
+	"bytes"
 	"context"
 	"github.com/perlin-network/noise/skademlia"
 	"github.com/perlin-network/wavelet/debounce"
 	"github.com/perlin-network/wavelet/log"
+	"sort"
 	"sync"
 	"time"
 )
@@ -65,6 +67,8 @@ func (g *Gossiper) Push(tx Transaction) {
 }
 
 func (g *Gossiper) Gossip(transactions [][]byte) {
+	sortByTip(transactions)
+
 	batch := &Transactions{Transactions: transactions}
 
 	peers := g.client.ClosestPeers()
@@ -96,3 +100,36 @@ func (g *Gossiper) Gossip(transactions [][]byte) {
 
 	wg.Wait()
 }
+
+// sortByTip orders a batch of marshaled transactions such that transactions whose
+// creators paid a higher priority tip are gossiped first. Transactions that fail to
+// be unmarshaled are treated as having paid no tip.
+func sortByTip(transactions [][]byte) {
+	tips := make([]uint64, len(transactions))
+
+	for i, buf := range transactions {
+		if tx, err := UnmarshalTransaction(bytes.NewReader(buf)); err == nil {
+			tips[i] = tx.Tip
+		}
+	}
+
+	sort.Stable(byTip{transactions: transactions, tips: tips})
+}
+
+type byTip struct {
+	transactions [][]byte
+	tips         []uint64
+}
+
+func (b byTip) Len() int {
+	return len(b.transactions)
+}
+
+func (b byTip) Less(i, j int) bool {
+	return b.tips[i] > b.tips[j]
+}
+
+func (b byTip) Swap(i, j int) {
+	b.transactions[i], b.transactions[j] = b.transactions[j], b.transactions[i]
+	b.tips[i], b.tips[j] = b.tips[j], b.tips[i]
+}
@@ -345,7 +345,7 @@ func (g *Graph) FindEligibleParents() []*Transaction {
 
 		eligibleParents = append(eligibleParents, (*Transaction)(eligibleParent))
 
-		return len(eligibleParents) != sys.MaxParentsPerTransaction
+		return true
 	})
 
 	for _, i := range pending {
@@ -354,6 +354,17 @@ func (g *Graph) FindEligibleParents() []*Transaction {
 
 	g.Unlock()
 
+	// Prefer parents whose creators paid a higher priority tip. Parents with equal
+	// tips retain their ordering by depth.
+
+	sort.SliceStable(eligibleParents, func(i, j int) bool {
+		return eligibleParents[i].Tip > eligibleParents[j].Tip
+	})
+
+	if len(eligibleParents) > sys.MaxParentsPerTransaction {
+		eligibleParents = eligibleParents[:sys.MaxParentsPerTransaction]
+	}
+
 	return eligibleParents
 }
 
@@ -653,7 +664,7 @@ func (g *Graph) validateTransaction(tx Transaction) error {
 		set[tx.ParentIDs[i]] = struct{}{}
 	}
 
-	if tx.Tag > sys.TagBatch {
+	if tx.Tag > sys.TagEvidence {
 		return errors.New("tx has an unknown tag")
 	}
 
@@ -666,10 +677,8 @@ func (g *Graph) validateTransaction(tx Transaction) error {
 	}
 
 	if g.verifySignatures {
-		var nonce [8]byte // TODO(kenta): nonce
-
 		if tx.Sender != tx.Creator {
-			if !edwards25519.Verify(tx.Creator, append(nonce[:], append([]byte{byte(tx.Tag)}, tx.Payload...)...), tx.CreatorSignature) {
+			if !edwards25519.Verify(tx.Creator, tx.CreatorSignaturePayload(), tx.CreatorSignature) {
 				return errors.New("tx has invalid creator signature")
 			}
 		}
@@ -38,7 +38,7 @@ func TestNewGraph(t *testing.T) {
 	keys, err := skademlia.NewKeys(1, 1)
 	assert.NoError(t, err)
 
-	tx := AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil))
+	tx := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil))
 
 	graph := NewGraph(WithRoot(tx))
 	eligible := graph.FindEligibleParents()
@@ -46,7 +46,7 @@ func TestNewGraph(t *testing.T) {
 	assert.Len(t, eligible, 1)
 	assert.Equal(t, tx, *eligible[0])
 
-	tx2 := AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil), eligible...)
+	tx2 := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil), eligible...)
 
 	assert.NoError(t, graph.AddTransaction(tx2))
 	assert.NotNil(t, graph.FindTransaction(tx2.ID))
@@ -67,17 +67,17 @@ func TestGraphValidateTransaction(t *testing.T) {
 	keys, err := skademlia.NewKeys(1, 1)
 	assert.NoError(t, err)
 
-	root := AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil))
+	root := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil))
 	graph := NewGraph(WithRoot(root), VerifySignatures())
 
 	tests := []struct {
 		Tx  func() Transaction
 		Err string
 	}{
-		{func() Transaction { return NewTransaction(keys, sys.TagNop, nil) }, "tx must have an ID"},
+		{func() Transaction { return NewTransaction(keys, 0, sys.TagNop, nil) }, "tx must have an ID"},
 		{
 			func() Transaction {
-				tx := AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil), graph.FindEligibleParents()...)
+				tx := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil), graph.FindEligibleParents()...)
 				tx.Sender = ZeroAccountID
 				return tx
 			},
@@ -85,7 +85,7 @@ func TestGraphValidateTransaction(t *testing.T) {
 		},
 		{
 			func() Transaction {
-				tx := AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil), graph.FindEligibleParents()...)
+				tx := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil), graph.FindEligibleParents()...)
 				tx.Creator = ZeroAccountID
 				return tx
 			},
@@ -94,7 +94,7 @@ func TestGraphValidateTransaction(t *testing.T) {
 		{
 			func() Transaction {
 				k, _ := skademlia.NewKeys(1, 1)
-				return AttachSenderToTransaction(k, NewTransaction(k, sys.TagNop, nil), []*Transaction{}...)
+				return AttachSenderToTransaction(k, NewTransaction(k, 0, sys.TagNop, nil), []*Transaction{}...)
 			},
 			"transaction has no parents",
 		},
@@ -104,17 +104,17 @@ func TestGraphValidateTransaction(t *testing.T) {
 
 				parents := []*Transaction{}
 				for i := 0; i < sys.MaxParentsPerTransaction+1; i++ {
-					tx := NewTransaction(k, sys.TagNop, nil)
+					tx := NewTransaction(k, 0, sys.TagNop, nil)
 					parents = append(parents, &tx)
 				}
 
-				return AttachSenderToTransaction(k, NewTransaction(k, sys.TagNop, nil), parents...)
+				return AttachSenderToTransaction(k, NewTransaction(k, 0, sys.TagNop, nil), parents...)
 			},
 			"tx has 33 parents, but tx may only have 32 parents at most",
 		},
 		{
 			func() Transaction {
-				tx := AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil), graph.FindEligibleParents()...)
+				tx := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil), graph.FindEligibleParents()...)
 				tx.ParentIDs = append(tx.ParentIDs, tx.ID)
 				tx.ParentSeeds = append(tx.ParentSeeds, tx.Seed)
 				tx.SenderSignature = edwards25519.Sign(keys.PrivateKey(), tx.Marshal())
@@ -130,12 +130,12 @@ func TestGraphValidateTransaction(t *testing.T) {
 				parents := make([]*Transaction, 6)
 				parents[0] = &root
 				for i := 0; i < 5; i++ {
-					tx := AttachSenderToTransaction(k, NewTransaction(k, sys.TagNop, nil), []*Transaction{parents[i]}...)
+					tx := AttachSenderToTransaction(k, NewTransaction(k, 0, sys.TagNop, nil), []*Transaction{parents[i]}...)
 					assert.NoError(t, graph.AddTransaction(tx))
 					parents[i+1] = &tx
 				}
 
-				tx := AttachSenderToTransaction(keys, NewTransaction(k, sys.TagNop, nil), parents...)
+				tx := AttachSenderToTransaction(keys, NewTransaction(k, 0, sys.TagNop, nil), parents...)
 				tx.ParentIDs[0], tx.ParentIDs[1] = tx.ParentIDs[1], tx.ParentIDs[0]
 				tx.ParentSeeds[0], tx.ParentSeeds[1] = tx.ParentSeeds[1], tx.ParentSeeds[0]
 				tx.SenderSignature = edwards25519.Sign(keys.PrivateKey(), tx.Marshal())
@@ -148,19 +148,19 @@ func TestGraphValidateTransaction(t *testing.T) {
 			func() Transaction {
 				parents := graph.FindEligibleParents()
 				parents = append(parents, parents[0])
-				return AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil), parents...)
+				return AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil), parents...)
 			},
 			"tx must not have duplicate parent ids",
 		},
 		{
 			func() Transaction {
-				return AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagBatch+1, nil), graph.FindEligibleParents()...)
+				return AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagEvidence+1, nil), graph.FindEligibleParents()...)
 			},
 			"tx has an unknown tag",
 		},
 		{
 			func() Transaction {
-				return AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagTransfer, nil), graph.FindEligibleParents()...)
+				return AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagTransfer, nil), graph.FindEligibleParents()...)
 			},
 			"tx must have payload if not a nop transaction",
 		},
@@ -168,14 +168,14 @@ func TestGraphValidateTransaction(t *testing.T) {
 			func() Transaction {
 				payload := bytes.NewBuffer(nil)
 				payload.Write([]byte("foobar"))
-				return AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, payload.Bytes()), graph.FindEligibleParents()...)
+				return AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, payload.Bytes()), graph.FindEligibleParents()...)
 			},
 			"tx must have no payload if is a nop transaction",
 		},
 		{
 			func() Transaction {
 				k, _ := skademlia.NewKeys(1, 1)
-				tx := AttachSenderToTransaction(keys, NewTransaction(k, sys.TagNop, nil), graph.FindEligibleParents()...)
+				tx := AttachSenderToTransaction(keys, NewTransaction(k, 0, sys.TagNop, nil), graph.FindEligibleParents()...)
 				tx.CreatorSignature[0] = '0'
 				return tx
 			},
@@ -184,7 +184,7 @@ func TestGraphValidateTransaction(t *testing.T) {
 		{
 			func() Transaction {
 				k, _ := skademlia.NewKeys(1, 1)
-				tx := AttachSenderToTransaction(keys, NewTransaction(k, sys.TagNop, nil), graph.FindEligibleParents()...)
+				tx := AttachSenderToTransaction(keys, NewTransaction(k, 0, sys.TagNop, nil), graph.FindEligibleParents()...)
 				tx.SenderSignature[0] = '0'
 				return tx
 			},
@@ -205,7 +205,7 @@ func TestGraphFuzz(t *testing.T) {
 	keys, err := skademlia.NewKeys(1, 1)
 	assert.NoError(t, err)
 
-	root := AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil))
+	root := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil))
 	graph := NewGraph(WithRoot(root))
 
 	count := 1
@@ -219,7 +219,7 @@ func TestGraphFuzz(t *testing.T) {
 			_, err = rand.Read(payload[:])
 			assert.NoError(t, err)
 
-			depth = append(depth, AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagTransfer, payload[:]), graph.FindEligibleParents()...))
+			depth = append(depth, AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagTransfer, payload[:]), graph.FindEligibleParents()...))
 		}
 
 		for _, tx := range depth {
@@ -279,7 +279,7 @@ func TestGraphPruneBelowDepth(t *testing.T) {
 	keys, err := skademlia.NewKeys(1, 1)
 	assert.NoError(t, err)
 
-	root := AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil))
+	root := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil))
 	graph := NewGraph(WithRoot(root))
 
 	count := 1
@@ -299,7 +299,7 @@ func TestGraphPruneBelowDepth(t *testing.T) {
 			_, err = rand.Read(payload[:])
 			assert.NoError(t, err)
 
-			depth = append(depth, AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagTransfer, payload[:]), graph.FindEligibleParents()...))
+			depth = append(depth, AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagTransfer, payload[:]), graph.FindEligibleParents()...))
 		}
 
 		for _, tx := range depth {
@@ -343,7 +343,7 @@ func TestGraphUpdateRoot(t *testing.T) {
 	keys, err := skademlia.NewKeys(1, 1)
 	assert.NoError(t, err)
 
-	root := AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil))
+	root := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil))
 	graph := NewGraph(WithRoot(root))
 
 	for i := 0; i < 50; i++ {
@@ -355,7 +355,7 @@ func TestGraphUpdateRoot(t *testing.T) {
 			_, err = rand.Read(payload[:])
 			assert.NoError(t, err)
 
-			depth = append(depth, AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagTransfer, payload[:]), graph.FindEligibleParents()...))
+			depth = append(depth, AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagTransfer, payload[:]), graph.FindEligibleParents()...))
 		}
 
 		for _, tx := range depth {
@@ -386,13 +386,13 @@ func TestGraphUpdateRoot(t *testing.T) {
 	assert.Len(t, graph.children, numChildren)
 
 	// Create a transaction that is at an ineligible depth exceeding DEPTH_DIFF.
-	tx := AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil), graph.depthIndex[(graph.height-1)-(sys.MaxDepthDiff+2)][0])
+	tx := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil), graph.depthIndex[(graph.height-1)-(sys.MaxDepthDiff+2)][0])
 
 	// An error should occur.
 	assert.Error(t, graph.AddTransaction(tx))
 
 	// Create a transaction at an eligible depth.
-	tx = AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil), graph.FindEligibleParents()...)
+	tx = AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil), graph.FindEligibleParents()...)
 
 	// No error should occur.
 	assert.NoError(t, graph.AddTransaction(tx))
@@ -404,7 +404,7 @@ func TestGraphValidateTransactionParents(t *testing.T) {
 	keys, err := skademlia.NewKeys(1, 1)
 	assert.NoError(t, err)
 
-	root := AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil))
+	root := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil))
 	graph := NewGraph(WithRoot(root))
 
 	for i := 0; i < 50; i++ {
@@ -416,7 +416,7 @@ func TestGraphValidateTransactionParents(t *testing.T) {
 			_, err = rand.Read(payload[:])
 			assert.NoError(t, err)
 
-			depth = append(depth, AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagTransfer, payload[:]), graph.FindEligibleParents()...))
+			depth = append(depth, AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagTransfer, payload[:]), graph.FindEligibleParents()...))
 		}
 
 		for _, tx := range depth {
@@ -424,7 +424,7 @@ func TestGraphValidateTransactionParents(t *testing.T) {
 		}
 	}
 
-	tx := AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil), graph.depthIndex[(graph.height-1)-(sys.MaxDepthDiff+2)][0])
+	tx := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil), graph.depthIndex[(graph.height-1)-(sys.MaxDepthDiff+2)][0])
 	assert.NoError(t, graph.validateTransactionParents(&tx))
 
 	assert.Equal(t, len(tx.ParentIDs), len(tx.ParentSeeds))
@@ -447,14 +447,14 @@ func TestGraphFindEligibleCritical(t *testing.T) {
 	keys, err := skademlia.NewKeys(1, 1)
 	assert.NoError(t, err)
 
-	root := AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil))
+	root := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil))
 	graph := NewGraph(WithRoot(root))
 
 	// Go through a range of difficulties, and check if we can always
 	// find the eligible critical transaction.
 
 	for difficulty := byte(2); difficulty < 8; difficulty++ {
-		eligible := AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil), graph.FindEligibleParents()...)
+		eligible := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil), graph.FindEligibleParents()...)
 
 		for {
 			if eligible.IsCritical(difficulty) {
@@ -464,13 +464,13 @@ func TestGraphFindEligibleCritical(t *testing.T) {
 			sender, err := skademlia.NewKeys(1, 1)
 			assert.NoError(t, err)
 
-			eligible = AttachSenderToTransaction(sender, NewTransaction(keys, sys.TagNop, nil), graph.FindEligibleParents()...)
+			eligible = AttachSenderToTransaction(sender, NewTransaction(keys, 0, sys.TagNop, nil), graph.FindEligibleParents()...)
 		}
 
 		assert.NoError(t, graph.AddTransaction(eligible))
 		assert.Equal(t, *graph.FindEligibleCritical(difficulty), eligible)
 
-		root = AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil))
+		root = AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil))
 		graph = NewGraph(WithRoot(root))
 	}
 }
@@ -481,7 +481,7 @@ func TestGraphFindEligibleCriticalInBigGraph(t *testing.T) {
 	keys, err := skademlia.NewKeys(1, 1)
 	assert.NoError(t, err)
 
-	root := AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil))
+	root := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil))
 	graph := NewGraph(WithRoot(root))
 
 	difficulty := byte(8)
@@ -494,7 +494,7 @@ func TestGraphFindEligibleCriticalInBigGraph(t *testing.T) {
 		}
 
 		if i == 500/2 { // Create an eligible critical transaction in the middle of the graph.
-			eligible = AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil), graph.FindEligibleParents()...)
+			eligible = AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil), graph.FindEligibleParents()...)
 
 			for {
 				if eligible.IsCritical(difficulty) {
@@ -504,7 +504,7 @@ func TestGraphFindEligibleCriticalInBigGraph(t *testing.T) {
 				sender, err := skademlia.NewKeys(1, 1)
 				assert.NoError(t, err)
 
-				eligible = AttachSenderToTransaction(sender, NewTransaction(keys, sys.TagNop, nil), graph.FindEligibleParents()...)
+				eligible = AttachSenderToTransaction(sender, NewTransaction(keys, 0, sys.TagNop, nil), graph.FindEligibleParents()...)
 			}
 
 			assert.NoError(t, graph.AddTransaction(eligible))
@@ -518,7 +518,7 @@ func TestGraphFindEligibleCriticalInBigGraph(t *testing.T) {
 			_, err = rand.Read(payload[:])
 			assert.NoError(t, err)
 
-			tx := AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagTransfer, payload[:]), graph.FindEligibleParents()...)
+			tx := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagTransfer, payload[:]), graph.FindEligibleParents()...)
 
 			for { // Be sure we never create a transaction with the difficulty we set.
 				if !tx.IsCritical(difficulty) {
@@ -528,7 +528,7 @@ func TestGraphFindEligibleCriticalInBigGraph(t *testing.T) {
 				sender, err := skademlia.NewKeys(1, 1)
 				assert.NoError(t, err)
 
-				tx = AttachSenderToTransaction(sender, NewTransaction(keys, sys.TagTransfer, payload[:]), graph.FindEligibleParents()...)
+				tx = AttachSenderToTransaction(sender, NewTransaction(keys, 0, sys.TagTransfer, payload[:]), graph.FindEligibleParents()...)
 			}
 
 			depth = append(depth, tx)
@@ -541,3 +541,29 @@ func TestGraphFindEligibleCriticalInBigGraph(t *testing.T) {
 
 	assert.Equal(t, *graph.FindEligibleCritical(difficulty), eligible)
 }
+
+func TestGraphFindEligibleParentsPrefersTips(t *testing.T) {
+	t.Parallel()
+
+	keys, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	root := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil))
+	graph := NewGraph(WithRoot(root))
+
+	a := AttachSenderToTransaction(keys, NewTransaction(keys, 1, sys.TagNop, nil), &root)
+	assert.NoError(t, graph.AddTransaction(a))
+
+	b := AttachSenderToTransaction(keys, NewTransaction(keys, 2, sys.TagNop, nil), &a)
+	assert.NoError(t, graph.AddTransaction(b))
+
+	c := AttachSenderToTransaction(keys, NewTransactionWithTip(keys, 3, 10, sys.TagNop, nil), &root)
+	assert.NoError(t, graph.AddTransaction(c))
+
+	// Parents are ordered by depth, unless a shallower parent pays a higher tip.
+
+	eligible := graph.FindEligibleParents()
+	assert.Len(t, eligible, 2)
+	assert.Equal(t, c.ID, eligible[0].ID)
+	assert.Equal(t, b.ID, eligible[1].ID)
+}
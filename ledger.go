@@ -43,7 +43,9 @@ import (
 )
 
 type Ledger struct {
+	db      store.KV
 	client  *skademlia.Client
+	peers   *PeerBook
 	metrics *Metrics
 	indexer *Indexer
 
@@ -64,13 +66,47 @@ type Ledger struct {
 	sync      chan struct{}
 	syncVotes chan vote
 
+	validatorsRound RoundID
+	validatorsStake map[AccountID]uint64
+	validatorsLock  sync.Mutex
+
 	cacheCollapse *LRU
 	cacheChunks   *LRU
 
 	sendQuota chan struct{}
+
+	nonce     uint64
+	nonceLock sync.Mutex
+
+	archive       bool
+	archiveWindow uint64
+
+	light bool
 }
 
-func NewLedger(kv store.KV, client *skademlia.Client, genesis *string) *Ledger {
+type LedgerOption func(*Ledger)
+
+// WithArchive has the ledger keep the state of all accounts at each finalized round rather than
+// garbage collecting it, such that it may be queried later. Only the states of the last window
+// rounds are kept, or the states of all rounds should window be zero.
+func WithArchive(window uint64) LedgerOption {
+	return func(l *Ledger) {
+		l.archive = true
+		l.archiveWindow = window
+	}
+}
+
+// WithLight has the ledger run as a light client, which only follows the headers of finalized
+// rounds rather than participating in consensus and keeping the state of all accounts. The state
+// of an account is instead fetched from peers on demand, and verified against the Merkle root of
+// a round.
+func WithLight() LedgerOption {
+	return func(l *Ledger) {
+		l.light = true
+	}
+}
+
+func NewLedger(kv store.KV, client *skademlia.Client, genesis *string, opts ...LedgerOption) *Ledger {
 	logger := log.Node()
 
 	metrics := NewMetrics(context.TODO())
@@ -85,14 +121,11 @@ func NewLedger(kv store.KV, client *skademlia.Client, genesis *string) *Ledger {
 
 	if rounds != nil && err != nil {
 		genesis := performInception(accounts.tree, genesis)
-		if err := accounts.Commit(nil); err != nil {
-			logger.Fatal().Err(err).Msg("BUG: accounts.Commit")
-		}
 
 		ptr := &genesis
 
-		if _, err := rounds.Save(ptr); err != nil {
-			logger.Fatal().Err(err).Msg("BUG: rounds.Save")
+		if _, err := commitRound(accounts, rounds, ptr, nil, false); err != nil {
+			logger.Fatal().Err(err).Msg("BUG: commitRound")
 		}
 
 		round = ptr
@@ -104,20 +137,19 @@ func NewLedger(kv store.KV, client *skademlia.Client, genesis *string) *Ledger {
 		logger.Fatal().Err(err).Msg("BUG: COULD NOT FIND GENESIS, OR STORAGE IS CORRUPTED.")
 	}
 
-	graph := NewGraph(WithMetrics(metrics), WithIndexer(indexer), WithRoot(round.End), VerifySignatures())
-
 	gossiper := NewGossiper(context.TODO(), client, metrics)
 	finalizer := NewSnowball(WithName("finalizer"), WithBeta(sys.SnowballBeta))
 	syncer := NewSnowball(WithName("syncer"), WithBeta(sys.SnowballBeta))
 
 	ledger := &Ledger{
+		db:      kv,
 		client:  client,
+		peers:   NewPeerBook(),
 		metrics: metrics,
 		indexer: indexer,
 
 		accounts: accounts,
 		rounds:   rounds,
-		graph:    graph,
 
 		gossiper:  gossiper,
 		finalizer: finalizer,
@@ -132,9 +164,33 @@ func NewLedger(kv store.KV, client *skademlia.Client, genesis *string) *Ledger {
 		sendQuota: make(chan struct{}, 2000),
 	}
 
-	ledger.PerformConsensus()
+	for _, opt := range opts {
+		opt(ledger)
+	}
+
+	// Light clients only keep the headers of rounds, and thus never have their accounts state
+	// match up with the latest round.
+	if !ledger.light {
+		if round, err = ledger.recoverLatestRound(); err != nil {
+			logger.Fatal().Err(err).Msg("BUG: COULD NOT FIND GENESIS, OR STORAGE IS CORRUPTED.")
+		}
+	}
+
+	ledger.graph = NewGraph(WithMetrics(metrics), WithIndexer(indexer), WithRoot(round.End), VerifySignatures())
+
+	if ledger.archive {
+		accounts.archive = true
+		accounts.archiveWindow = ledger.archiveWindow
+	}
+
+	if ledger.light {
+		go ledger.FollowRounds()
+	} else {
+		ledger.PerformConsensus()
+
+		go ledger.SyncToLatestRound()
+	}
 
-	go ledger.SyncToLatestRound()
 	go ledger.PushSendQuota()
 
 	return ledger
@@ -234,6 +290,77 @@ func (l *Ledger) TakeSendQuota() bool {
 	}
 }
 
+// NextNonce returns the nonce this node should sign the next transaction it creates with.
+// Nonces are tracked locally such that multiple transactions may be created by the node
+// within a single round, and are fast-forwarded to the nodes account nonce should the
+// account nonce ever surpass the locally tracked one.
+func (l *Ledger) NextNonce() uint64 {
+	publicKey := l.client.Keys().PublicKey()
+	accountNonce, _ := ReadAccountNonce(l.accounts.Snapshot(), publicKey)
+
+	l.nonceLock.Lock()
+	defer l.nonceLock.Unlock()
+
+	if l.nonce < accountNonce {
+		l.nonce = accountNonce
+	}
+
+	nonce := l.nonce
+	l.nonce++
+
+	return nonce
+}
+
+// ReleaseNonce hands a nonce returned by NextNonce back should the transaction it was signed
+// into have failed to be created or added to the graph. Should later nonces have been handed
+// out since, the locally tracked nonce is rewound to the nodes account nonce instead, as
+// transactions signed with those later nonces may no longer be applied.
+func (l *Ledger) ReleaseNonce(nonce uint64) {
+	publicKey := l.client.Keys().PublicKey()
+	accountNonce, _ := ReadAccountNonce(l.accounts.Snapshot(), publicKey)
+
+	l.nonceLock.Lock()
+	defer l.nonceLock.Unlock()
+
+	if l.nonce == nonce+1 && nonce >= accountNonce {
+		l.nonce = nonce
+	} else {
+		l.nonce = accountNonce
+	}
+}
+
+// reconcileNonce rewinds the locally tracked nonce back to the nodes account nonce after a round
+// is finalized, should any transaction created by this node have been rejected in the round, or
+// should the graph no longer hold any transaction created by this node that is yet to be applied.
+// Either case leaves behind a nonce that will never be consumed, which would otherwise have every
+// later transaction created by this node be rejected.
+func (l *Ledger) reconcileNonce(rejected []*Transaction) {
+	publicKey := l.client.Keys().PublicKey()
+	accountNonce, _ := ReadAccountNonce(l.accounts.Snapshot(), publicKey)
+
+	l.nonceLock.Lock()
+	defer l.nonceLock.Unlock()
+
+	if l.nonce <= accountNonce {
+		return
+	}
+
+	for _, tx := range rejected {
+		if tx.Creator == publicKey {
+			l.nonce = accountNonce
+			return
+		}
+	}
+
+	for _, tx := range l.graph.ListTransactions(0, 0, ZeroAccountID, publicKey) {
+		if tx.Nonce >= accountNonce {
+			return
+		}
+	}
+
+	l.nonce = accountNonce
+}
+
 // Protocol returns an implementation of WaveletServer to handle incoming
 // RPC and streams for the ledger. The protocol is agnostic to whatever
 // choice of network stack is used with Wavelet, though by default it is
@@ -267,10 +394,174 @@ func (l *Ledger) PerformConsensus() {
 	go l.FinalizeRounds()
 }
 
+// RoundEvents returns all events emitted by smart contracts within a finalized round.
+func (l *Ledger) RoundEvents(round uint64) ([]Event, error) {
+	return LoadRoundEvents(l.db, round)
+}
+
+// RoundVotes returns the signed votes cast by validators while a round was being finalized. Only
+// the latest vote of each validator is kept.
+func (l *Ledger) RoundVotes(round uint64) ([]Vote, error) {
+	return LoadRoundVotes(l.db, round)
+}
+
+// Receipt returns the receipt of a finalized transaction, or nil should the transaction not have
+// been finalized yet.
+func (l *Ledger) Receipt(id TransactionID) (*Receipt, error) {
+	return LoadReceipt(l.db, id)
+}
+
+// Light returns whether or not the ledger runs as a light client.
+func (l *Ledger) Light() bool {
+	return l.light
+}
+
 func (l *Ledger) Snapshot() *avl.Tree {
 	return l.accounts.Snapshot()
 }
 
+// validators returns the total stake of every validator as of the latest round. The accounts tree
+// is only scanned for validators once per round, as peers are repeatedly sampled and their votes
+// tallied throughout it. The map returned must not be modified.
+func (l *Ledger) validators() map[AccountID]uint64 {
+	latest := l.rounds.Latest()
+
+	l.validatorsLock.Lock()
+	defer l.validatorsLock.Unlock()
+
+	if l.validatorsStake == nil || l.validatorsRound != latest.ID {
+		l.validatorsStake = ReadStakedAccounts(l.accounts.Snapshot())
+		l.validatorsRound = latest.ID
+	}
+
+	return l.validatorsStake
+}
+
+// SnapshotAt returns a snapshot of all accounts as they were at the end of a finalized round. Should
+// the ledger not be an archive, only the state of the latest round and the round prior to it, whose
+// Merkle root is the latest of the old roots of the accounts tree, are available.
+func (l *Ledger) SnapshotAt(round uint64) (*avl.Tree, error) {
+	if l.light {
+		return nil, errors.New("light nodes do not keep the state of accounts")
+	}
+
+	latest := l.rounds.Latest()
+
+	if round == latest.Index {
+		return l.Snapshot(), nil
+	}
+
+	if round > latest.Index {
+		return nil, errors.Errorf("round %d has not been finalized yet; the latest round is %d", round, latest.Index)
+	}
+
+	if !l.archive {
+		// Nodes that are not archives only keep the latest of the old roots of the accounts tree
+		// from being garbage collected.
+
+		if past, err := l.rounds.GetByIndex(round); err == nil {
+			if next := l.accounts.NextOldRootIndex(); next > 0 {
+				if root, exists := l.accounts.OldRoot(next - 1); exists && root == past.Merkle {
+					return l.accounts.SnapshotAt(root)
+				}
+			}
+		}
+
+		return nil, errors.Errorf("the state of round %d is not available, as this node is not an archive node and only keeps the state of the latest round and the round prior to it", round)
+	}
+
+	if l.archiveWindow != 0 && latest.Index-round > l.archiveWindow {
+		return nil, errors.Errorf("the state of round %d has been pruned, as only the state of the last %d rounds are kept", round, l.archiveWindow)
+	}
+
+	idx, exists := LoadArchivedRootIndex(l.db, round)
+	if !exists {
+		return nil, errors.Errorf("the state of round %d was never archived", round)
+	}
+
+	root, exists := l.accounts.OldRoot(idx)
+	if !exists {
+		return nil, errors.Errorf("the state of round %d has been pruned", round)
+	}
+
+	return l.accounts.SnapshotAt(root)
+}
+
+// commitRound atomically commits the state of all accounts at the end of a round, or the current
+// state of all accounts should snapshot be nil, alongside the round itself. Committing the state of
+// all accounts moves the Merkle root of the latest round prior amongst the old roots of the
+// accounts tree; should archive be true, the index it is moved to is committed alongside as well.
+// It returns the round that got pruned to make space for the round, if any.
+func commitRound(accounts *Accounts, rounds *Rounds, round *Round, snapshot *avl.Tree, archive bool) (*Round, error) {
+	return commitRoundWithBatch(rounds.store.NewWriteBatch(), accounts, rounds, round, snapshot, archive)
+}
+
+// commitRoundWithBatch commits a round just like commitRound, atomically alongside all writes
+// already staged in batch.
+func commitRoundWithBatch(batch store.WriteBatch, accounts *Accounts, rounds *Rounds, round *Round, snapshot *avl.Tree, archive bool) (*Round, error) {
+	if archive {
+		if kept := rounds.Clone(); len(kept) > 0 {
+			if latest := kept[len(kept)-1]; latest.Merkle == accounts.Snapshot().Checksum() {
+				StoreArchivedRootIndexWithBatch(batch, latest.Index, accounts.NextOldRootIndex())
+			}
+		}
+	}
+
+	pruned := rounds.SaveWithBatch(round, batch)
+
+	if err := accounts.CommitWithBatch(snapshot, batch); err != nil {
+		return pruned, errors.Wrapf(err, "failed to commit round %d", round.Index)
+	}
+
+	return pruned, nil
+}
+
+// recoverLatestRound checks that the state of all accounts matches up with the Merkle root of the
+// latest round. Should the node have crashed in between committing the two, the rounds are rewound
+// to the latest round whose Merkle root matches up with the state of all accounts, or whose state
+// may still be loaded from the database.
+func (l *Ledger) recoverLatestRound() (*Round, error) {
+	latest := l.rounds.Latest()
+	checksum := l.accounts.Snapshot().Checksum()
+
+	if latest.Merkle == checksum {
+		return latest, nil
+	}
+
+	logger := log.Node()
+
+	rounds := l.rounds.Clone()
+
+	for i := len(rounds) - 1; i >= 0; i-- {
+		round := rounds[i]
+
+		if round.Merkle != checksum {
+			snapshot, err := l.accounts.SnapshotAt(round.Merkle)
+			if err != nil {
+				continue
+			}
+
+			if err := l.accounts.Commit(snapshot); err != nil {
+				return nil, errors.Wrapf(err, "failed to roll back the state of all accounts to round %d", round.Index)
+			}
+		}
+
+		if err := l.rounds.Rewind(round.Index); err != nil {
+			return nil, errors.Wrapf(err, "failed to rewind rounds back to round %d", round.Index)
+		}
+
+		logger.Warn().
+			Uint64("latest_round", latest.Index).
+			Uint64("recovered_round", round.Index).
+			Hex("merkle_root", round.Merkle[:]).
+			Msg("The state of all accounts did not match up with the latest round stored. Recovered back to the last consistent round.")
+
+		return round, nil
+	}
+
+	return nil, errors.Errorf("the state of all accounts with merkle root %x does not match up with any stored round", checksum)
+}
+
 // BroadcastNop has the node send a nop transaction should they have sufficient
 // balance available. They are broadcasted if no other transaction that is not a nop transaction
 // is not broadcasted by the node after 500 milliseconds. These conditions only apply so long as
@@ -292,13 +583,15 @@ func (l *Ledger) BroadcastNop() *Transaction {
 	balance, _ := ReadAccountBalance(l.accounts.Snapshot(), publicKey)
 
 	// FIXME(kenta): FOR TESTNET ONLY. FAUCET DOES NOT GET ANY PERLs DEDUCTED.
-	if balance < sys.TransactionFeeAmount && hex.EncodeToString(publicKey[:]) != sys.FaucetAddress {
+	if balance < (Transaction{Tag: sys.TagNop}).Fee() && hex.EncodeToString(publicKey[:]) != sys.FaucetAddress {
 		return nil
 	}
 
-	nop := AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil), l.graph.FindEligibleParents()...)
+	nonce := l.NextNonce()
+	nop := AttachSenderToTransaction(keys, NewTransaction(keys, nonce, sys.TagNop, nil), l.graph.FindEligibleParents()...)
 
 	if err := l.AddTransaction(nop); err != nil {
+		l.ReleaseNonce(nonce)
 		return nil
 	}
 
@@ -483,8 +776,15 @@ FINALIZE_ROUNDS:
 		var workerWG sync.WaitGroup
 		workerWG.Add(cap(workerChan))
 
+		// The latest signed vote of each voter is kept, and committed alongside the round once
+		// it is finalized.
+
+		signed := make(map[AccountID]Vote)
+
 		voteChan := make(chan vote, sys.SnowballK)
-		go CollectVotes(l.accounts, l.finalizer, voteChan, &workerWG)
+		go CollectVotes(l.finalizer, voteChan, &workerWG, l.validators, func(vote Vote) {
+			signed[vote.Voter] = vote
+		})
 
 		req := &QueryRequest{RoundIndex: current.Index + 1}
 
@@ -518,6 +818,9 @@ FINALIZE_ROUNDS:
 							return
 						}
 
+						var signature Signature
+						copy(signature[:], res.Signature)
+
 						round, err := UnmarshalRound(bytes.NewReader(res.Round))
 						if err != nil {
 							voteChan <- vote{voter: voter, preferred: nil}
@@ -536,7 +839,7 @@ FINALIZE_ROUNDS:
 						if round.Index != current.Index+1 {
 							if round.Index > sys.SyncIfRoundsDifferBy+current.Index {
 								select {
-								case l.syncVotes <- vote{voter: voter, preferred: &round}:
+								case l.syncVotes <- vote{voter: voter, preferred: &round, signature: signature}:
 								default:
 								}
 							}
@@ -578,7 +881,7 @@ FINALIZE_ROUNDS:
 							return
 						}
 
-						voteChan <- vote{voter: voter, preferred: &round}
+						voteChan <- vote{voter: voter, preferred: &round, signature: signature}
 					}
 
 					l.metrics.queryLatency.Time(f)
@@ -602,7 +905,7 @@ FINALIZE_ROUNDS:
 			}
 
 			// Randomly sample a peer to query
-			peers, err := SelectPeers(l.client.ClosestPeers(), sys.SnowballK)
+			peers, err := SelectPeersByStake(l.client, l.peers, l.validators(), sys.SnowballK)
 			if err != nil {
 				close(workerChan)
 				workerWG.Wait()
@@ -645,9 +948,23 @@ FINALIZE_ROUNDS:
 			continue
 		}
 
-		pruned, err := l.rounds.Save(finalized)
+		// Events emitted within the round, the receipts of all transactions finalized within the
+		// round, and the signed votes cast while finalizing the round are committed atomically
+		// alongside it.
+
+		batch := l.db.NewWriteBatch()
+
+		StoreRoundVotesWithBatch(batch, finalized.Index, sortVotes(signed))
+
+		if len(results.events) > 0 {
+			StoreRoundEventsWithBatch(batch, finalized.Index, results.events)
+		}
+
+		StoreReceiptsWithBatch(batch, results.receipts)
+
+		pruned, err := commitRoundWithBatch(batch, l.accounts, l.rounds, finalized, results.snapshot, l.archive)
 		if err != nil {
-			fmt.Printf("Failed to save finalized round to our database: %v\n", err)
+			fmt.Printf("Failed to commit finalized round and collapsed state to our database: %v\n", err)
 		}
 
 		if pruned != nil {
@@ -662,15 +979,16 @@ FINALIZE_ROUNDS:
 		}
 
 		l.graph.UpdateRootDepth(finalized.End.Depth)
-
-		if err = l.accounts.Commit(results.snapshot); err != nil {
-			fmt.Printf("Failed to commit collaped state to our database: %v\n", err)
-		}
+		l.reconcileNonce(results.rejected)
 
 		l.metrics.acceptedTX.Mark(int64(results.appliedCount))
 
 		l.LogChanges(results.snapshot, current.Index)
 
+		for _, event := range results.events {
+			logContractEvent(event)
+		}
+
 		logger := log.Consensus("round_end")
 		logger.Info().
 			Int("num_applied_tx", results.appliedCount).
@@ -694,11 +1012,11 @@ FINALIZE_ROUNDS:
 func (l *Ledger) SyncToLatestRound() {
 	voteWG := new(sync.WaitGroup)
 
-	go CollectVotes(l.accounts, l.syncer, l.syncVotes, voteWG)
+	go CollectVotes(l.syncer, l.syncVotes, voteWG, l.validators, nil)
 
 	for {
 		for {
-			conns, err := SelectPeers(l.client.ClosestPeers(), sys.SnowballK)
+			conns, err := SelectPeersByStake(l.client, l.peers, l.validators(), sys.SnowballK)
 			if err != nil {
 				select {
 				case <-time.After(1 * time.Second):
@@ -709,66 +1027,7 @@ func (l *Ledger) SyncToLatestRound() {
 
 			current := l.rounds.Latest()
 
-			var wg sync.WaitGroup
-			wg.Add(len(conns))
-
-			for _, conn := range conns {
-				client := NewWaveletClient(conn)
-
-				go func() {
-					ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-
-					p := &peer.Peer{}
-
-					res, err := client.CheckOutOfSync(ctx, &OutOfSyncRequest{}, grpc.Peer(p))
-					if err != nil {
-						cancel()
-						wg.Done()
-						return
-					}
-
-					cancel()
-
-					info := noise.InfoFromPeer(p)
-					if info == nil {
-						wg.Done()
-						return
-					}
-
-					voter, ok := info.Get(skademlia.KeyID).(*skademlia.ID)
-					if !ok {
-						wg.Done()
-						return
-					}
-
-					round, err := UnmarshalRound(bytes.NewReader(res.Round))
-					if err != nil {
-						wg.Done()
-						return
-					}
-
-					if round.ID == ZeroRoundID || round.Start.ID == ZeroTransactionID || round.End.ID == ZeroTransactionID {
-						wg.Done()
-						return
-					}
-
-					if round.End.Depth <= round.Start.Depth {
-						wg.Done()
-						return
-					}
-
-					if round.Index < sys.SyncIfRoundsDifferBy+current.Index {
-						wg.Done()
-						return
-					}
-
-					l.syncVotes <- vote{voter: voter, preferred: &round}
-
-					wg.Done()
-				}()
-			}
-
-			wg.Wait()
+			l.queryLatestRound(conns, sys.SyncIfRoundsDifferBy+current.Index)
 
 			if l.syncer.Decided() {
 				break
@@ -801,7 +1060,7 @@ func (l *Ledger) SyncToLatestRound() {
 
 		restart := func() { // Respawn all previously stopped workers.
 			l.syncVotes = make(chan vote, sys.SnowballK)
-			go CollectVotes(l.accounts, l.syncer, l.syncVotes, voteWG)
+			go CollectVotes(l.syncer, l.syncVotes, voteWG, l.validators, nil)
 
 			l.sync = make(chan struct{})
 			l.PerformConsensus()
@@ -817,7 +1076,7 @@ func (l *Ledger) SyncToLatestRound() {
 
 	SYNC:
 
-		conns, err := SelectPeers(l.client.ClosestPeers(), sys.SnowballK)
+		conns, err := SelectPeersByStake(l.client, l.peers, l.validators(), sys.SnowballK)
 		if err != nil {
 			logger.Warn().Msg("It looks like there are no peers for us to sync with. Retrying...")
 
@@ -1107,10 +1366,10 @@ func (l *Ledger) SyncToLatestRound() {
 			goto SYNC
 		}
 
-		pruned, err := l.rounds.Save(latest)
+		pruned, err := commitRound(l.accounts, l.rounds, latest, snapshot, l.archive)
 		if err != nil {
-			fmt.Printf("Failed to save finalized round to our database: %v\n", err)
-			goto SYNC
+			logger := log.Node()
+			logger.Fatal().Err(err).Msg("failed to commit synced round and state to our database")
 		}
 
 		if pruned != nil {
@@ -1125,11 +1384,7 @@ func (l *Ledger) SyncToLatestRound() {
 		}
 
 		l.graph.UpdateRoot(latest.End)
-
-		if err := l.accounts.Commit(snapshot); err != nil {
-			logger := log.Node()
-			logger.Fatal().Err(err).Msg("failed to commit collapsed state to our database")
-		}
+		l.reconcileNonce(nil)
 
 		logger = log.Sync("apply")
 		logger.Info().
@@ -1148,6 +1403,75 @@ func (l *Ledger) SyncToLatestRound() {
 	}
 }
 
+// queryLatestRound asks each of the given peers for the latest round they have finalized, and
+// casts every well-formed round with an index of at least minIndex as a vote to the syncing
+// Snowball sampler.
+func (l *Ledger) queryLatestRound(conns []*grpc.ClientConn, minIndex uint64) {
+	var wg sync.WaitGroup
+	wg.Add(len(conns))
+
+	for _, conn := range conns {
+		client := NewWaveletClient(conn)
+
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+
+			p := &peer.Peer{}
+
+			res, err := client.CheckOutOfSync(ctx, &OutOfSyncRequest{}, grpc.Peer(p))
+			if err != nil {
+				cancel()
+				wg.Done()
+				return
+			}
+
+			cancel()
+
+			info := noise.InfoFromPeer(p)
+			if info == nil {
+				wg.Done()
+				return
+			}
+
+			voter, ok := info.Get(skademlia.KeyID).(*skademlia.ID)
+			if !ok {
+				wg.Done()
+				return
+			}
+
+			var signature Signature
+			copy(signature[:], res.Signature)
+
+			round, err := UnmarshalRound(bytes.NewReader(res.Round))
+			if err != nil {
+				wg.Done()
+				return
+			}
+
+			if round.ID == ZeroRoundID || round.Start.ID == ZeroTransactionID || round.End.ID == ZeroTransactionID {
+				wg.Done()
+				return
+			}
+
+			if round.End.Depth <= round.Start.Depth {
+				wg.Done()
+				return
+			}
+
+			if round.Index < minIndex {
+				wg.Done()
+				return
+			}
+
+			l.syncVotes <- vote{voter: voter, preferred: &round, signature: signature}
+
+			wg.Done()
+		}()
+	}
+
+	wg.Wait()
+}
+
 // collapseResults is what returned by calling collapseTransactions. Refer to collapseTransactions
 // to understand what counts of accepted, rejected, or otherwise ignored transactions truly represent
 // after calling collapseTransactions.
@@ -1156,6 +1480,9 @@ type collapseResults struct {
 	rejected       []*Transaction
 	rejectedErrors []error
 
+	events   []Event
+	receipts []Receipt
+
 	appliedCount  int
 	rejectedCount int
 	ignoredCount  int
@@ -0,0 +1,279 @@
+// Copyright (c) 2019 Perlin
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package wavelet
+
+import (
+	"testing"
+
+	"github.com/perlin-network/noise/skademlia"
+	"github.com/perlin-network/wavelet/avl"
+	"github.com/perlin-network/wavelet/store"
+	"github.com/perlin-network/wavelet/sys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecoverLatestRound(t *testing.T) {
+	kv := store.NewInmem()
+
+	accounts := NewAccounts(kv)
+	rounds, _ := NewRounds(kv, sys.PruningLimit)
+
+	genesis := performInception(accounts.tree, nil)
+
+	_, err := commitRound(accounts, rounds, &genesis, nil, false)
+	assert.NoError(t, err)
+
+	// nextRound returns a round, and the state of all accounts at the end of it, that follows
+	// after the latest round.
+	nextRound := func() (*Round, *avl.Tree) {
+		latest := rounds.Latest()
+
+		snapshot := accounts.Snapshot()
+		snapshot.SetViewID(latest.Index + 1)
+
+		WriteAccountBalance(snapshot, AccountID{1}, latest.Index+1)
+
+		round := NewRound(latest.Index+1, snapshot.Checksum(), 0, genesis.End, genesis.End)
+
+		return &round, snapshot
+	}
+
+	reload := func() *Ledger {
+		accounts = NewAccounts(kv)
+		rounds, err = NewRounds(kv, sys.PruningLimit)
+		assert.NoError(t, err)
+
+		return &Ledger{accounts: accounts, rounds: rounds}
+	}
+
+	round1, snapshot := nextRound()
+
+	_, err = commitRound(accounts, rounds, round1, snapshot, true)
+	assert.NoError(t, err)
+
+	// Archives commit where the Merkle root of the round prior is kept amongst the old roots of
+	// the accounts tree alongside the round.
+
+	idx, archived := LoadArchivedRootIndex(kv, genesis.Index)
+	assert.True(t, archived)
+
+	root, exists := accounts.OldRoot(idx)
+	assert.True(t, exists)
+	assert.Equal(t, genesis.Merkle, root)
+
+	_, archived = LoadArchivedRootIndex(kv, round1.Index)
+	assert.False(t, archived)
+
+	recovered, err := reload().recoverLatestRound()
+	assert.NoError(t, err)
+	assert.Equal(t, round1.Index, recovered.Index)
+
+	// Crash after saving a round, but before committing the state of all accounts at the end of it.
+
+	round2, _ := nextRound()
+
+	_, err = rounds.Save(round2)
+	assert.NoError(t, err)
+
+	recovered, err = reload().recoverLatestRound()
+	assert.NoError(t, err)
+	assert.Equal(t, round1.Index, recovered.Index)
+	assert.Equal(t, round1.Index, rounds.Latest().Index)
+
+	recovered, err = reload().recoverLatestRound()
+	assert.NoError(t, err)
+	assert.Equal(t, round1.Index, recovered.Index)
+
+	// Crash after committing the state of all accounts at the end of a round, but before saving the round.
+
+	_, snapshot = nextRound()
+	assert.NoError(t, accounts.Commit(snapshot))
+
+	recovered, err = reload().recoverLatestRound()
+	assert.NoError(t, err)
+	assert.Equal(t, round1.Index, recovered.Index)
+	assert.Equal(t, round1.Merkle, accounts.Snapshot().Checksum())
+
+	balance, _ := ReadAccountBalance(accounts.Snapshot(), AccountID{1})
+	assert.EqualValues(t, round1.Index, balance)
+
+	// Fail should the state of all accounts not match up with any round.
+
+	snapshot = accounts.Snapshot()
+	WriteAccountBalance(snapshot, AccountID{2}, 1)
+	assert.NoError(t, accounts.Commit(snapshot))
+	assert.NoError(t, kv.Delete(append(avl.NodeKeyPrefix, round1.Merkle[:]...)))
+	assert.NoError(t, kv.Delete(append(avl.NodeKeyPrefix, genesis.Merkle[:]...)))
+
+	_, err = reload().recoverLatestRound()
+	assert.Error(t, err)
+}
+
+func TestLedgerSnapshotAt(t *testing.T) {
+	for _, archive := range []bool{false, true} {
+		kv := store.NewInmem()
+
+		accounts := NewAccounts(kv)
+		accounts.archive = archive
+
+		rounds, _ := NewRounds(kv, sys.PruningLimit)
+
+		genesis := performInception(accounts.tree, nil)
+
+		_, err := commitRound(accounts, rounds, &genesis, nil, archive)
+		assert.NoError(t, err)
+
+		for i := uint64(1); i <= 3; i++ {
+			snapshot := accounts.Snapshot()
+			snapshot.SetViewID(i)
+
+			WriteAccountBalance(snapshot, AccountID{1}, i)
+
+			round := NewRound(i, snapshot.Checksum(), 0, genesis.End, genesis.End)
+
+			_, err = commitRound(accounts, rounds, &round, snapshot, archive)
+			assert.NoError(t, err)
+
+			if accounts.profile != nil {
+				_, err = accounts.profile.PerformFullGC()
+				assert.NoError(t, err)
+			}
+		}
+
+		ledger := &Ledger{db: kv, accounts: accounts, rounds: rounds, archive: archive}
+
+		// The state of the latest round, and the round prior to it, are available on all nodes,
+		// whereas the state of rounds before then are only available on archives.
+
+		for i := uint64(1); i <= 3; i++ {
+			snapshot, err := ledger.SnapshotAt(i)
+
+			if !archive && i < 2 {
+				assert.Error(t, err)
+				continue
+			}
+
+			if assert.NoError(t, err) {
+				balance, _ := ReadAccountBalance(snapshot, AccountID{1})
+				assert.Equal(t, i, balance)
+			}
+		}
+
+		_, err = ledger.SnapshotAt(4)
+		assert.Error(t, err)
+	}
+}
+
+func TestCommitRoundWithBatch(t *testing.T) {
+	kv := store.NewInmem()
+
+	accounts := NewAccounts(kv)
+	rounds, _ := NewRounds(kv, sys.PruningLimit)
+
+	genesis := performInception(accounts.tree, nil)
+
+	_, err := commitRound(accounts, rounds, &genesis, nil, false)
+	assert.NoError(t, err)
+
+	snapshot := accounts.Snapshot()
+	snapshot.SetViewID(1)
+
+	WriteAccountBalance(snapshot, AccountID{1}, 1)
+
+	round := NewRound(1, snapshot.Checksum(), 0, genesis.End, genesis.End)
+
+	// Events emitted within a round, the receipts of transactions finalized within the round,
+	// and the votes cast while finalizing the round are committed alongside the round.
+
+	events := []Event{{Round: 1, Contract: AccountID{2}, TxID: TransactionID{3}, Topic: "transfer", Data: []byte("hello")}}
+	receipts := []Receipt{{TxID: TransactionID{3}, Round: 1, Status: ReceiptStatusApplied, Events: events}}
+	votes := []Vote{{Voter: AccountID{4}, RoundIndex: 1, RoundID: RoundID{5}, Finalized: true, Signature: Signature{6}}}
+
+	batch := kv.NewWriteBatch()
+	StoreRoundEventsWithBatch(batch, round.Index, events)
+	StoreReceiptsWithBatch(batch, receipts)
+	StoreRoundVotesWithBatch(batch, round.Index, votes)
+
+	_, err = commitRoundWithBatch(batch, accounts, rounds, &round, snapshot, false)
+	assert.NoError(t, err)
+
+	assert.Equal(t, round.ID, rounds.Latest().ID)
+
+	loaded, err := LoadRoundEvents(kv, round.Index)
+	assert.NoError(t, err)
+	assert.Equal(t, events, loaded)
+
+	receipt, err := LoadReceipt(kv, receipts[0].TxID)
+	assert.NoError(t, err)
+
+	if assert.NotNil(t, receipt) {
+		assert.Equal(t, receipts[0], *receipt)
+	}
+
+	loadedVotes, err := LoadRoundVotes(kv, round.Index)
+	assert.NoError(t, err)
+	assert.Equal(t, votes, loadedVotes)
+
+	loadedVotes, err = LoadRoundVotes(kv, round.Index+1)
+	assert.NoError(t, err)
+	assert.Empty(t, loadedVotes)
+}
+
+func TestReleaseNonce(t *testing.T) {
+	keys, err := skademlia.NewKeys(sys.SKademliaC1, sys.SKademliaC2)
+	assert.NoError(t, err)
+
+	client := skademlia.NewClient("127.0.0.1:0", keys, skademlia.WithC1(sys.SKademliaC1), skademlia.WithC2(sys.SKademliaC2))
+	l := NewLedger(store.NewInmem(), client, nil)
+
+	// Fail to send a transaction that has no parents.
+
+	nonce := l.NextNonce()
+	assert.EqualValues(t, 0, nonce)
+
+	assert.Error(t, l.AddTransaction(AttachSenderToTransaction(keys, NewTransaction(keys, nonce, sys.TagNop, nil))))
+	l.ReleaseNonce(nonce)
+
+	// Send the transaction again, which reuses the nonce that was handed back.
+
+	nonce = l.NextNonce()
+	assert.EqualValues(t, 0, nonce)
+
+	tx := AttachSenderToTransaction(keys, NewTransaction(keys, nonce, sys.TagNop, nil), l.graph.FindEligibleParents()...)
+	assert.NoError(t, l.AddTransaction(tx))
+
+	// Handing back a nonce that later nonces were handed out after rewinds to the account nonce.
+
+	first := l.NextNonce()
+	assert.EqualValues(t, 1, first)
+	assert.EqualValues(t, 2, l.NextNonce())
+
+	l.ReleaseNonce(first)
+	assert.EqualValues(t, 0, l.NextNonce())
+
+	// Finalizing a round keeps nonces that are held by transactions in the graph that are yet to
+	// be applied, but rewinds to the account nonce should any of them have been rejected.
+
+	l.reconcileNonce(nil)
+	assert.EqualValues(t, 1, l.NextNonce())
+
+	l.reconcileNonce([]*Transaction{&tx})
+	assert.EqualValues(t, 0, l.NextNonce())
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2019 Perlin
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package wavelet
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/perlin-network/wavelet/avl"
+	"github.com/perlin-network/wavelet/log"
+	"github.com/perlin-network/wavelet/store"
+	"github.com/perlin-network/wavelet/sys"
+	"github.com/pkg/errors"
+)
+
+// Fields of an account that a light client fetches from its peers.
+var lightAccountFields = [][]byte{
+	keyAccountNonce[:],
+	keyAccountBalance[:],
+	keyAccountStake[:],
+	keyAccountReward[:],
+	keyAccountContractCode[:],
+	keyAccountContractNumPages[:],
+	keyAccountContractGasBalance[:],
+	keyAccountContractOwner[:],
+	keyAccountContractABI[:],
+	keyAccountDelegatedStake[:],
+	keyAccountCommission[:],
+}
+
+// FollowRounds has a light client keep track of the latest round finalized by the network. Peers
+// are repeatedly sampled for the latest round they have finalized, and the header of a round is
+// saved once the syncing Snowball sampler decides on it.
+func (l *Ledger) FollowRounds() {
+	voteWG := new(sync.WaitGroup)
+
+	go CollectVotes(l.syncer, l.syncVotes, voteWG, nil, nil)
+
+	for {
+		conns, err := SelectPeers(l.client.ClosestPeers(), sys.SnowballK)
+		if err != nil {
+			select {
+			case <-time.After(1 * time.Second):
+			}
+
+			continue
+		}
+
+		current := l.rounds.Latest()
+
+		l.queryLatestRound(conns, current.Index+1)
+
+		if !l.syncer.Decided() {
+			time.Sleep(50 * time.Millisecond)
+			continue
+		}
+
+		latest := *l.syncer.Preferred()
+		l.syncer.Reset()
+
+		if latest.Index <= current.Index {
+			continue
+		}
+
+		pruned, err := l.rounds.Save(&latest)
+		if err != nil {
+			logger := log.Sync("follow")
+			logger.Error().Err(err).Msg("Failed to save the header of the latest round to our database.")
+
+			continue
+		}
+
+		if pruned != nil {
+			l.graph.PruneBelowDepth(pruned.End.Depth)
+		}
+
+		l.graph.UpdateRoot(latest.End)
+
+		logger := log.Sync("follow")
+		logger.Info().
+			Uint64("old_round", current.Index).
+			Uint64("new_round", latest.Index).
+			Hex("new_root", latest.End.ID[:]).
+			Hex("new_merkle_root", latest.Merkle[:]).
+			Msg("Followed the network to the latest finalized round.")
+	}
+}
+
+// FetchAccount fetches all fields of an account at the end of a round from the peers of a light
+// client, verifying each field against the Merkle root of the round. It returns a snapshot which
+// only holds the fields of the account.
+func (l *Ledger) FetchAccount(round uint64, id AccountID) (*avl.Tree, error) {
+	header, err := l.rounds.GetByIndex(round)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not find the header of round %d", round)
+	}
+
+	snapshot := avl.New(store.NewInmem())
+
+	for _, field := range lightAccountFields {
+		key := accountKey(id, field)
+
+		value, exists, err := l.fetchProvenValue(header, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if exists {
+			snapshot.Insert(key, value)
+		}
+	}
+
+	// Stake withdrawn by the account is only pending for sys.StakeUnbondingRounds rounds, such that
+	// only the unbonding requests made within the rounds leading up to the round need be fetched.
+
+	start := uint64(0)
+
+	if round >= sys.StakeUnbondingRounds {
+		start = round - sys.StakeUnbondingRounds + 1
+	}
+
+	for index := start; index <= round; index++ {
+		key := accountUnbondingKey(id, index)
+
+		value, exists, err := l.fetchProvenValue(header, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if exists {
+			snapshot.Insert(key, value)
+		}
+	}
+
+	return snapshot, nil
+}
+
+// fetchProvenValue fetches the value of a key within the accounts tree at the end of a round from
+// the first peer that proves the value against the Merkle root of the round. As the absence of a
+// key can not be proven, the key is only considered to not exist should at least sys.SnowballAlpha
+// of the sys.SnowballK peers sampled claim so.
+func (l *Ledger) fetchProvenValue(round *Round, key []byte) ([]byte, bool, error) {
+	conns, err := SelectPeers(l.client.ClosestPeers(), sys.SnowballK)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var absent int
+
+	for _, conn := range conns {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		res, err := NewWaveletClient(conn).Prove(ctx, &ProveRequest{RoundIndex: round.Index, Key: key})
+		cancel()
+
+		if err != nil {
+			continue
+		}
+
+		if len(res.Proof) == 0 {
+			absent++
+			continue
+		}
+
+		proof, err := avl.UnmarshalProof(bytes.NewReader(res.Proof))
+		if err != nil {
+			continue
+		}
+
+		if avl.VerifyProof(round.Merkle, key, res.Value, proof) {
+			return res.Value, true, nil
+		}
+	}
+
+	if absent >= int(math.Ceil(sys.SnowballAlpha*float64(sys.SnowballK))) {
+		return nil, false, nil
+	}
+
+	return nil, false, errors.Errorf("none of our peers could prove the value of key %x at round %d", key, round.Index)
+}
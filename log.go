@@ -49,3 +49,14 @@ func logEventTX(event string, tx *Transaction, other ...interface{}) {
 
 	log.Msg("")
 }
+
+func logContractEvent(event Event) {
+	logger := log.Events("emitted")
+	logger.Log().
+		Uint64("round", event.Round).
+		Hex("contract_id", event.Contract[:]).
+		Hex("tx_id", event.TxID[:]).
+		Str("topic", event.Topic).
+		Hex("data", event.Data).
+		Msg("")
+}
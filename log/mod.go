@@ -39,6 +39,7 @@ var (
 	stake     zerolog.Logger
 	tx        zerolog.Logger
 	metrics   zerolog.Logger
+	events    zerolog.Logger
 )
 
 const (
@@ -57,6 +58,7 @@ const (
 	ModuleStake     = "stake"
 	ModuleTX        = "tx"
 	ModuleMetrics   = "metrics"
+	ModuleEvents    = "events"
 )
 
 func init() {
@@ -73,6 +75,7 @@ func setupChildLoggers() {
 	stake = logger.With().Str(KeyModule, ModuleStake).Logger()
 	tx = logger.With().Str(KeyModule, ModuleTX).Logger()
 	metrics = logger.With().Str(KeyModule, ModuleMetrics).Logger()
+	events = logger.With().Str(KeyModule, ModuleEvents).Logger()
 }
 
 func SetWriter(key string, writer io.Writer) {
@@ -114,3 +117,7 @@ func Sync(event string) zerolog.Logger {
 func Metrics() zerolog.Logger {
 	return metrics
 }
+
+func Events(event string) zerolog.Logger {
+	return events.With().Str(KeyEvent, event).Logger()
+}
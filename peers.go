@@ -17,57 +17,40 @@
 // IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
 // CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
 
-package store
+package wavelet
 
 import (
-	"github.com/stretchr/testify/assert"
-	"math/rand"
-	"testing"
-)
-
-func BenchmarkInmem(b *testing.B) {
-	b.StopTimer()
-
-	db := NewInmem()
-	defer func() {
-		_ = db.Close()
-	}()
+	"sync"
 
-	b.StartTimer()
-	defer b.StopTimer()
-
-	for i := 0; i < b.N; i++ {
-		var randomKey [128]byte
-		var randomValue [600]byte
+	"github.com/perlin-network/noise/skademlia"
+)
 
-		_, err := rand.Read(randomKey[:])
-		assert.NoError(b, err)
-		_, err = rand.Read(randomValue[:])
-		assert.NoError(b, err)
+// PeerBook keeps track of the IDs of validators we have come across, keyed by their public keys,
+// such that validators that have placed stake within the ledger may be dialed by their account IDs.
+type PeerBook struct {
+	sync.RWMutex
+	ids map[AccountID]*skademlia.ID
+}
 
-		err = db.Put(randomKey[:], randomValue[:])
-		assert.NoError(b, err)
+func NewPeerBook() *PeerBook {
+	return &PeerBook{ids: make(map[AccountID]*skademlia.ID)}
+}
 
-		value, err := db.Get(randomKey[:])
-		assert.NoError(b, err)
+// Add records the IDs of peers, replacing the addresses of any peers that were already recorded.
+func (b *PeerBook) Add(ids ...*skademlia.ID) {
+	b.Lock()
+	defer b.Unlock()
 
-		assert.EqualValues(b, randomValue[:], value)
+	for _, id := range ids {
+		b.ids[id.PublicKey()] = id
 	}
 }
 
-func TestExistence(t *testing.T) {
-	db := NewInmem()
-	defer func() {
-		_ = db.Close()
-	}()
-
-	_, err := db.Get([]byte("not_exist"))
-	assert.Error(t, err)
-
-	err = db.Put([]byte("exist"), []byte{})
-	assert.NoError(t, err)
+// Lookup returns the ID of the peer whose public key is id, should it have been recorded.
+func (b *PeerBook) Lookup(id AccountID) (*skademlia.ID, bool) {
+	b.RLock()
+	defer b.RUnlock()
 
-	val, err := db.Get([]byte("exist"))
-	assert.NoError(t, err)
-	assert.Equal(t, []byte{}, val)
+	peer, exists := b.ids[id]
+	return peer, exists
 }
@@ -23,10 +23,14 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"github.com/perlin-network/noise"
+	"github.com/perlin-network/noise/edwards25519"
+	"github.com/perlin-network/noise/skademlia"
 	"github.com/perlin-network/wavelet/log"
 	"github.com/perlin-network/wavelet/sys"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/blake2b"
+	"google.golang.org/grpc/peer"
 )
 
 type Protocol struct {
@@ -58,25 +62,66 @@ func (p *Protocol) Gossip(stream Wavelet_GossipServer) error {
 }
 
 func (p *Protocol) Query(ctx context.Context, req *QueryRequest) (*QueryResponse, error) {
+	p.recordValidator(ctx)
+
 	res := &QueryResponse{}
 
 	round, err := p.ledger.rounds.GetByIndex(req.RoundIndex)
 
 	if err == nil {
-		res.Round = round.Marshal()
+		res.Round, res.Signature = round.Marshal(), p.signRound(round)
 		return res, nil
 	}
 
 	preferred := p.ledger.finalizer.Preferred()
 
 	if preferred != nil {
-		res.Round = preferred.Marshal()
+		res.Round, res.Signature = preferred.Marshal(), p.signPreference(preferred)
 		return res, nil
 	}
 
 	return res, nil
 }
 
+// recordValidator records the ID of the peer that sent a request into our peer book should the
+// peer have stake, such that we may sample it in turn even should it not be amongst our closest peers.
+func (p *Protocol) recordValidator(ctx context.Context) {
+	pr, ok := peer.FromContext(ctx)
+	if !ok {
+		return
+	}
+
+	info := noise.InfoFromPeer(pr)
+	if info == nil {
+		return
+	}
+
+	id, ok := info.Get(skademlia.KeyID).(*skademlia.ID)
+	if !ok {
+		return
+	}
+
+	if ReadAccountTotalStake(p.ledger.accounts.Snapshot(), id.PublicKey()) == 0 {
+		return
+	}
+
+	p.ledger.peers.Add(id)
+}
+
+// signRound signs the index and ID of a finalized round with the keys of our node, vouching for the
+// round being finalized at its index such that our vote may be proven to a third party.
+func (p *Protocol) signRound(round *Round) []byte {
+	signature := edwards25519.Sign(p.ledger.client.Keys().PrivateKey(), QueryResponseSignaturePayload(round.Index, round.ID))
+	return signature[:]
+}
+
+// signPreference signs the index and ID of a round we prefer but have yet to finalize. As our
+// preference may change, the signature may not be submitted as evidence against us.
+func (p *Protocol) signPreference(round *Round) []byte {
+	signature := edwards25519.Sign(p.ledger.client.Keys().PrivateKey(), PreferenceSignaturePayload(round.Index, round.ID))
+	return signature[:]
+}
+
 func (p *Protocol) Sync(stream Wavelet_SyncServer) error {
 	req, err := stream.Recv()
 	if err != nil {
@@ -139,7 +184,9 @@ func (p *Protocol) Sync(stream Wavelet_SyncServer) error {
 }
 
 func (p *Protocol) CheckOutOfSync(context.Context, *OutOfSyncRequest) (*OutOfSyncResponse, error) {
-	return &OutOfSyncResponse{Round: p.ledger.rounds.Latest().Marshal()}, nil
+	latest := p.ledger.rounds.Latest()
+
+	return &OutOfSyncResponse{Round: latest.Marshal(), Signature: p.signRound(latest)}, nil
 }
 
 func (p *Protocol) DownloadTx(ctx context.Context, req *DownloadTxRequest) (*DownloadTxResponse, error) {
@@ -156,3 +203,27 @@ func (p *Protocol) DownloadTx(ctx context.Context, req *DownloadTxRequest) (*Dow
 
 	return res, nil
 }
+
+func (p *Protocol) Prove(ctx context.Context, req *ProveRequest) (*ProveResponse, error) {
+	snapshot, err := p.ledger.SnapshotAt(req.RoundIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ProveResponse{}
+
+	value, exists := snapshot.Lookup(req.Key)
+	if !exists {
+		return res, nil
+	}
+
+	proof, err := snapshot.Prove(req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Value = value
+	res.Proof = proof.Marshal()
+
+	return res, nil
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2019 Perlin
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package wavelet
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/perlin-network/noise/edwards25519"
+	"github.com/perlin-network/noise/skademlia"
+	"github.com/perlin-network/wavelet/avl"
+	"github.com/perlin-network/wavelet/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProtocolProve(t *testing.T) {
+	kv := store.NewInmem()
+
+	// Commit the account into the state the ledger starts off from.
+
+	s := avl.New(kv)
+	WriteAccountBalance(s, AccountID{1}, 100)
+	assert.NoError(t, s.Commit())
+
+	keys, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	ledger := NewLedger(kv, skademlia.NewClient(":0", keys), nil)
+	latest := ledger.Rounds().Latest()
+
+	key := AccountBalanceKey(AccountID{1})
+
+	res, err := ledger.Protocol().Prove(context.Background(), &ProveRequest{RoundIndex: latest.Index, Key: key})
+	assert.NoError(t, err)
+
+	proof, err := avl.UnmarshalProof(bytes.NewReader(res.Proof))
+	assert.NoError(t, err)
+	assert.True(t, avl.VerifyProof(latest.Merkle, key, res.Value, proof))
+
+	// Keys that do not exist have no proof.
+
+	res, err = ledger.Protocol().Prove(context.Background(), &ProveRequest{RoundIndex: latest.Index, Key: AccountBalanceKey(AccountID{2})})
+	assert.NoError(t, err)
+	assert.Empty(t, res.Proof)
+
+	// Rounds that have yet to be finalized may not be proven against.
+
+	_, err = ledger.Protocol().Prove(context.Background(), &ProveRequest{RoundIndex: latest.Index + 1, Key: key})
+	assert.Error(t, err)
+
+	// Recent rounds may still be proven against after a newer round is finalized.
+
+	snapshot := ledger.Snapshot()
+	WriteAccountBalance(snapshot, AccountID{1}, 200)
+
+	next := NewRound(latest.Index+1, snapshot.Checksum(), 0, Transaction{}, Transaction{})
+
+	_, err = commitRound(ledger.accounts, ledger.rounds, &next, snapshot, false)
+	assert.NoError(t, err)
+
+	res, err = ledger.Protocol().Prove(context.Background(), &ProveRequest{RoundIndex: latest.Index, Key: key})
+	assert.NoError(t, err)
+
+	proof, err = avl.UnmarshalProof(bytes.NewReader(res.Proof))
+	assert.NoError(t, err)
+	assert.True(t, avl.VerifyProof(latest.Merkle, key, res.Value, proof))
+
+	// Light clients do not keep the state of accounts to prove against.
+
+	light := NewLedger(store.NewInmem(), skademlia.NewClient(":0", keys), nil, WithLight())
+	assert.True(t, light.Light())
+
+	_, err = light.Protocol().Prove(context.Background(), &ProveRequest{RoundIndex: light.Rounds().Latest().Index, Key: key})
+	assert.Error(t, err)
+}
+
+func TestProtocolQuerySignature(t *testing.T) {
+	keys, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	ledger := NewLedger(store.NewInmem(), skademlia.NewClient(":0", keys), nil)
+	latest := ledger.Rounds().Latest()
+
+	res, err := ledger.Protocol().Query(context.Background(), &QueryRequest{RoundIndex: latest.Index})
+	assert.NoError(t, err)
+
+	var signature Signature
+	copy(signature[:], res.Signature)
+
+	assert.True(t, edwards25519.Verify(keys.PublicKey(), QueryResponseSignaturePayload(latest.Index, latest.ID), signature))
+	assert.False(t, edwards25519.Verify(keys.PublicKey(), QueryResponseSignaturePayload(latest.Index+1, latest.ID), signature))
+
+	sync, err := ledger.Protocol().CheckOutOfSync(context.Background(), &OutOfSyncRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, res.Signature, sync.Signature)
+
+	// A round that is only preferred must be signed such that the signature may not be submitted
+	// as evidence of the node having finalized it.
+
+	preferred := NewRound(latest.Index+1, MerkleNodeID{1}, 0, Transaction{}, Transaction{})
+	ledger.Finalizer().Prefer(&preferred)
+
+	res, err = ledger.Protocol().Query(context.Background(), &QueryRequest{RoundIndex: preferred.Index})
+	assert.NoError(t, err)
+
+	copy(signature[:], res.Signature)
+
+	assert.True(t, edwards25519.Verify(keys.PublicKey(), PreferenceSignaturePayload(preferred.Index, preferred.ID), signature))
+	assert.False(t, edwards25519.Verify(keys.PublicKey(), QueryResponseSignaturePayload(preferred.Index, preferred.ID), signature))
+}
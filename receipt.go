@@ -0,0 +1,164 @@
+// Copyright (c) 2019 Perlin
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package wavelet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/pkg/errors"
+	"io"
+)
+
+// ReceiptStatus denotes whether a finalized transaction was applied to the ledger, or rejected.
+type ReceiptStatus byte
+
+const (
+	ReceiptStatusApplied ReceiptStatus = iota
+	ReceiptStatusRejected
+)
+
+func (s ReceiptStatus) String() string {
+	switch s {
+	case ReceiptStatusApplied:
+		return "applied"
+	case ReceiptStatusRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
+// Receipt denotes the outcome of a transaction within the round it was finalized in: whether or
+// not it was applied, why it was rejected, the gas and fees it expended, and the events emitted by
+// smart contracts it invoked. Rejected transactions have no gas used nor events recorded, as all
+// of their changes are reverted.
+type Receipt struct {
+	TxID  TransactionID
+	Round uint64
+
+	Status ReceiptStatus
+	Error  string
+
+	Fee uint64
+	Gas uint64
+
+	Events []Event
+}
+
+func (r Receipt) Marshal() []byte {
+	var w bytes.Buffer
+
+	w.Write(r.TxID[:])
+
+	var buf [8]byte
+
+	binary.BigEndian.PutUint64(buf[:], r.Round)
+	w.Write(buf[:8])
+
+	w.WriteByte(byte(r.Status))
+
+	binary.BigEndian.PutUint32(buf[:4], uint32(len(r.Error)))
+	w.Write(buf[:4])
+	w.WriteString(r.Error)
+
+	binary.BigEndian.PutUint64(buf[:], r.Fee)
+	w.Write(buf[:8])
+
+	binary.BigEndian.PutUint64(buf[:], r.Gas)
+	w.Write(buf[:8])
+
+	binary.BigEndian.PutUint32(buf[:4], uint32(len(r.Events)))
+	w.Write(buf[:4])
+
+	for _, event := range r.Events {
+		w.Write(event.Marshal())
+	}
+
+	return w.Bytes()
+}
+
+func UnmarshalReceipt(r io.Reader) (receipt Receipt, err error) {
+	if _, err = io.ReadFull(r, receipt.TxID[:]); err != nil {
+		err = errors.Wrap(err, "failed to decode receipt transaction ID")
+		return
+	}
+
+	var buf [8]byte
+
+	if _, err = io.ReadFull(r, buf[:8]); err != nil {
+		err = errors.Wrap(err, "failed to decode receipt round index")
+		return
+	}
+
+	receipt.Round = binary.BigEndian.Uint64(buf[:8])
+
+	if _, err = io.ReadFull(r, buf[:1]); err != nil {
+		err = errors.Wrap(err, "failed to decode receipt status")
+		return
+	}
+
+	receipt.Status = ReceiptStatus(buf[0])
+
+	if _, err = io.ReadFull(r, buf[:4]); err != nil {
+		err = errors.Wrap(err, "failed to decode receipt error length")
+		return
+	}
+
+	msg := make([]byte, binary.BigEndian.Uint32(buf[:4]))
+
+	if _, err = io.ReadFull(r, msg); err != nil {
+		err = errors.Wrap(err, "failed to decode receipt error")
+		return
+	}
+
+	receipt.Error = string(msg)
+
+	if _, err = io.ReadFull(r, buf[:8]); err != nil {
+		err = errors.Wrap(err, "failed to decode receipt fee")
+		return
+	}
+
+	receipt.Fee = binary.BigEndian.Uint64(buf[:8])
+
+	if _, err = io.ReadFull(r, buf[:8]); err != nil {
+		err = errors.Wrap(err, "failed to decode receipt gas")
+		return
+	}
+
+	receipt.Gas = binary.BigEndian.Uint64(buf[:8])
+
+	if _, err = io.ReadFull(r, buf[:4]); err != nil {
+		err = errors.Wrap(err, "failed to decode number of receipt events")
+		return
+	}
+
+	if n := binary.BigEndian.Uint32(buf[:4]); n > 0 {
+		receipt.Events = make([]Event, n)
+
+		for i := range receipt.Events {
+			if receipt.Events[i], err = UnmarshalEvent(r); err != nil {
+				err = errors.Wrap(err, "failed to decode receipt event")
+				return
+			}
+		}
+	}
+
+	return
+}
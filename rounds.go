@@ -22,6 +22,7 @@ package wavelet
 import (
 	"fmt"
 	"github.com/perlin-network/wavelet/store"
+	"github.com/pkg/errors"
 	"sync"
 )
 
@@ -76,7 +77,23 @@ func (r *Rounds) Count() uint64 {
 }
 
 func (r *Rounds) Save(round *Round) (*Round, error) {
+	batch := r.store.NewWriteBatch()
+
+	oldRound := r.SaveWithBatch(round, batch)
+
+	if err := r.store.CommitWriteBatch(batch); err != nil {
+		return oldRound, errors.Wrap(err, "error storing round")
+	}
+
+	return oldRound, nil
+}
+
+// SaveWithBatch saves round as the latest round, staging it into batch rather than writing it
+// to the database, such that the caller may commit it atomically alongside other writes. It
+// returns the round that got pruned to make space for round, if any.
+func (r *Rounds) SaveWithBatch(round *Round, batch store.WriteBatch) *Round {
 	r.Lock()
+	defer r.Unlock()
 
 	if len(r.buffer) > 0 {
 		r.latest = (r.latest + 1) % uint32(r.limit)
@@ -95,11 +112,64 @@ func (r *Rounds) Save(round *Round) (*Round, error) {
 		r.buffer[r.latest] = round
 	}
 
-	err := StoreRound(r.store, *round, r.latest, r.oldest, uint8(len(r.buffer)))
+	storeRound(batch, *round, r.latest, r.oldest, uint8(len(r.buffer)))
+
+	return oldRound
+}
+
+// Rewind drops all rounds after the round with index ix, such that it becomes the latest round.
+// The remaining rounds are re-written to the database from oldest to latest.
+func (r *Rounds) Rewind(ix uint64) error {
+	r.Lock()
+	defer r.Unlock()
+
+	var rounds []*Round
+
+	for _, round := range r.ordered() {
+		if round.Index > ix {
+			break
+		}
+
+		rounds = append(rounds, round)
+	}
+
+	if len(rounds) == 0 || rounds[len(rounds)-1].Index != ix {
+		return errors.Errorf("no round found for index - %d", ix)
+	}
+
+	batch := r.store.NewWriteBatch()
+
+	for i, round := range rounds {
+		storeRound(batch, *round, uint32(i), 0, uint8(i+1))
+	}
+
+	if err := r.store.CommitWriteBatch(batch); err != nil {
+		return errors.Wrap(err, "error storing rewound rounds")
+	}
+
+	r.buffer = append(make([]*Round, 0, r.limit), rounds...)
+	r.latest = uint32(len(rounds) - 1)
+	r.oldest = 0
 
-	r.Unlock()
+	return nil
+}
+
+// Clone returns all rounds that are kept, ordered from the oldest round to the latest round.
+func (r *Rounds) Clone() []*Round {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.ordered()
+}
+
+func (r *Rounds) ordered() []*Round {
+	rounds := make([]*Round, 0, len(r.buffer))
+
+	for i := 0; i < len(r.buffer); i++ {
+		rounds = append(rounds, r.buffer[(int(r.oldest)+i)%len(r.buffer)])
+	}
 
-	return oldRound, err
+	return rounds
 }
 
 func (r *Rounds) GetByIndex(ix uint64) (*Round, error) {
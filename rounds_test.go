@@ -106,3 +106,63 @@ func TestRoundsCircular(t *testing.T) {
 	assert.Equal(t, uint32(4), newRM.latest)
 	assert.Equal(t, uint32(5), newRM.oldest)
 }
+
+func TestRoundsRewind(t *testing.T) {
+	t.Parallel()
+
+	storage := store.NewInmem()
+
+	rm, _ := NewRounds(storage, 10)
+
+	for i := 0; i < 15; i++ {
+		r := &Round{
+			Index: uint64(i + 1),
+			Start: Transaction{},
+			End:   Transaction{},
+		}
+		_, err := rm.Save(r)
+		assert.NoError(t, err)
+	}
+
+	// Rounds that are no longer kept may not be rewound to.
+	assert.Error(t, rm.Rewind(3))
+	assert.Error(t, rm.Rewind(16))
+
+	assert.NoError(t, rm.Rewind(12))
+
+	assert.Equal(t, uint64(12), rm.Latest().Index)
+	assert.Equal(t, uint64(6), rm.Oldest().Index)
+
+	_, err := rm.GetByIndex(13)
+	assert.Error(t, err)
+
+	newRM, err := NewRounds(storage, 10)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	indices := func(rounds []*Round) (ix []uint64) {
+		for _, r := range rounds {
+			ix = append(ix, r.Index)
+		}
+		return ix
+	}
+
+	assert.Equal(t, []uint64{6, 7, 8, 9, 10, 11, 12}, indices(rm.Clone()))
+	assert.Equal(t, indices(rm.Clone()), indices(newRM.Clone()))
+
+	// Saving rounds after rewinding should have the buffer wrap around like before.
+	for i := 12; i < 20; i++ {
+		r := &Round{
+			Index: uint64(i + 1),
+			Start: Transaction{},
+			End:   Transaction{},
+		}
+		_, err := newRM.Save(r)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, uint64(20), newRM.Latest().Index)
+	assert.Equal(t, uint64(11), newRM.Oldest().Index)
+	assert.Len(t, newRM.Clone(), 10)
+}
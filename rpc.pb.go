@@ -87,7 +87,8 @@ func (m *QueryRequest) GetRoundIndex() uint64 {
 }
 
 type QueryResponse struct {
-	Round []byte `protobuf:"bytes,1,opt,name=round,proto3" json:"round,omitempty"`
+	Round     []byte `protobuf:"bytes,1,opt,name=round,proto3" json:"round,omitempty"`
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
 func (m *QueryResponse) Reset()         { *m = QueryResponse{} }
@@ -130,6 +131,13 @@ func (m *QueryResponse) GetRound() []byte {
 	return nil
 }
 
+func (m *QueryResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 type OutOfSyncRequest struct {
 }
 
@@ -167,7 +175,8 @@ func (m *OutOfSyncRequest) XXX_DiscardUnknown() {
 var xxx_messageInfo_OutOfSyncRequest proto.InternalMessageInfo
 
 type OutOfSyncResponse struct {
-	Round []byte `protobuf:"bytes,1,opt,name=round,proto3" json:"round,omitempty"`
+	Round     []byte `protobuf:"bytes,1,opt,name=round,proto3" json:"round,omitempty"`
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
 func (m *OutOfSyncResponse) Reset()         { *m = OutOfSyncResponse{} }
@@ -210,6 +219,13 @@ func (m *OutOfSyncResponse) GetRound() []byte {
 	return nil
 }
 
+func (m *OutOfSyncResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 type SyncInfo struct {
 	LatestRound []byte   `protobuf:"bytes,1,opt,name=latest_round,json=latestRound,proto3" json:"latest_round,omitempty"`
 	Checksums   [][]byte `protobuf:"bytes,2,rep,name=checksums,proto3" json:"checksums,omitempty"`
@@ -639,6 +655,110 @@ func (m *DownloadTxResponse) GetTransactions() [][]byte {
 	return nil
 }
 
+type ProveRequest struct {
+	RoundIndex uint64 `protobuf:"varint,1,opt,name=round_index,json=roundIndex,proto3" json:"round_index,omitempty"`
+	Key        []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (m *ProveRequest) Reset()         { *m = ProveRequest{} }
+func (m *ProveRequest) String() string { return proto.CompactTextString(m) }
+func (*ProveRequest) ProtoMessage()    {}
+func (*ProveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{9}
+}
+func (m *ProveRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProveRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProveRequest.Merge(m, src)
+}
+func (m *ProveRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProveRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProveRequest proto.InternalMessageInfo
+
+func (m *ProveRequest) GetRoundIndex() uint64 {
+	if m != nil {
+		return m.RoundIndex
+	}
+	return 0
+}
+
+func (m *ProveRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+type ProveResponse struct {
+	Value []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Proof []byte `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (m *ProveResponse) Reset()         { *m = ProveResponse{} }
+func (m *ProveResponse) String() string { return proto.CompactTextString(m) }
+func (*ProveResponse) ProtoMessage()    {}
+func (*ProveResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{10}
+}
+func (m *ProveResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProveResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProveResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProveResponse.Merge(m, src)
+}
+func (m *ProveResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProveResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProveResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProveResponse proto.InternalMessageInfo
+
+func (m *ProveResponse) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *ProveResponse) GetProof() []byte {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
 type Transactions struct {
 	Transactions [][]byte `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
 }
@@ -647,7 +767,7 @@ func (m *Transactions) Reset()         { *m = Transactions{} }
 func (m *Transactions) String() string { return proto.CompactTextString(m) }
 func (*Transactions) ProtoMessage()    {}
 func (*Transactions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{9}
+	return fileDescriptor_77a6da22d6a3feb1, []int{11}
 }
 func (m *Transactions) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -690,7 +810,7 @@ func (m *Empty) Reset()         { *m = Empty{} }
 func (m *Empty) String() string { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()    {}
 func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{10}
+	return fileDescriptor_77a6da22d6a3feb1, []int{12}
 }
 func (m *Empty) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -729,6 +849,8 @@ func init() {
 	proto.RegisterType((*SyncResponse)(nil), "wavelet.SyncResponse")
 	proto.RegisterType((*DownloadTxRequest)(nil), "wavelet.DownloadTxRequest")
 	proto.RegisterType((*DownloadTxResponse)(nil), "wavelet.DownloadTxResponse")
+	proto.RegisterType((*ProveRequest)(nil), "wavelet.ProveRequest")
+	proto.RegisterType((*ProveResponse)(nil), "wavelet.ProveResponse")
 	proto.RegisterType((*Transactions)(nil), "wavelet.Transactions")
 	proto.RegisterType((*Empty)(nil), "wavelet.Empty")
 }
@@ -736,37 +858,41 @@ func init() {
 func init() { proto.RegisterFile("rpc.proto", fileDescriptor_77a6da22d6a3feb1) }
 
 var fileDescriptor_77a6da22d6a3feb1 = []byte{
-	// 473 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0xf5, 0xa6, 0xf9, 0xea, 0xc4, 0x54, 0xcd, 0xa8, 0xad, 0x8c, 0x8b, 0x4c, 0x59, 0xa9, 0x52,
-	0x10, 0x52, 0x40, 0xe9, 0xa5, 0x5c, 0x4b, 0x51, 0x13, 0x71, 0x28, 0x98, 0x4a, 0x1c, 0x38, 0x54,
-	0xc6, 0xde, 0x2a, 0x51, 0xd3, 0x5d, 0xe3, 0x5d, 0xd3, 0xe6, 0x5f, 0xf0, 0xaf, 0xe0, 0xd8, 0x23,
-	0x47, 0x94, 0xfc, 0x11, 0xe4, 0xf5, 0x47, 0x36, 0xa1, 0x42, 0xdc, 0xbc, 0x6f, 0xde, 0x7b, 0x3b,
-	0x6f, 0x67, 0x0c, 0x9b, 0x49, 0x1c, 0xf6, 0xe3, 0x44, 0x28, 0x81, 0xad, 0xdb, 0xe0, 0x1b, 0x9b,
-	0x32, 0x45, 0x5f, 0x82, 0xfd, 0x21, 0x65, 0xc9, 0xcc, 0x67, 0x5f, 0x53, 0x26, 0x15, 0x3e, 0x85,
-	0x4e, 0x22, 0x52, 0x1e, 0x5d, 0x4e, 0x78, 0xc4, 0xee, 0x1c, 0x72, 0x40, 0x7a, 0x75, 0x1f, 0x34,
-	0x34, 0xca, 0x10, 0x7a, 0x08, 0x8f, 0x0a, 0x81, 0x8c, 0x05, 0x97, 0x0c, 0x77, 0xa0, 0xa1, 0xcb,
-	0x9a, 0x6b, 0xfb, 0xf9, 0x81, 0x22, 0x6c, 0x9f, 0xa7, 0xea, 0xfc, 0xea, 0xe3, 0x8c, 0x87, 0x85,
-	0x37, 0x7d, 0x0e, 0x5d, 0x03, 0xfb, 0xa7, 0xfc, 0x1d, 0xb4, 0x33, 0xd6, 0x88, 0x5f, 0x09, 0x7c,
-	0x06, 0xf6, 0x34, 0x50, 0x4c, 0xaa, 0x4b, 0x93, 0xd8, 0xc9, 0x31, 0x3f, 0x83, 0xf0, 0x09, 0x6c,
-	0x86, 0x63, 0x16, 0x5e, 0xcb, 0xf4, 0x46, 0x3a, 0xb5, 0x83, 0x8d, 0x9e, 0xed, 0x2f, 0x01, 0xfa,
-	0x1e, 0x3a, 0x46, 0x1b, 0xb8, 0x0f, 0xed, 0x22, 0x62, 0xee, 0x55, 0x1f, 0x5a, 0x7e, 0x2b, 0x4f,
-	0x98, 0x39, 0xb5, 0x4b, 0xa1, 0x53, 0xcb, 0x2e, 0x1a, 0x5a, 0x7e, 0x85, 0x9c, 0x34, 0xa1, 0x7e,
-	0x1a, 0xa8, 0x80, 0x7e, 0x06, 0x7b, 0x25, 0xc4, 0x0b, 0x68, 0x8e, 0x59, 0x10, 0xb1, 0x44, 0x1b,
-	0x76, 0x06, 0xdd, 0x7e, 0xf1, 0xbe, 0xfd, 0x32, 0xc5, 0xd0, 0xf2, 0x0b, 0x0a, 0xee, 0x41, 0x23,
-	0x1c, 0xa7, 0xfc, 0xba, 0xf2, 0xcf, 0x8f, 0x95, 0xf9, 0x21, 0x74, 0x4f, 0xc5, 0x2d, 0x9f, 0x8a,
-	0x20, 0xba, 0xb8, 0x2b, 0x9b, 0xde, 0x86, 0x8d, 0x49, 0x24, 0x1d, 0xa2, 0xb3, 0x65, 0x9f, 0xf4,
-	0x18, 0xd0, 0xa4, 0x15, 0x9d, 0x50, 0xb0, 0x55, 0x12, 0x70, 0x19, 0x84, 0x6a, 0x22, 0x78, 0x29,
-	0x58, 0xc1, 0xe8, 0x00, 0xec, 0x0b, 0xe3, 0xfc, 0x5f, 0x9a, 0x16, 0x34, 0xde, 0xde, 0xc4, 0x6a,
-	0x36, 0xf8, 0x51, 0x83, 0xd6, 0xa7, 0x3c, 0x1c, 0x1e, 0x41, 0xf3, 0x4c, 0x48, 0x39, 0x89, 0x71,
-	0xb7, 0x0a, 0x6c, 0x3a, 0xbb, 0x5b, 0x15, 0xac, 0xc5, 0xd4, 0xea, 0x11, 0x3c, 0x86, 0x86, 0x5e,
-	0x20, 0x43, 0x63, 0x6e, 0xa0, 0xbb, 0xb7, 0x0e, 0xe7, 0xc9, 0xa8, 0x85, 0x23, 0xd8, 0x7a, 0x93,
-	0x4d, 0xa2, 0x5a, 0x22, 0x7c, 0x5c, 0x71, 0xd7, 0x97, 0xcd, 0x75, 0x1f, 0x2a, 0x55, 0x56, 0xaf,
-	0xa1, 0xae, 0x0d, 0x76, 0x56, 0x06, 0x55, 0x6a, 0x77, 0xd7, 0xd0, 0x52, 0xd6, 0x23, 0xaf, 0x08,
-	0x9e, 0x01, 0x2c, 0xdf, 0x1d, 0x97, 0xd7, 0xfc, 0x35, 0x33, 0x77, 0xff, 0xc1, 0x5a, 0x69, 0x76,
-	0xe2, 0xfc, 0x9c, 0x7b, 0xe4, 0x7e, 0xee, 0x91, 0xdf, 0x73, 0x8f, 0x7c, 0x5f, 0x78, 0xd6, 0xfd,
-	0xc2, 0xb3, 0x7e, 0x2d, 0x3c, 0xeb, 0x4b, 0x53, 0xff, 0xa4, 0x47, 0x7f, 0x02, 0x00, 0x00, 0xff,
-	0xff, 0xf7, 0x4a, 0x1b, 0xf3, 0xb1, 0x03, 0x00, 0x00,
+	// 540 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x4d, 0x6f, 0xd3, 0x30,
+	0x18, 0x4e, 0xb6, 0x7e, 0xed, 0x6d, 0x36, 0xad, 0xd6, 0x56, 0x95, 0x0c, 0x85, 0x61, 0x09, 0xa9,
+	0x12, 0x52, 0x41, 0xdd, 0xa5, 0x88, 0x13, 0xdb, 0x50, 0x5b, 0x71, 0xd8, 0x08, 0x93, 0x38, 0x70,
+	0x98, 0x4c, 0xea, 0xd2, 0xaa, 0x9d, 0x1d, 0x62, 0xa7, 0x5b, 0x8f, 0xfc, 0x03, 0x7e, 0x16, 0xc7,
+	0x1d, 0x39, 0xa2, 0xf6, 0x8f, 0xa0, 0x38, 0x89, 0xeb, 0x96, 0x1d, 0x26, 0x71, 0x8b, 0x9f, 0xf7,
+	0x7d, 0x1e, 0xbf, 0x1f, 0x8f, 0x03, 0x3b, 0x51, 0x18, 0xb4, 0xc2, 0x88, 0x4b, 0x8e, 0xca, 0xb7,
+	0x64, 0x46, 0xa7, 0x54, 0xe2, 0x57, 0xe0, 0x7c, 0x8c, 0x69, 0x34, 0xf7, 0xe9, 0xf7, 0x98, 0x0a,
+	0x89, 0x9e, 0x41, 0x35, 0xe2, 0x31, 0x1b, 0x5c, 0x8f, 0xd9, 0x80, 0xde, 0x35, 0xec, 0x63, 0xbb,
+	0x59, 0xf0, 0x41, 0x41, 0xfd, 0x04, 0xc1, 0x67, 0xb0, 0x9b, 0x11, 0x44, 0xc8, 0x99, 0xa0, 0xe8,
+	0x00, 0x8a, 0x2a, 0xac, 0x72, 0x1d, 0x3f, 0x3d, 0xa0, 0xa7, 0xb0, 0x23, 0xc6, 0xdf, 0x18, 0x91,
+	0x71, 0x44, 0x1b, 0x5b, 0x2a, 0xb2, 0x02, 0x30, 0x82, 0xfd, 0x8b, 0x58, 0x5e, 0x0c, 0x3f, 0xcd,
+	0x59, 0x90, 0xdd, 0x8c, 0xbb, 0x50, 0x33, 0xb0, 0xff, 0x10, 0xff, 0x00, 0x95, 0x44, 0xa3, 0xcf,
+	0x86, 0x1c, 0x3d, 0x07, 0x67, 0x4a, 0x24, 0x15, 0xf2, 0xda, 0x94, 0xa9, 0xa6, 0x98, 0x9f, 0x8b,
+	0x05, 0x23, 0x1a, 0x4c, 0x44, 0x7c, 0x23, 0x1a, 0x5b, 0xc7, 0xdb, 0x89, 0x98, 0x06, 0xf0, 0x25,
+	0x54, 0x8d, 0x22, 0xd1, 0x11, 0x54, 0xb2, 0xf1, 0xa4, 0x5a, 0x85, 0x9e, 0xe5, 0x97, 0xd3, 0xe9,
+	0x24, 0x4a, 0x95, 0x9c, 0x98, 0x56, 0xd5, 0xb3, 0x7c, 0x8d, 0x9c, 0x96, 0xa0, 0x70, 0x4e, 0x24,
+	0xc1, 0x5f, 0xc0, 0x59, 0x6b, 0xf1, 0x25, 0x94, 0x46, 0x94, 0x0c, 0x68, 0xa4, 0x04, 0xab, 0xed,
+	0x5a, 0x2b, 0xdb, 0x4d, 0x2b, 0xef, 0xa2, 0x67, 0xf9, 0x59, 0x0a, 0xaa, 0x43, 0x31, 0x18, 0xc5,
+	0x6c, 0xa2, 0xf5, 0xd3, 0xa3, 0x16, 0x7f, 0x01, 0xb5, 0x73, 0x7e, 0xcb, 0xa6, 0x9c, 0x0c, 0xae,
+	0xee, 0xf2, 0xa2, 0xf7, 0x61, 0x7b, 0x3c, 0x10, 0x0d, 0x5b, 0xf5, 0x96, 0x7c, 0xe2, 0x0e, 0x20,
+	0x33, 0x2d, 0xab, 0x04, 0x83, 0x23, 0x23, 0xc2, 0x04, 0x09, 0xe4, 0x98, 0xb3, 0x9c, 0xb0, 0x86,
+	0xe1, 0x77, 0xe0, 0x5c, 0x46, 0x7c, 0x46, 0x1f, 0xeb, 0x97, 0xe4, 0xf2, 0x09, 0x9d, 0x67, 0x5b,
+	0x4a, 0x3e, 0xf1, 0x5b, 0xd8, 0xcd, 0x24, 0x56, 0x4b, 0x9e, 0x91, 0x69, 0x4c, 0xf3, 0x25, 0xab,
+	0x43, 0x82, 0x86, 0x11, 0xe7, 0xc3, 0x8c, 0x9a, 0x1e, 0x70, 0x1b, 0x9c, 0x2b, 0xa3, 0x9e, 0x47,
+	0xd5, 0x5c, 0x86, 0xe2, 0xfb, 0x9b, 0x50, 0xce, 0xdb, 0x3f, 0xb6, 0xa1, 0xfc, 0x39, 0x1d, 0x2e,
+	0x3a, 0x81, 0x52, 0x97, 0x0b, 0x31, 0x0e, 0xd1, 0xa1, 0x1e, 0xb8, 0xa9, 0xec, 0xee, 0x69, 0x58,
+	0x91, 0xb1, 0xd5, 0xb4, 0x51, 0x07, 0x8a, 0xca, 0xfc, 0x06, 0xc7, 0x7c, 0x3d, 0x6e, 0x7d, 0x13,
+	0x4e, 0x3b, 0xc4, 0x16, 0xea, 0xc3, 0xde, 0x59, 0xe2, 0x04, 0x6d, 0x71, 0xf4, 0x44, 0xe7, 0x6e,
+	0x3e, 0x05, 0xd7, 0x7d, 0x28, 0xa4, 0xa5, 0xde, 0x40, 0x41, 0x09, 0x1c, 0xac, 0x19, 0x25, 0xe7,
+	0x1e, 0x6e, 0xa0, 0x39, 0xad, 0x69, 0xbf, 0xb6, 0x51, 0x17, 0x60, 0xb5, 0x77, 0xb4, 0xba, 0xe6,
+	0x1f, 0xcf, 0xb8, 0x47, 0x0f, 0xc6, 0x74, 0x0d, 0x1d, 0x28, 0xaa, 0x1d, 0x1a, 0x83, 0x30, 0x6d,
+	0xe1, 0xd6, 0x37, 0xe1, 0x9c, 0x79, 0xda, 0xf8, 0xb5, 0xf0, 0xec, 0xfb, 0x85, 0x67, 0xff, 0x59,
+	0x78, 0xf6, 0xcf, 0xa5, 0x67, 0xdd, 0x2f, 0x3d, 0xeb, 0xf7, 0xd2, 0xb3, 0xbe, 0x96, 0xd4, 0xaf,
+	0xe9, 0xe4, 0xef, 0x00, 0x85, 0x63, 0x51, 0x55, 0xa7, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -786,6 +912,7 @@ type WaveletClient interface {
 	CheckOutOfSync(ctx context.Context, in *OutOfSyncRequest, opts ...grpc.CallOption) (*OutOfSyncResponse, error)
 	Sync(ctx context.Context, opts ...grpc.CallOption) (Wavelet_SyncClient, error)
 	DownloadTx(ctx context.Context, in *DownloadTxRequest, opts ...grpc.CallOption) (*DownloadTxResponse, error)
+	Prove(ctx context.Context, in *ProveRequest, opts ...grpc.CallOption) (*ProveResponse, error)
 }
 
 type waveletClient struct {
@@ -888,6 +1015,15 @@ func (c *waveletClient) DownloadTx(ctx context.Context, in *DownloadTxRequest, o
 	return out, nil
 }
 
+func (c *waveletClient) Prove(ctx context.Context, in *ProveRequest, opts ...grpc.CallOption) (*ProveResponse, error) {
+	out := new(ProveResponse)
+	err := c.cc.Invoke(ctx, "/wavelet.Wavelet/Prove", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WaveletServer is the server API for Wavelet service.
 type WaveletServer interface {
 	Gossip(Wavelet_GossipServer) error
@@ -895,6 +1031,7 @@ type WaveletServer interface {
 	CheckOutOfSync(context.Context, *OutOfSyncRequest) (*OutOfSyncResponse, error)
 	Sync(Wavelet_SyncServer) error
 	DownloadTx(context.Context, *DownloadTxRequest) (*DownloadTxResponse, error)
+	Prove(context.Context, *ProveRequest) (*ProveResponse, error)
 }
 
 func RegisterWaveletServer(s *grpc.Server, srv WaveletServer) {
@@ -1007,6 +1144,24 @@ func _Wavelet_DownloadTx_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Wavelet_Prove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WaveletServer).Prove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wavelet.Wavelet/Prove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WaveletServer).Prove(ctx, req.(*ProveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Wavelet_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "wavelet.Wavelet",
 	HandlerType: (*WaveletServer)(nil),
@@ -1023,6 +1178,10 @@ var _Wavelet_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DownloadTx",
 			Handler:    _Wavelet_DownloadTx_Handler,
 		},
+		{
+			MethodName: "Prove",
+			Handler:    _Wavelet_Prove_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1084,6 +1243,12 @@ func (m *QueryResponse) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintRpc(dAtA, i, uint64(len(m.Round)))
 		i += copy(dAtA[i:], m.Round)
 	}
+	if len(m.Signature) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.Signature)))
+		i += copy(dAtA[i:], m.Signature)
+	}
 	return i, nil
 }
 
@@ -1126,6 +1291,12 @@ func (m *OutOfSyncResponse) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintRpc(dAtA, i, uint64(len(m.Round)))
 		i += copy(dAtA[i:], m.Round)
 	}
+	if len(m.Signature) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.Signature)))
+		i += copy(dAtA[i:], m.Signature)
+	}
 	return i, nil
 }
 
@@ -1304,6 +1475,65 @@ func (m *DownloadTxResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *ProveRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProveRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.RoundIndex != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(m.RoundIndex))
+	}
+	if len(m.Key) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	return i, nil
+}
+
+func (m *ProveResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProveResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Value) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	if len(m.Proof) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.Proof)))
+		i += copy(dAtA[i:], m.Proof)
+	}
+	return i, nil
+}
+
 func (m *Transactions) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1379,6 +1609,10 @@ func (m *QueryResponse) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovRpc(uint64(l))
 	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
 	return n
 }
 
@@ -1401,6 +1635,10 @@ func (m *OutOfSyncResponse) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovRpc(uint64(l))
 	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
 	return n
 }
 
@@ -1522,6 +1760,39 @@ func (m *DownloadTxResponse) Size() (n int) {
 	return n
 }
 
+func (m *ProveRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RoundIndex != 0 {
+		n += 1 + sovRpc(uint64(m.RoundIndex))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
+func (m *ProveResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.Proof)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
 func (m *Transactions) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1694,6 +1965,40 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 				m.Round = []byte{}
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(dAtA[iNdEx:])
@@ -1834,6 +2139,40 @@ func (m *OutOfSyncResponse) Unmarshal(dAtA []byte) error {
 				m.Round = []byte{}
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(dAtA[iNdEx:])
@@ -2374,6 +2713,233 @@ func (m *DownloadTxResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ProveRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProveRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProveRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RoundIndex", wireType)
+			}
+			m.RoundIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RoundIndex |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProveResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProveResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProveResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Proof = append(m.Proof[:0], dAtA[iNdEx:postIndex]...)
+			if m.Proof == nil {
+				m.Proof = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Transactions) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
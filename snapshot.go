@@ -0,0 +1,249 @@
+// Copyright (c) 2019 Perlin
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package wavelet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/perlin-network/wavelet/avl"
+	"github.com/perlin-network/wavelet/store"
+	"github.com/perlin-network/wavelet/sys"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+// SnapshotMagic prefixes every snapshot file, followed by the version of the snapshot format.
+var SnapshotMagic = [...]byte{'w', 's', 'n', 'p'}
+
+const SnapshotVersion = byte(1)
+
+// ExportSnapshot writes the header of a finalized round, alongside the state of all accounts at
+// the end of the round, to w. The state is written as a difference against the genesis state,
+// split into chunks of sys.SyncChunkSize bytes that are each prefixed with their checksum. The
+// latest round is exported should round be nil. Rounds prior to the latest round may only be
+// exported from the database of an archive node.
+//
+// The format of a snapshot is: the magic bytes, the version, the length-prefixed round header,
+// the number of chunks, and then each chunk comprised of its length, its BLAKE2b-256 checksum,
+// and its contents. All integers are big-endian encoded.
+func ExportSnapshot(kv store.KV, round *uint64, w io.Writer) (*Round, error) {
+	rounds, err := NewRounds(kv, sys.PruningLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load any rounds from the database")
+	}
+
+	header := rounds.Latest()
+	tree := avl.New(kv)
+
+	if round != nil && *round != header.Index {
+		if header, err = rounds.GetByIndex(*round); err != nil {
+			return nil, errors.Wrapf(err, "could not find the header of round %d", *round)
+		}
+
+		idx, exists := LoadArchivedRootIndex(kv, *round)
+		if !exists {
+			return nil, errors.Errorf("the state of round %d was never archived", *round)
+		}
+
+		root, exists := tree.OldRoot(idx)
+		if !exists {
+			return nil, errors.Errorf("the state of round %d has been pruned", *round)
+		}
+
+		if tree, err = tree.SnapshotAt(root); err != nil {
+			return nil, errors.Wrapf(err, "failed to load the state of round %d", *round)
+		}
+	}
+
+	if checksum := tree.Checksum(); checksum != header.Merkle {
+		return nil, errors.Errorf("expected the merkle root of round %d to be %x, but the state of the round has a root of %x", header.Index, header.Merkle, checksum)
+	}
+
+	diff := tree.DumpDiff(0)
+
+	var buf [4]byte
+
+	if _, err := w.Write(SnapshotMagic[:]); err != nil {
+		return nil, errors.Wrap(err, "failed to write snapshot magic")
+	}
+
+	if _, err := w.Write([]byte{SnapshotVersion}); err != nil {
+		return nil, errors.Wrap(err, "failed to write snapshot version")
+	}
+
+	marshaled := header.Marshal()
+
+	binary.BigEndian.PutUint32(buf[:], uint32(len(marshaled)))
+
+	if _, err := w.Write(append(buf[:], marshaled...)); err != nil {
+		return nil, errors.Wrap(err, "failed to write round header")
+	}
+
+	numChunks := (len(diff) + sys.SyncChunkSize - 1) / sys.SyncChunkSize
+
+	binary.BigEndian.PutUint32(buf[:], uint32(numChunks))
+
+	if _, err := w.Write(buf[:]); err != nil {
+		return nil, errors.Wrap(err, "failed to write number of chunks")
+	}
+
+	for i := 0; i < len(diff); i += sys.SyncChunkSize {
+		end := i + sys.SyncChunkSize
+
+		if end > len(diff) {
+			end = len(diff)
+		}
+
+		chunk := diff[i:end]
+		checksum := blake2b.Sum256(chunk)
+
+		binary.BigEndian.PutUint32(buf[:], uint32(len(chunk)))
+
+		if _, err := w.Write(buf[:]); err != nil {
+			return nil, errors.Wrap(err, "failed to write chunk length")
+		}
+
+		if _, err := w.Write(checksum[:]); err != nil {
+			return nil, errors.Wrap(err, "failed to write chunk checksum")
+		}
+
+		if _, err := w.Write(chunk); err != nil {
+			return nil, errors.Wrap(err, "failed to write chunk")
+		}
+	}
+
+	return header, nil
+}
+
+// ImportSnapshot reads a snapshot written by ExportSnapshot from r, and seeds an empty database
+// with the round header and account state held within it. The database is first seeded with the
+// genesis state, as the state within a snapshot is a difference against the genesis state. The
+// state of an existing database may have been pruned away from the genesis state, and so
+// snapshots may not be imported into a database that already holds rounds. The checksum of
+// each chunk, and the Merkle root of the state against the round header, are verified before
+// anything is committed to the database.
+func ImportSnapshot(kv store.KV, genesis *string, r io.Reader) (*Round, error) {
+	var magic [len(SnapshotMagic) + 1]byte
+
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return nil, errors.Wrap(err, "failed to read snapshot magic")
+	}
+
+	if !bytes.Equal(magic[:len(SnapshotMagic)], SnapshotMagic[:]) {
+		return nil, errors.New("file is not a snapshot")
+	}
+
+	if version := magic[len(SnapshotMagic)]; version != SnapshotVersion {
+		return nil, errors.Errorf("unsupported snapshot version %d", version)
+	}
+
+	var buf [4]byte
+
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return nil, errors.Wrap(err, "failed to read round header length")
+	}
+
+	marshaled := make([]byte, binary.BigEndian.Uint32(buf[:]))
+
+	if _, err := io.ReadFull(r, marshaled); err != nil {
+		return nil, errors.Wrap(err, "failed to read round header")
+	}
+
+	header, err := UnmarshalRound(bytes.NewReader(marshaled))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode round header")
+	}
+
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return nil, errors.Wrap(err, "failed to read number of chunks")
+	}
+
+	numChunks := binary.BigEndian.Uint32(buf[:])
+
+	var diff []byte
+
+	for i := uint32(0); i < numChunks; i++ {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return nil, errors.Wrapf(err, "failed to read length of chunk %d", i)
+		}
+
+		size := binary.BigEndian.Uint32(buf[:])
+		if size > uint32(sys.SyncChunkSize) {
+			return nil, errors.Errorf("chunk %d is %d bytes, which exceeds the max chunk size of %d bytes", i, size, sys.SyncChunkSize)
+		}
+
+		var checksum [blake2b.Size256]byte
+
+		if _, err := io.ReadFull(r, checksum[:]); err != nil {
+			return nil, errors.Wrapf(err, "failed to read checksum of chunk %d", i)
+		}
+
+		chunk := make([]byte, size)
+
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			return nil, errors.Wrapf(err, "failed to read chunk %d", i)
+		}
+
+		if blake2b.Sum256(chunk) != checksum {
+			return nil, errors.Errorf("checksum of chunk %d does not match its contents", i)
+		}
+
+		diff = append(diff, chunk...)
+	}
+
+	accounts := NewAccounts(kv)
+
+	rounds, err := NewRounds(kv, sys.PruningLimit)
+	if err == nil {
+		return nil, errors.Errorf("the database is already at round %d, and so a snapshot may only be imported into an empty database", rounds.Latest().Index)
+	}
+
+	if errors.Cause(err) != store.ErrNotFound {
+		return nil, errors.Wrap(err, "failed to check whether the database is empty")
+	}
+
+	inception := performInception(accounts.tree, genesis)
+
+	if inception.Index >= header.Index {
+		return nil, errors.Errorf("round %d of the snapshot is not ahead of the genesis round", header.Index)
+	}
+
+	if _, err := commitRound(accounts, rounds, &inception, nil, false); err != nil {
+		return nil, errors.Wrap(err, "failed to commit genesis round")
+	}
+
+	snapshot := accounts.Snapshot()
+
+	if err := snapshot.ApplyDiff(diff); err != nil {
+		return nil, errors.Wrap(err, "failed to apply the state held within the snapshot")
+	}
+
+	if checksum := snapshot.Checksum(); checksum != header.Merkle {
+		return nil, errors.Errorf("expected the merkle root of round %d to be %x, but the state held within the snapshot has a root of %x", header.Index, header.Merkle, checksum)
+	}
+
+	if _, err := commitRound(accounts, rounds, &header, snapshot, false); err != nil {
+		return nil, errors.Wrap(err, "failed to commit the round and state held within the snapshot")
+	}
+
+	return &header, nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2019 Perlin
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package wavelet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/perlin-network/wavelet/store"
+	"github.com/perlin-network/wavelet/sys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotExportImport(t *testing.T) {
+	kv := store.NewInmem()
+
+	// Seed the database with the genesis state, and a single round on top of it.
+
+	accounts := NewAccounts(kv)
+
+	rounds, err := NewRounds(kv, sys.PruningLimit)
+	assert.Error(t, err)
+
+	genesis := performInception(accounts.tree, nil)
+	assert.NoError(t, accounts.Commit(nil))
+
+	_, err = rounds.Save(&genesis)
+	assert.NoError(t, err)
+
+	snapshot := accounts.Snapshot()
+	snapshot.SetViewID(1)
+
+	for i := byte(1); i <= 100; i++ {
+		WriteAccountBalance(snapshot, AccountID{i}, uint64(i))
+	}
+
+	assert.NoError(t, accounts.Commit(snapshot))
+
+	round := NewRound(1, snapshot.Checksum(), 100, genesis.End, genesis.End)
+
+	_, err = rounds.Save(&round)
+	assert.NoError(t, err)
+
+	// The state of past rounds may only be exported by archive nodes.
+
+	past := uint64(0)
+
+	_, err = ExportSnapshot(kv, &past, new(bytes.Buffer))
+	assert.Error(t, err)
+
+	var buf bytes.Buffer
+
+	exported, err := ExportSnapshot(kv, nil, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, round.ID, exported.ID)
+
+	file := buf.Bytes()
+
+	// Snapshots with corrupted chunks may not be imported.
+
+	corrupted := append([]byte{}, file...)
+	corrupted[len(corrupted)-1]++
+
+	_, err = ImportSnapshot(store.NewInmem(), nil, bytes.NewReader(corrupted))
+	assert.Error(t, err)
+
+	_, err = ImportSnapshot(store.NewInmem(), nil, bytes.NewReader([]byte("not a snapshot")))
+	assert.Error(t, err)
+
+	// Import the snapshot into an empty database.
+
+	imported := store.NewInmem()
+
+	header, err := ImportSnapshot(imported, nil, bytes.NewReader(file))
+	assert.NoError(t, err)
+	assert.Equal(t, round.ID, header.ID)
+
+	rounds, err = NewRounds(imported, sys.PruningLimit)
+	assert.NoError(t, err)
+	assert.Equal(t, round.ID, rounds.Latest().ID)
+
+	state := NewAccounts(imported).Snapshot()
+	assert.Equal(t, round.Merkle, state.Checksum())
+
+	for i := byte(1); i <= 100; i++ {
+		balance, _ := ReadAccountBalance(state, AccountID{i})
+		assert.Equal(t, uint64(i), balance)
+	}
+
+	// Snapshots may only be imported into an empty database.
+
+	_, err = ImportSnapshot(imported, nil, bytes.NewReader(file))
+	assert.Error(t, err)
+
+	behind := store.NewInmem()
+
+	behindAccounts := NewAccounts(behind)
+	behindRounds, err := NewRounds(behind, sys.PruningLimit)
+	assert.Error(t, err)
+
+	inception := performInception(behindAccounts.tree, nil)
+
+	_, err = commitRound(behindAccounts, behindRounds, &inception, nil, false)
+	assert.NoError(t, err)
+
+	_, err = ImportSnapshot(behind, nil, bytes.NewReader(file))
+	assert.Error(t, err)
+}
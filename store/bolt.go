@@ -0,0 +1,205 @@
+// Copyright (c) 2019 Perlin
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package store
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+)
+
+// All keys are stored within a single bucket, as the KV interface has no notion of buckets.
+var boltBucket = []byte("wavelet")
+
+var _ WriteBatch = (*boltWriteBatch)(nil)
+
+type boltWriteBatch struct {
+	pairs []kvPair
+}
+
+func (b *boltWriteBatch) Put(key, value []byte) {
+	// Callers may reuse their buffers once Put returns, so the pair is copied.
+	b.pairs = append(b.pairs, kvPair{key: append([]byte{}, key...), value: append([]byte{}, value...)})
+}
+
+func (b *boltWriteBatch) Clear() {
+	b.pairs = make([]kvPair, 0)
+}
+
+func (b *boltWriteBatch) Count() int {
+	return len(b.pairs)
+}
+
+func (b *boltWriteBatch) Destroy() {
+	b.pairs = nil
+}
+
+var _ KV = (*boltKV)(nil)
+
+type boltKV struct {
+	dir string
+	db  *bbolt.DB
+}
+
+func (b *boltKV) Close() error {
+	return b.db.Close()
+}
+
+func (b *boltKV) Get(key []byte) ([]byte, error) {
+	var value []byte
+
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		buf := tx.Bucket(boltBucket).Get(key)
+		if buf == nil {
+			return ErrNotFound
+		}
+
+		// Values returned by bolt are only valid for the lifetime of the transaction.
+		value = append([]byte{}, buf...)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (b *boltKV) MultiGet(keys ...[]byte) ([][]byte, error) {
+	var bufs = make([][]byte, len(keys))
+
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(boltBucket)
+
+		for i := range keys {
+			buf := bucket.Get(keys[i])
+			if buf == nil {
+				return ErrNotFound
+			}
+
+			bufs[i] = append([]byte{}, buf...)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bufs, nil
+}
+
+func (b *boltKV) Put(key, value []byte) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(boltBucket).Put(key, value)
+	})
+}
+
+func (b *boltKV) NewWriteBatch() WriteBatch {
+	return new(boltWriteBatch)
+}
+
+func (b *boltKV) CommitWriteBatch(batch WriteBatch) error {
+	wb, ok := batch.(*boltWriteBatch)
+	if !ok {
+		return errors.New("bolt: not fed in a proper bolt write batch")
+	}
+
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(boltBucket)
+
+		for _, pair := range wb.pairs {
+			if err := bucket.Put(pair.key, pair.value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (b *boltKV) Delete(key []byte) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(boltBucket).Delete(key)
+	})
+}
+
+// NewIterator copies all pairs within the range of the iterator out of a single read-only
+// transaction, as holding a transaction open for the lifetime of the iterator would block the
+// database from growing while writes take place, deadlocking callers that write while iterating.
+// Should the transaction fail, the error is reported by the iterator.
+func (b *boltKV) NewIterator(prefix, start []byte) Iterator {
+	var pairs []kvPair
+
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(boltBucket).Cursor()
+
+		for key, value := c.Seek(iteratorStart(prefix, start)); key != nil && bytes.HasPrefix(key, prefix); key, value = c.Next() {
+			pairs = append(pairs, kvPair{key: append([]byte{}, key...), value: append([]byte{}, value...)})
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return &pairIterator{err: errors.Wrap(err, "bolt: failed to iterate")}
+	}
+
+	return &pairIterator{pairs: pairs}
+}
+
+// NewBolt opens a BoltDB database stored in a single file within the directory dir, creating
+// both the directory and the database should they not exist.
+func NewBolt(dir string) (*boltKV, error) {
+	if len(dir) == 0 {
+		return nil, errors.New("bolt: a directory to store the database in must be specified")
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "failed to create bolt directory")
+	}
+
+	db, err := bbolt.Open(filepath.Join(dir, "wavelet.db"), 0600, &bbolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init bolt")
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltBucket)
+		return err
+	})
+
+	if err != nil {
+		_ = db.Close()
+		return nil, errors.Wrap(err, "failed to init bolt bucket")
+	}
+
+	return &boltKV{
+		dir: dir,
+		db:  db,
+	}, nil
+}
@@ -38,7 +38,14 @@ type inmemWriteBatch struct {
 }
 
 func (b *inmemWriteBatch) Put(key, value []byte) {
-	b.pairs = append(b.pairs, kvPair{key: key, value: value})
+	// Like levelDB and BoltDB batches, the pair is copied as callers (such as the AVL tree,
+	// which serializes nodes into pooled buffers) may reuse their buffers once Put returns.
+	pair := kvPair{key: make([]byte, len(key)), value: make([]byte, len(value))}
+
+	copy(pair.key, key)
+	copy(pair.value, value)
+
+	b.pairs = append(b.pairs, pair)
 }
 
 func (b *inmemWriteBatch) Clear() {
@@ -80,7 +87,7 @@ func (s *inmemKV) Get(key []byte) ([]byte, error) {
 
 	buf, found := s.db.GetValue(key)
 	if !found {
-		return nil, errors.New("key not found")
+		return nil, ErrNotFound
 	}
 
 	return buf.([]byte), nil
@@ -95,7 +102,7 @@ func (s *inmemKV) MultiGet(keys ...[]byte) ([][]byte, error) {
 	for _, key := range keys {
 		buf, found := s.db.GetValue(key)
 		if !found {
-			return nil, errors.New("key not found")
+			return nil, ErrNotFound
 		}
 
 		bufs = append(bufs, buf.([]byte))
@@ -133,6 +140,9 @@ func (s *inmemKV) CommitWriteBatch(batch WriteBatch) error {
 			_ = s.db.Set(pair.key, pair.value)
 		}
 
+		// Clear the batch before pooling it, such that its pairs are not committed again
+		// by whoever gets handed the batch next.
+		wb.Clear()
 		writeBatchPool.Put(wb)
 		return nil
 	}
@@ -148,9 +158,31 @@ func (s *inmemKV) Delete(key []byte) error {
 	return nil
 }
 
+func (s *inmemKV) NewIterator(prefix, start []byte) Iterator {
+	s.RLock()
+	defer s.RUnlock()
+
+	start = iteratorStart(prefix, start)
+
+	var pairs []kvPair
+
+	for elem := s.db.Find(start); elem != nil; elem = elem.Next() {
+		key := elem.Key().([]byte)
+
+		// Keys are sorted, so no keys past the first key without the prefix have the prefix.
+		if !bytes.HasPrefix(key, prefix) {
+			break
+		}
+
+		pairs = append(pairs, kvPair{key: key, value: elem.Value.([]byte)})
+	}
+
+	return &pairIterator{pairs: pairs}
+}
+
 func NewInmem() *inmemKV {
-	var comparator skiplist.GreaterThanFunc = func(lhs, rhs interface{}) bool {
-		return bytes.Compare(lhs.([]byte), rhs.([]byte)) == 1
+	var comparator skiplist.GreaterThanFunc = func(lhs, rhs interface{}) int {
+		return bytes.Compare(lhs.([]byte), rhs.([]byte))
 	}
 
 	return &inmemKV{db: skiplist.New(comparator)}
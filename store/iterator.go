@@ -0,0 +1,77 @@
+// Copyright (c) 2019 Perlin
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package store
+
+import (
+	"bytes"
+)
+
+var _ Iterator = (*pairIterator)(nil)
+
+// pairIterator iterates over key-value pairs that were copied out of a database upon the
+// iterators creation, which trivially makes it consistent with the database at that time. Should
+// the pairs have failed to be copied out, the iterator yields no pairs and reports err instead.
+type pairIterator struct {
+	pairs []kvPair
+	pos   int
+	err   error
+}
+
+func (it *pairIterator) Next() bool {
+	if it.pos <= len(it.pairs) {
+		it.pos++
+	}
+
+	return it.pos <= len(it.pairs)
+}
+
+func (it *pairIterator) Key() []byte {
+	if it.pos == 0 || it.pos > len(it.pairs) {
+		return nil
+	}
+
+	return it.pairs[it.pos-1].key
+}
+
+func (it *pairIterator) Value() []byte {
+	if it.pos == 0 || it.pos > len(it.pairs) {
+		return nil
+	}
+
+	return it.pairs[it.pos-1].value
+}
+
+func (it *pairIterator) Error() error {
+	return it.err
+}
+
+func (it *pairIterator) Release() {
+	it.pairs = nil
+}
+
+// iteratorStart returns the first key an iterator over keys with the prefix prefix, starting
+// from start, should seek to.
+func iteratorStart(prefix, start []byte) []byte {
+	if bytes.Compare(start, prefix) > 0 {
+		return start
+	}
+
+	return prefix
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2019 Perlin
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package store
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// Every backend is run against the same conformance suite.
+var backends = []string{"inmem", "level", "bolt"}
+
+// Backends whose contents persist after being closed and re-opened, and the engine to re-open them with.
+var persistentBackends = map[string]string{
+	"level": EngineLevelDB,
+	"bolt":  EngineBolt,
+}
+
+func BenchmarkKV(b *testing.B) {
+	for _, backend := range backends {
+		b.Run(backend, func(b *testing.B) {
+			b.StopTimer()
+
+			db, cleanup := NewTestKV(b, backend, backend)
+			defer cleanup()
+
+			b.StartTimer()
+			defer b.StopTimer()
+
+			for i := 0; i < b.N; i++ {
+				var randomKey [128]byte
+				var randomValue [600]byte
+
+				_, err := rand.Read(randomKey[:])
+				assert.NoError(b, err)
+				_, err = rand.Read(randomValue[:])
+				assert.NoError(b, err)
+
+				err = db.Put(randomKey[:], randomValue[:])
+				assert.NoError(b, err)
+
+				value, err := db.Get(randomKey[:])
+				assert.NoError(b, err)
+
+				assert.EqualValues(b, randomValue[:], value)
+			}
+		})
+	}
+}
+
+func TestExistence(t *testing.T) {
+	for _, backend := range backends {
+		t.Run(backend, func(t *testing.T) {
+			db, cleanup := NewTestKV(t, backend, backend)
+			defer cleanup()
+
+			_, err := db.Get([]byte("not_exist"))
+			assert.Equal(t, ErrNotFound, err)
+
+			err = db.Put([]byte("exist"), []byte{})
+			assert.NoError(t, err)
+
+			val, err := db.Get([]byte("exist"))
+			assert.NoError(t, err)
+			assert.Equal(t, []byte{}, val)
+		})
+	}
+}
+
+func TestWriteBatch(t *testing.T) {
+	for _, backend := range backends {
+		t.Run(backend, func(t *testing.T) {
+			db, cleanup := NewTestKV(t, backend, backend)
+			defer cleanup()
+
+			err := db.Put([]byte("exist"), []byte("value"))
+			assert.NoError(t, err)
+
+			key := []byte("key_batch1")
+			value := []byte("val_batch1")
+
+			wb := db.NewWriteBatch()
+			wb.Put(key, value)
+			wb.Put([]byte("key_batch2"), []byte("val_batch2"))
+			wb.Put([]byte("key_batch3"), []byte("val_batch2"))
+			assert.Equal(t, 3, wb.Count())
+
+			// Buffers passed into a write batch may be reused once Put returns.
+			key[0], value[0] = 'x', 'x'
+
+			assert.NoError(t, db.CommitWriteBatch(wb))
+
+			// Check multiget
+			mv, err := db.MultiGet([]byte("key_batch1"), []byte("key_batch2"))
+			assert.NoError(t, err)
+			assert.Equal(t, [][]byte{[]byte("val_batch1"), []byte("val_batch2")}, mv)
+
+			_, err = db.MultiGet([]byte("key_batch1"), []byte("not_exist"))
+			assert.Error(t, err)
+
+			// Check delete
+			assert.NoError(t, db.Delete([]byte("exist")))
+
+			_, err = db.Get([]byte("exist"))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestIterator(t *testing.T) {
+	for _, backend := range backends {
+		t.Run(backend, func(t *testing.T) {
+			db, cleanup := NewTestKV(t, backend, backend)
+			defer cleanup()
+
+			for _, key := range []string{"a", "b1", "b2", "b3", "b4", "c"} {
+				assert.NoError(t, db.Put([]byte(key), []byte("val_"+key)))
+			}
+
+			collect := func(it Iterator) (keys []string) {
+				defer it.Release()
+
+				for it.Next() {
+					assert.Equal(t, "val_"+string(it.Key()), string(it.Value()))
+					keys = append(keys, string(it.Key()))
+				}
+
+				assert.NoError(t, it.Error())
+
+				return keys
+			}
+
+			assert.Equal(t, []string{"b1", "b2", "b3", "b4"}, collect(db.NewIterator([]byte("b"), nil)))
+			assert.Equal(t, []string{"b3", "b4"}, collect(db.NewIterator([]byte("b"), []byte("b3"))))
+			assert.Equal(t, []string{"b3", "b4"}, collect(db.NewIterator([]byte("b"), []byte("b25"))))
+			assert.Equal(t, []string{"c"}, collect(db.NewIterator(nil, []byte("b5"))))
+			assert.Equal(t, []string{"a", "b1", "b2", "b3", "b4", "c"}, collect(db.NewIterator(nil, nil)))
+			assert.Empty(t, collect(db.NewIterator([]byte("b"), []byte("c"))))
+			assert.Empty(t, collect(db.NewIterator([]byte("d"), nil)))
+
+			// Iterators must not observe writes made after they were created.
+			it := db.NewIterator([]byte("b"), nil)
+
+			assert.NoError(t, db.Put([]byte("b5"), []byte("val_b5")))
+			assert.NoError(t, db.Delete([]byte("b1")))
+
+			assert.Equal(t, []string{"b1", "b2", "b3", "b4"}, collect(it))
+			assert.Equal(t, []string{"b2", "b3", "b4", "b5"}, collect(db.NewIterator([]byte("b"), nil)))
+		})
+	}
+}
+
+func TestBoltIteratorError(t *testing.T) {
+	db, cleanup := NewTestKV(t, "bolt", "bolt")
+	defer cleanup()
+
+	assert.NoError(t, db.Put([]byte("a"), []byte("val_a")))
+	assert.NoError(t, db.Close())
+
+	// Iterators report failing to read from the database, rather than appearing empty.
+	it := db.NewIterator(nil, nil)
+	defer it.Release()
+
+	assert.False(t, it.Next())
+	assert.Error(t, it.Error())
+}
+
+func TestPersistence(t *testing.T) {
+	for backend, engine := range persistentBackends {
+		backend, engine := backend, engine
+
+		t.Run(backend, func(t *testing.T) {
+			db, cleanup := NewTestKV(t, backend, backend)
+			defer cleanup()
+
+			err := db.Put([]byte("exist"), []byte("value"))
+			assert.NoError(t, err)
+
+			wb := db.NewWriteBatch()
+			wb.Put([]byte("key_batch1"), []byte("val_batch1"))
+			wb.Put([]byte("key_batch2"), []byte("val_batch2"))
+			assert.NoError(t, db.CommitWriteBatch(wb))
+
+			assert.NoError(t, db.Close())
+
+			db2, err := NewKV(engine, backend)
+			assert.NoError(t, err)
+			defer db2.Close()
+
+			v, err := db2.Get([]byte("exist"))
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("value"), v)
+
+			mv, err := db2.MultiGet([]byte("key_batch1"), []byte("key_batch2"))
+			assert.NoError(t, err)
+			assert.Equal(t, [][]byte{[]byte("val_batch1"), []byte("val_batch2")}, mv)
+		})
+	}
+}
+
+func TestNewKV(t *testing.T) {
+	_, err := NewKV("unknown", "")
+	assert.Error(t, err)
+
+	_, err = NewBolt("")
+	assert.Error(t, err)
+}
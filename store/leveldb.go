@@ -25,6 +25,7 @@ import (
 	"github.com/syndtr/goleveldb/leveldb/filter"
 	"github.com/syndtr/goleveldb/leveldb/opt"
 	"github.com/syndtr/goleveldb/leveldb/storage"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 var _ WriteBatch = (*leveldbWriteBatch)(nil)
@@ -61,7 +62,12 @@ func (l *leveldbKV) Close() error {
 }
 
 func (l *leveldbKV) Get(key []byte) ([]byte, error) {
-	return l.db.Get(key, nil)
+	buf, err := l.db.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return nil, ErrNotFound
+	}
+
+	return buf, err
 }
 
 func (l *leveldbKV) MultiGet(keys ...[]byte) ([][]byte, error) {
@@ -102,6 +108,13 @@ func (l *leveldbKV) Delete(key []byte) error {
 	return l.db.Delete(key, nil)
 }
 
+func (l *leveldbKV) NewIterator(prefix, start []byte) Iterator {
+	r := util.BytesPrefix(prefix)
+	r.Start = iteratorStart(prefix, start)
+
+	return l.db.NewIterator(r, nil)
+}
+
 func NewLevelDB(dir string) (*leveldbKV, error) {
 	opts := &opt.Options{
 		Filter:       filter.NewBloomFilter(10),
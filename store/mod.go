@@ -21,6 +21,17 @@ package store
 
 import (
 	"io"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNotFound is returned by a KV should a key that is looked up not exist.
+var ErrNotFound = errors.New("key not found")
+
+// Names of the storage engines that may be opened through NewKV.
+const (
+	EngineLevelDB = "leveldb"
+	EngineBolt    = "bolt"
 )
 
 type KV interface {
@@ -35,6 +46,24 @@ type KV interface {
 	CommitWriteBatch(batch WriteBatch) error
 
 	Delete(key []byte) error
+
+	// NewIterator returns an iterator over all keys that have the prefix prefix, in ascending
+	// order, starting from the first key that is equal to or greater than start. The iterator
+	// observes a consistent snapshot of the database taken upon its creation.
+	NewIterator(prefix, start []byte) Iterator
+}
+
+// Iterator iterates over the key-value pairs of a KV in ascending order of their keys. Next must
+// be called before the first pair is read. The slices returned by Key and Value are only valid
+// until the next call to Next, and Release must be called once the iterator is no longer in use.
+type Iterator interface {
+	Next() bool
+
+	Key() []byte
+	Value() []byte
+
+	Error() error
+	Release()
 }
 
 type WriteBatch interface {
@@ -44,3 +73,16 @@ type WriteBatch interface {
 	Count() int
 	Destroy()
 }
+
+// NewKV opens the database located at dir using the storage engine named engine. Should dir
+// be empty, engines that support it open a temporary in-memory database instead.
+func NewKV(engine string, dir string) (KV, error) {
+	switch engine {
+	case EngineLevelDB:
+		return NewLevelDB(dir)
+	case EngineBolt:
+		return NewBolt(dir)
+	default:
+		return nil, errors.Errorf("unknown storage engine %q", engine)
+	}
+}
@@ -30,6 +30,20 @@ func NewTestKV(t testing.TB, kv string, path string) (KV, func()) {
 			_ = os.RemoveAll(path)
 		}
 
+	case "bolt":
+		// Remove existing db
+		_ = os.RemoveAll(path)
+
+		boltdb, err := NewBolt(path)
+		if err != nil {
+			t.Fatalf("failed to create BoltDB: %s", err)
+		}
+
+		return boltdb, func() {
+			_ = boltdb.Close()
+			_ = os.RemoveAll(path)
+		}
+
 	default:
 		panic("unknown kv " + kv)
 	}
@@ -0,0 +1,74 @@
+// Copyright (c) 2019 Perlin
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package sys
+
+// GasSchedule denotes the amount of gas a smart contract is charged for executing
+// WebAssembly instructions, invoking host functions, and growing its memory.
+type GasSchedule struct {
+	// Version of the schedule.
+	Version uint32
+
+	// Round index from which all nodes start charging gas by this schedule.
+	Round uint64
+
+	// Gas charged per WebAssembly instruction or host function, keyed by name.
+	Costs map[string]uint64
+
+	// Gas charged per WebAssembly instruction or host function not found in Costs.
+	DefaultCost uint64
+
+	// Gas charged per page of memory a smart contract grows its memory by.
+	MemoryPageCost uint64
+}
+
+// Cost returns the amount of gas charged for a WebAssembly instruction or host
+// function by its name.
+func (s GasSchedule) Cost(key string) uint64 {
+	cost, ok := s.Costs[key]
+	if !ok {
+		return s.DefaultCost
+	}
+
+	return cost
+}
+
+var (
+	// Gas schedules ordered by the round index they take effect from. A new schedule
+	// is to be appended to the end of the list, such that all nodes switch over to it
+	// once they reach the same round index.
+	GasSchedules = []GasSchedule{
+		{Version: 1, Round: 0, Costs: GasTable, DefaultCost: 1, MemoryPageCost: 1000},
+	}
+)
+
+// GasScheduleAt returns the gas schedule that is in effect for a given round index.
+func GasScheduleAt(round uint64) GasSchedule {
+	var schedule GasSchedule
+
+	for _, s := range GasSchedules {
+		if s.Round > round {
+			break
+		}
+
+		schedule = s
+	}
+
+	return schedule
+}
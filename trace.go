@@ -0,0 +1,95 @@
+// Copyright (c) 2019 Perlin
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package wavelet
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kinds of entries recorded in the trace of a smart contracts execution.
+const (
+	TraceHostCall     = "host_call"
+	TraceMemoryGrowth = "memory_growth"
+	TraceExit         = "exit"
+)
+
+// ContractTrace records every host function a smart contract calls, every time a smart
+// contract grows its memory, and the reason a smart contract exited. Tracing is opt-in,
+// and is enabled by setting the Trace field of a ContractExecutor before executing a
+// smart contract. Smart contracts called by a traced smart contract are traced as well.
+type ContractTrace struct {
+	Entries []ContractTraceEntry
+}
+
+type ContractTraceEntry struct {
+	Kind string
+
+	// Smart contract the entry was recorded for, and how deep it is nested within calls
+	// made to other smart contracts. The smart contract executed first has a depth of 0.
+	Contract AccountID
+	Depth    int
+
+	// Name, arguments, and return value of a host function call. The return value of an
+	// attempt to grow memory is the number of pages of memory prior to growing it, or -1
+	// should memory not have been grown.
+	Name   string
+	Args   []int64
+	Result int64
+
+	// Gas used by the smart contract once the entry was recorded.
+	Gas uint64
+
+	// Size in bytes the smart contract attempted to grow its memory to.
+	MemorySize int
+
+	// Reason the smart contract exited. It is "ok" should the smart contract have exited
+	// successfully.
+	Reason string
+}
+
+func (t *ContractTrace) record(entry ContractTraceEntry) int {
+	t.Entries = append(t.Entries, entry)
+	return len(t.Entries) - 1
+}
+
+func (e ContractTraceEntry) String() string {
+	prefix := fmt.Sprintf("%s[%x] gas=%d ", strings.Repeat("  ", e.Depth), e.Contract[:4], e.Gas)
+
+	switch e.Kind {
+	case TraceHostCall:
+		args := make([]string, len(e.Args))
+		for i, arg := range e.Args {
+			args[i] = fmt.Sprint(arg)
+		}
+
+		return prefix + fmt.Sprintf("%s(%s) = %d", e.Name, strings.Join(args, ", "), e.Result)
+	case TraceMemoryGrowth:
+		if e.Result < 0 {
+			return prefix + fmt.Sprintf("memory not grown to %d pages", e.MemorySize/PageSize)
+		}
+
+		return prefix + fmt.Sprintf("memory grown to %d pages", e.MemorySize/PageSize)
+	case TraceExit:
+		return prefix + "exit: " + e.Reason
+	default:
+		return prefix + e.Kind
+	}
+}
@@ -40,6 +40,7 @@ type Transaction struct {
 	Creator AccountID // Transaction creator.
 
 	Nonce uint64
+	Tip   uint64 // Priority tip paid by the creator on top of the transaction fee.
 
 	ParentIDs   []TransactionID // Transactions parents.
 	ParentSeeds []TransactionSeed
@@ -58,17 +59,38 @@ type Transaction struct {
 	SeedLen byte            // Number of prefixed zeroes of BLAKE2b(Sender || ParentIDs).
 }
 
-func NewTransaction(creator *skademlia.Keypair, tag sys.Tag, payload []byte) Transaction {
-	tx := Transaction{Tag: tag, Payload: payload}
+// NewTransaction creates a new transaction signed by its creator. The creators nonce is
+// signed alongside the transactions tag and payload, such that the transaction may not be
+// re-wrapped and replayed by a different sender once it has been applied.
+func NewTransaction(creator *skademlia.Keypair, nonce uint64, tag sys.Tag, payload []byte) Transaction {
+	return NewTransactionWithTip(creator, nonce, 0, tag, payload)
+}
 
-	var nonce [8]byte // TODO(kenta): nonce
+// NewTransactionWithTip creates a new transaction signed by its creator, which pays a
+// priority tip on top of its transaction fee to have the transaction be preferred by
+// nodes when selecting parents and gossiping.
+func NewTransactionWithTip(creator *skademlia.Keypair, nonce, tip uint64, tag sys.Tag, payload []byte) Transaction {
+	tx := Transaction{Nonce: nonce, Tip: tip, Tag: tag, Payload: payload}
 
 	tx.Creator = creator.PublicKey()
-	tx.CreatorSignature = edwards25519.Sign(creator.PrivateKey(), append(nonce[:], append([]byte{byte(tx.Tag)}, tx.Payload...)...))
+	tx.CreatorSignature = edwards25519.Sign(creator.PrivateKey(), tx.CreatorSignaturePayload())
 
 	return tx
 }
 
+// CreatorSignaturePayload returns the contents of a transaction that are signed by its
+// creator, comprised of the creators nonce, the tip, the transactions tag, and its payload.
+func (tx Transaction) CreatorSignaturePayload() []byte {
+	buf := make([]byte, 16, 16+1+len(tx.Payload))
+	binary.BigEndian.PutUint64(buf[:8], tx.Nonce)
+	binary.BigEndian.PutUint64(buf[8:16], tx.Tip)
+
+	buf = append(buf, byte(tx.Tag))
+	buf = append(buf, tx.Payload...)
+
+	return buf
+}
+
 // AttachSenderToTransaction immutably attaches sender to a transaction without modifying it in-place.
 func AttachSenderToTransaction(sender *skademlia.Keypair, tx Transaction, parents ...*Transaction) Transaction {
 	if len(parents) > 0 {
@@ -136,7 +158,7 @@ func (tx *Transaction) rehash() {
 }
 
 func (tx Transaction) Marshal() []byte {
-	w := bytes.NewBuffer(make([]byte, 0, 222+(SizeTransactionID*len(tx.ParentIDs))+SizeTransactionSeed*len(tx.ParentSeeds)+len(tx.Payload)))
+	w := bytes.NewBuffer(make([]byte, 0, 230+(SizeTransactionID*len(tx.ParentIDs))+SizeTransactionSeed*len(tx.ParentSeeds)+len(tx.Payload)))
 
 	w.Write(tx.Sender[:])
 
@@ -152,6 +174,9 @@ func (tx Transaction) Marshal() []byte {
 	binary.BigEndian.PutUint64(buf[:8], tx.Nonce)
 	w.Write(buf[:8])
 
+	binary.BigEndian.PutUint64(buf[:8], tx.Tip)
+	w.Write(buf[:8])
+
 	w.WriteByte(byte(len(tx.ParentIDs)))
 	for _, parentID := range tx.ParentIDs {
 		w.Write(parentID[:])
@@ -214,6 +239,13 @@ func UnmarshalTransaction(r io.Reader) (t Transaction, err error) {
 
 	t.Nonce = binary.BigEndian.Uint64(buf[:8])
 
+	if _, err = io.ReadFull(r, buf[:8]); err != nil {
+		err = errors.Wrap(err, "failed to read tip")
+		return
+	}
+
+	t.Tip = binary.BigEndian.Uint64(buf[:8])
+
 	if _, err = io.ReadFull(r, buf[:1]); err != nil {
 		err = errors.Wrap(err, "failed to read num parents")
 		return
@@ -307,6 +339,16 @@ func (tx Transaction) LogicalUnits() int {
 	return int(buf[0])
 }
 
+// Fee computes the fee the creator of the specified tx pays to have it applied, excluding
+// any priority tip. The fee scales with the number of logical units the tx comprises of,
+// alongside the number of KiBs its payload takes up.
+func (tx Transaction) Fee() uint64 {
+	units := uint64(tx.LogicalUnits())
+	kibs := (uint64(len(tx.Payload)) + 1023) / 1024
+
+	return units*sys.TransactionFeeAmount + kibs*sys.TransactionFeePerKiB
+}
+
 func (tx Transaction) String() string {
 	return fmt.Sprintf("Transaction{ID: %x}", tx.ID)
 }
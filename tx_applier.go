@@ -21,8 +21,10 @@ package wavelet
 
 import (
 	"encoding/hex"
+	"math"
 
 	wasm "github.com/perlin-network/life/wasm-validation"
+	"github.com/perlin-network/noise/edwards25519"
 	"github.com/perlin-network/wavelet/avl"
 	"github.com/perlin-network/wavelet/log"
 	"github.com/perlin-network/wavelet/sys"
@@ -33,6 +35,10 @@ type contractExecutorState struct {
 	GasPayer      AccountID
 	GasLimit      uint64
 	GasLimitIsSet bool
+	GasConsumed   uint64
+
+	// Events emitted by smart contracts invoked by the transaction.
+	Events []Event
 }
 
 func ApplyTransaction(round *Round, state *avl.Tree, tx *Transaction) error {
@@ -43,29 +49,46 @@ func ApplyTransaction(round *Round, state *avl.Tree, tx *Transaction) error {
 
 func applyTransaction(round *Round, state *avl.Tree, tx *Transaction, execState *contractExecutorState) error {
 	original := state.Snapshot()
+	originalEvents := len(execState.Events)
 
 	switch tx.Tag {
 	case sys.TagNop:
 	case sys.TagTransfer:
 		if err := applyTransferTransaction(state, round, tx, execState); err != nil {
 			state.Revert(original)
+			execState.Events = execState.Events[:originalEvents]
 			return errors.Wrap(err, "could not apply transfer transaction")
 		}
 	case sys.TagStake:
 		if err := applyStakeTransaction(state, round, tx); err != nil {
 			state.Revert(original)
+			execState.Events = execState.Events[:originalEvents]
 			return errors.Wrap(err, "could not apply stake transaction")
 		}
 	case sys.TagContract:
 		if err := applyContractTransaction(state, round, tx, execState); err != nil {
 			state.Revert(original)
+			execState.Events = execState.Events[:originalEvents]
 			return errors.Wrap(err, "could not apply contract transaction")
 		}
 	case sys.TagBatch:
 		if err := applyBatchTransaction(state, round, tx, execState); err != nil {
 			state.Revert(original)
+			execState.Events = execState.Events[:originalEvents]
 			return errors.Wrap(err, "could not apply batch transaction")
 		}
+	case sys.TagContractLifecycle:
+		if err := applyContractLifecycleTransaction(state, tx); err != nil {
+			state.Revert(original)
+			execState.Events = execState.Events[:originalEvents]
+			return errors.Wrap(err, "could not apply contract lifecycle transaction")
+		}
+	case sys.TagEvidence:
+		if err := applyEvidenceTransaction(state, round, tx); err != nil {
+			state.Revert(original)
+			execState.Events = execState.Events[:originalEvents]
+			return errors.Wrap(err, "could not apply evidence transaction")
+		}
 	}
 
 	return nil
@@ -148,11 +171,11 @@ func applyStakeTransaction(snapshot *avl.Tree, round *Round, tx *Transaction) er
 		WriteAccountStake(snapshot, tx.Creator, stake+payload.Amount)
 	case sys.WithdrawStake:
 		if stake < payload.Amount {
-			return errors.Errorf("stake: %x attempt to withdraw a stake of %d PERLs, but only has staked %d PERLs", tx.Creator, payload.Amount, payload)
+			return errors.Errorf("stake: %x attempt to withdraw a stake of %d PERLs, but only has staked %d PERLs", tx.Creator, payload.Amount, stake)
 		}
 
-		WriteAccountBalance(snapshot, tx.Creator, balance+payload.Amount)
 		WriteAccountStake(snapshot, tx.Creator, stake-payload.Amount)
+		queueUnbonding(snapshot, tx.Creator, round, payload.Amount)
 	case sys.WithdrawReward:
 		if payload.Amount < sys.MinimumRewardWithdraw {
 			return errors.Errorf("stake: %x attempt to withdraw rewards amounting to %d PERLs, but system requires the minimum amount to withdraw to be %d PERLs", tx.Creator, payload.Amount, sys.MinimumRewardWithdraw)
@@ -168,11 +191,62 @@ func applyStakeTransaction(snapshot *avl.Tree, round *Round, tx *Transaction) er
 			amount:  payload.Amount,
 			round:   round.Index,
 		})
+	case sys.DelegateStake:
+		if payload.Validator == tx.Creator {
+			return errors.Errorf("stake: %x attempt to delegate stake to itself", tx.Creator)
+		}
+
+		if _, isContract := ReadAccountContractCode(snapshot, payload.Validator); isContract {
+			return errors.Errorf("stake: %x attempt to delegate stake to smart contract %x", tx.Creator, payload.Validator)
+		}
+
+		if balance < payload.Amount {
+			return errors.Errorf("stake: %x attempt to delegate a stake of %d PERLs, but only has %d PERLs", tx.Creator, payload.Amount, balance)
+		}
+
+		delegation, _ := ReadAccountDelegation(snapshot, tx.Creator, payload.Validator)
+		delegated, _ := ReadAccountDelegatedStake(snapshot, payload.Validator)
+
+		WriteAccountBalance(snapshot, tx.Creator, balance-payload.Amount)
+		WriteAccountDelegation(snapshot, tx.Creator, payload.Validator, delegation+payload.Amount)
+		WriteAccountDelegatedStake(snapshot, payload.Validator, delegated+payload.Amount)
+	case sys.UndelegateStake:
+		delegation, _ := ReadAccountDelegation(snapshot, tx.Creator, payload.Validator)
+
+		if delegation < payload.Amount {
+			return errors.Errorf("stake: %x attempt to undelegate a stake of %d PERLs from %x, but only has delegated %d PERLs", tx.Creator, payload.Amount, payload.Validator, delegation)
+		}
+
+		delegated, _ := ReadAccountDelegatedStake(snapshot, payload.Validator)
+
+		// The validator may have been deleted since the stake was delegated, in which case its
+		// delegated stake no longer accounts for the delegation.
+		if delegated < payload.Amount {
+			delegated = payload.Amount
+		}
+
+		WriteAccountDelegation(snapshot, tx.Creator, payload.Validator, delegation-payload.Amount)
+		WriteAccountDelegatedStake(snapshot, payload.Validator, delegated-payload.Amount)
+		queueUnbonding(snapshot, tx.Creator, round, payload.Amount)
+	case sys.SetCommission:
+		WriteAccountCommission(snapshot, tx.Creator, payload.Amount)
 	}
 
 	return nil
 }
 
+// queueUnbonding adds an amount of stake withdrawn by an account at a round to the unbonding request
+// the account has made at the round, such that it is released after sys.StakeUnbondingRounds rounds.
+func queueUnbonding(snapshot *avl.Tree, id AccountID, round *Round, amount uint64) {
+	pending, _ := ReadAccountUnbonding(snapshot, id, round.Index)
+
+	StoreUnbondingRequest(snapshot, UnbondingRequest{
+		Account: id,
+		Amount:  pending + amount,
+		Round:   round.Index,
+	})
+}
+
 func applyContractTransaction(snapshot *avl.Tree, round *Round, tx *Transaction, state *contractExecutorState) error {
 	payload, err := ParseContract(tx.Payload)
 	if err != nil {
@@ -184,11 +258,11 @@ func applyContractTransaction(snapshot *avl.Tree, round *Round, tx *Transaction,
 	}
 
 	// Record the code of the smart contract into the ledgers state.
-	if err := wasm.GetValidator().ValidateWasm(payload.Code); err != nil {
-		return errors.Wrap(err, "invalid wasm")
+	if err := writeContractCode(snapshot, tx.ID, payload.Code); err != nil {
+		return err
 	}
 
-	WriteAccountContractCode(snapshot, tx.ID, payload.Code)
+	WriteAccountContractOwner(snapshot, tx.ID, tx.Creator)
 
 	if payload.GasDeposit != 0 {
 		err = transferValue(
@@ -230,6 +304,171 @@ func applyBatchTransaction(snapshot *avl.Tree, round *Round, tx *Transaction, st
 	return nil
 }
 
+// applyContractLifecycleTransaction has the owner of a smart contract, being the creator of the transaction
+// that spawned it, either upgrade the smart contracts code while keeping its storage intact, or destruct the
+// smart contract by refunding its balance and gas balance to a beneficiary and deleting its account.
+func applyContractLifecycleTransaction(snapshot *avl.Tree, tx *Transaction) error {
+	payload, err := ParseContractLifecycle(tx.Payload)
+	if err != nil {
+		return err
+	}
+
+	if _, exists := ReadAccountContractCode(snapshot, payload.Contract); !exists {
+		return errors.Wrapf(ErrNotSmartContract, "%x", payload.Contract)
+	}
+
+	owner, exists := ReadAccountContractOwner(snapshot, payload.Contract)
+	if !exists || owner != tx.Creator {
+		return errors.Errorf("contract_lifecycle: %x is not the owner of smart contract %x", tx.Creator, payload.Contract)
+	}
+
+	switch payload.Opcode {
+	case sys.UpgradeContract:
+		if err := writeContractCode(snapshot, payload.Contract, payload.Code); err != nil {
+			return err
+		}
+	case sys.DestructContract:
+		if holdsStake(snapshot, payload.Contract) {
+			return errors.Errorf("contract_lifecycle: smart contract %x may not be destructed while it has stake, rewards, delegations, or pending withdrawals", payload.Contract)
+		}
+
+		balance, _ := ReadAccountBalance(snapshot, payload.Contract)
+		gasBalance, _ := ReadAccountContractGasBalance(snapshot, payload.Contract)
+
+		beneficiaryBalance, _ := ReadAccountBalance(snapshot, payload.Beneficiary)
+
+		if beneficiaryBalance+balance < beneficiaryBalance || beneficiaryBalance+balance+gasBalance < beneficiaryBalance+balance {
+			return errors.Errorf("contract_lifecycle: refunding smart contract %x overflows the balance of beneficiary %x", payload.Contract, payload.Beneficiary)
+		}
+
+		DeleteAccount(snapshot, payload.Contract)
+		WriteAccountBalance(snapshot, payload.Beneficiary, beneficiaryBalance+balance+gasBalance)
+	}
+
+	return nil
+}
+
+// holdsStake returns true should an account have placed stake, been rewarded, delegated stake, or have
+// stake or rewards pending withdrawal, none of which are refunded should the account be deleted.
+func holdsStake(snapshot *avl.Tree, id AccountID) bool {
+	stake, _ := ReadAccountStake(snapshot, id)
+	reward, _ := ReadAccountReward(snapshot, id)
+
+	if stake != 0 || reward != 0 {
+		return true
+	}
+
+	if len(ReadDelegatorDelegations(snapshot, id)) != 0 || len(ReadAccountUnbondingRequests(snapshot, id)) != 0 {
+		return true
+	}
+
+	for _, rw := range GetRewardWithdrawalRequests(snapshot, math.MaxUint64) {
+		if rw.account == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applyEvidenceTransaction slashes a percentage of the stake of a validator that signed two conflicting
+// rounds as finalized for the same round index, including stake delegated to it and stake it has withdrawn
+// that is yet to be unbonded. Evidence must be submitted within sys.StakeUnbondingRounds rounds of the
+// offence, after which the validator may have withdrawn its stake. A validator is only ever punished once
+// for equivocating at a given round index, and the offence is recorded alongside the amount slashed.
+func applyEvidenceTransaction(snapshot *avl.Tree, round *Round, tx *Transaction) error {
+	payload, err := ParseEvidence(tx.Payload)
+	if err != nil {
+		return err
+	}
+
+	if payload.RoundIndex+sys.StakeUnbondingRounds < round.Index {
+		return errors.Errorf("evidence: offence at round %d is older than %d rounds", payload.RoundIndex, sys.StakeUnbondingRounds)
+	}
+
+	if !edwards25519.Verify(payload.Validator, QueryResponseSignaturePayload(payload.RoundIndex, payload.RoundA), payload.SignatureA) {
+		return errors.Errorf("evidence: signature over round %x was not signed by %x", payload.RoundA, payload.Validator)
+	}
+
+	if !edwards25519.Verify(payload.Validator, QueryResponseSignaturePayload(payload.RoundIndex, payload.RoundB), payload.SignatureB) {
+		return errors.Errorf("evidence: signature over round %x was not signed by %x", payload.RoundB, payload.Validator)
+	}
+
+	if _, punished := ReadAccountOffence(snapshot, payload.Validator, payload.RoundIndex); punished {
+		return errors.Errorf("evidence: %x was already punished for equivocating at round %d", payload.Validator, payload.RoundIndex)
+	}
+
+	unbonding := ReadAccountUnbondingRequests(snapshot, payload.Validator)
+
+	if ReadAccountTotalStake(snapshot, payload.Validator) == 0 && len(unbonding) == 0 {
+		return errors.Errorf("evidence: %x has no stake to be slashed", payload.Validator)
+	}
+
+	stake, _ := ReadAccountStake(snapshot, payload.Validator)
+	slashed := mulDiv(stake, sys.SlashPercentage, 100)
+
+	WriteAccountStake(snapshot, payload.Validator, stake-slashed)
+
+	for _, ur := range unbonding {
+		amount := mulDiv(ur.Amount, sys.SlashPercentage, 100)
+
+		ur.Amount -= amount
+		StoreUnbondingRequest(snapshot, ur)
+
+		slashed += amount
+	}
+
+	// Delegators share in the punishment of the validator they delegated to, in proportion to the
+	// stake they have delegated.
+
+	delegated, _ := ReadAccountDelegatedStake(snapshot, payload.Validator)
+
+	for _, delegation := range ReadValidatorDelegations(snapshot, payload.Validator) {
+		amount := mulDiv(delegation.Amount, sys.SlashPercentage, 100)
+
+		if amount > delegated {
+			amount = delegated
+		}
+
+		WriteAccountDelegation(snapshot, delegation.Delegator, payload.Validator, delegation.Amount-amount)
+		delegated -= amount
+
+		slashed += amount
+	}
+
+	WriteAccountDelegatedStake(snapshot, payload.Validator, delegated)
+	WriteAccountOffence(snapshot, payload.Validator, payload.RoundIndex, slashed)
+
+	return nil
+}
+
+// writeContractCode validates and records the code of a smart contract, alongside the ABI
+// shipped within its code. Any ABI recorded for a previous version of the code is dropped.
+func writeContractCode(snapshot *avl.Tree, id AccountID, code []byte) error {
+	if err := wasm.GetValidator().ValidateWasm(code); err != nil {
+		return errors.Wrap(err, "invalid wasm")
+	}
+
+	abi, err := ReadContractABISection(code)
+	if err != nil {
+		return err
+	}
+
+	if abi != nil {
+		if _, err := ParseContractABI(abi); err != nil {
+			return err
+		}
+
+		WriteAccountContractABI(snapshot, id, abi)
+	} else {
+		DeleteAccountContractABI(snapshot, id)
+	}
+
+	WriteAccountContractCode(snapshot, id, code)
+
+	return nil
+}
+
 // Transfers value of any form (balance, gasDeposit/gasBalance).
 func transferValue(
 	unitName string,
@@ -307,7 +546,9 @@ func executeContractInTransactionContext(
 		logger.Fatal().Msg("BUG: state.GasLimit < realGasLimit")
 	}
 
-	if executor.GasLimitExceeded || invocationErr != nil { // Revert changes and have the gas payer pay gas fees.
+	failed := executor.GasLimitExceeded || executor.failed || len(executor.Error) != 0
+
+	if failed || invocationErr != nil { // Revert changes and have the gas payer pay gas fees.
 		snapshot.Revert(snapshotBeforeExec)
 		if executor.Gas > contractGasBalance {
 			WriteAccountContractGasBalance(snapshot, contractID, 0)
@@ -319,9 +560,16 @@ func executeContractInTransactionContext(
 			WriteAccountContractGasBalance(snapshot, contractID, contractGasBalance-executor.Gas)
 		}
 		state.GasLimit -= executor.Gas
+		state.GasConsumed += executor.Gas
 
 		if invocationErr != nil {
 			logger.Info().Err(invocationErr).Msg("failed to invoke smart contract")
+		} else if !executor.GasLimitExceeded {
+			logger.Info().
+				Hex("sender_id", tx.Creator[:]).
+				Hex("contract_id", contractID[:]).
+				Bytes("error", executor.Error).
+				Msg("Smart contract function failed, reverting its changes.")
 		} else {
 			logger.Info().
 				Hex("sender_id", tx.Creator[:]).
@@ -341,6 +589,7 @@ func executeContractInTransactionContext(
 			WriteAccountContractGasBalance(snapshot, contractID, contractGasBalance-executor.Gas)
 		}
 		state.GasLimit -= executor.Gas
+		state.GasConsumed += executor.Gas
 
 		logger.Info().
 			Hex("sender_id", tx.Creator[:]).
@@ -349,6 +598,8 @@ func executeContractInTransactionContext(
 			Uint64("gas_limit", realGasLimit).
 			Msg("Deducted PERLs for invoking smart contract function.")
 
+		state.Events = append(state.Events, executor.Events...)
+
 		for _, entry := range executor.Queue {
 			err := applyTransaction(round, snapshot, entry, state)
 			if err != nil {
@@ -24,6 +24,8 @@ import (
 	"math/rand"
 	"testing"
 
+	"github.com/perlin-network/life/exec"
+	"github.com/perlin-network/noise/edwards25519"
 	"github.com/perlin-network/noise/skademlia"
 	"github.com/perlin-network/wavelet/avl"
 	"github.com/perlin-network/wavelet/store"
@@ -33,6 +35,7 @@ import (
 
 type testAccount struct {
 	keys   *skademlia.Keypair
+	nonce  uint64
 	effect struct {
 		Balance uint64
 		Stake   uint64
@@ -59,7 +62,7 @@ func TestApplyTransaction_Single(t *testing.T) {
 			keys: keys,
 		}
 		if i == 0 {
-			initialRoot = AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil))
+			initialRoot = AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil))
 		}
 		WriteAccountBalance(state, keys.PublicKey(), InitialBalance)
 		account.effect.Balance = InitialBalance
@@ -80,7 +83,7 @@ func TestApplyTransaction_Single(t *testing.T) {
 			account.effect.Stake += amount
 			account.effect.Balance -= amount
 
-			tx := AttachSenderToTransaction(account.keys, NewTransaction(account.keys, sys.TagStake, buildPlaceStakePayload(amount).Marshal()))
+			tx := AttachSenderToTransaction(account.keys, NewTransaction(account.keys, 0, sys.TagStake, buildPlaceStakePayload(amount).Marshal()))
 			err := ApplyTransaction(&round, state, &tx)
 			assert.NoError(t, err)
 		case 1:
@@ -96,7 +99,7 @@ func TestApplyTransaction_Single(t *testing.T) {
 			fromAccount.effect.Balance -= amount
 			toAccount.effect.Balance += amount
 
-			tx := AttachSenderToTransaction(fromAccount.keys, NewTransaction(fromAccount.keys, sys.TagTransfer, buildTransferPayload(toAccountID, amount).Marshal()))
+			tx := AttachSenderToTransaction(fromAccount.keys, NewTransaction(fromAccount.keys, 0, sys.TagTransfer, buildTransferPayload(toAccountID, amount).Marshal()))
 			err := ApplyTransaction(&round, state, &tx)
 			assert.NoError(t, err)
 		default:
@@ -137,7 +140,7 @@ func TestApplyTransaction_Collapse(t *testing.T) {
 			keys: keys,
 		}
 		if i == 0 {
-			initialRoot = AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil))
+			initialRoot = AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil))
 			graph = NewGraph(WithRoot(initialRoot))
 		}
 		WriteAccountBalance(state, keys.PublicKey(), InitialBalance)
@@ -162,12 +165,21 @@ func TestApplyTransaction_Collapse(t *testing.T) {
 		account := accounts[accountIDs[rng.Intn(len(accountIDs))]]
 		account.effect.Stake += amount
 
-		tx := AttachSenderToTransaction(account.keys, NewTransaction(account.keys, sys.TagStake, buildPlaceStakePayload(amount).Marshal()), graph.FindEligibleParents()...)
+		tx := AttachSenderToTransaction(account.keys, NewTransaction(account.keys, account.nonce, sys.TagStake, buildPlaceStakePayload(amount).Marshal()), graph.FindEligibleParents()...)
 		assert.NoError(t, graph.AddTransaction(tx))
 
+		account.nonce++
+
 		if tx.IsCritical(4) {
 			results, err := collapseTransactions(graph, accountState, viewID+1, &round, round.End, tx, false)
 			assert.NoError(t, err)
+
+			assert.Len(t, results.receipts, results.appliedCount+results.rejectedCount)
+			for _, receipt := range results.receipts {
+				assert.Equal(t, viewID+1, receipt.Round)
+				assert.Equal(t, ReceiptStatusApplied, receipt.Status)
+			}
+
 			err = accountState.Commit(results.snapshot)
 			assert.NoError(t, err)
 			state = results.snapshot
@@ -183,6 +195,50 @@ func TestApplyTransaction_Collapse(t *testing.T) {
 	}
 }
 
+func TestApplyCreatorNonce(t *testing.T) {
+	t.Parallel()
+
+	state := avl.New(store.NewInmem())
+	alice, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+	bob, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	aliceID := alice.PublicKey()
+
+	// Case 1 - First transaction of a new account
+	tx := AttachSenderToTransaction(alice, NewTransaction(alice, 0, sys.TagNop, nil))
+	assert.NoError(t, applyCreatorNonce(state, &tx))
+
+	nonce, _ := ReadAccountNonce(state, aliceID)
+	assert.Equal(t, uint64(1), nonce)
+	assert.Equal(t, uint64(1), ReadAccountsLen(state))
+
+	// Case 2 - Replaying the same transaction re-wrapped by a different sender
+	replayed := AttachSenderToTransaction(bob, tx)
+	assert.NotEqual(t, tx.ID, replayed.ID)
+	assert.Error(t, applyCreatorNonce(state, &replayed))
+
+	// Case 3 - Skipping ahead to a higher nonce
+	tx = AttachSenderToTransaction(alice, NewTransaction(alice, 5, sys.TagNop, nil))
+	assert.Error(t, applyCreatorNonce(state, &tx))
+
+	nonce, _ = ReadAccountNonce(state, aliceID)
+	assert.Equal(t, uint64(1), nonce)
+
+	// Case 4 - Next transaction signed with the account nonce
+	tx = AttachSenderToTransaction(alice, NewTransaction(alice, 1, sys.TagNop, nil))
+	assert.NoError(t, applyCreatorNonce(state, &tx))
+
+	nonce, _ = ReadAccountNonce(state, aliceID)
+	assert.Equal(t, uint64(2), nonce)
+	assert.Equal(t, uint64(1), ReadAccountsLen(state))
+
+	// Case 5 - Nonce lower than the account nonce
+	tx = AttachSenderToTransaction(alice, NewTransaction(alice, 0, sys.TagNop, nil))
+	assert.Error(t, applyCreatorNonce(state, &tx))
+}
+
 func TestApplyTransferTransaction(t *testing.T) {
 	t.Parallel()
 
@@ -199,17 +255,17 @@ func TestApplyTransferTransaction(t *testing.T) {
 	// Case 1 - Success
 	WriteAccountBalance(state, aliceID, 1)
 
-	tx := AttachSenderToTransaction(alice, NewTransaction(alice, sys.TagTransfer, buildTransferPayload(bobID, 1).Marshal()))
+	tx := AttachSenderToTransaction(alice, NewTransaction(alice, 0, sys.TagTransfer, buildTransferPayload(bobID, 1).Marshal()))
 	err = ApplyTransaction(&round, state, &tx)
 	assert.NoError(t, err)
 
 	// Case 2 - Not enough balance
-	tx = AttachSenderToTransaction(alice, NewTransaction(alice, sys.TagTransfer, buildTransferPayload(bobID, 1).Marshal()))
+	tx = AttachSenderToTransaction(alice, NewTransaction(alice, 0, sys.TagTransfer, buildTransferPayload(bobID, 1).Marshal()))
 	err = ApplyTransaction(&round, state, &tx)
 	assert.Error(t, err)
 
 	// Case 3 - Self-transfer without enough balance
-	tx = AttachSenderToTransaction(alice, NewTransaction(alice, sys.TagTransfer, buildTransferPayload(aliceID, 1).Marshal()))
+	tx = AttachSenderToTransaction(alice, NewTransaction(alice, 0, sys.TagTransfer, buildTransferPayload(aliceID, 1).Marshal()))
 	err = ApplyTransaction(&round, state, &tx)
 	assert.Error(t, err)
 }
@@ -227,22 +283,255 @@ func TestApplyStakeTransaction(t *testing.T) {
 	// Case 1 - Placement success
 	WriteAccountBalance(state, accountID, 100)
 
-	tx := AttachSenderToTransaction(account, NewTransaction(account, sys.TagStake, buildPlaceStakePayload(100).Marshal()))
+	tx := AttachSenderToTransaction(account, NewTransaction(account, 0, sys.TagStake, buildPlaceStakePayload(100).Marshal()))
 	err = ApplyTransaction(&round, state, &tx)
 	assert.NoError(t, err)
 
 	// Case 2 - Not enough balance
-	tx = AttachSenderToTransaction(account, NewTransaction(account, sys.TagStake, buildPlaceStakePayload(100).Marshal()))
+	tx = AttachSenderToTransaction(account, NewTransaction(account, 0, sys.TagStake, buildPlaceStakePayload(100).Marshal()))
 	err = ApplyTransaction(&round, state, &tx)
 	assert.Error(t, err)
 
 	// Case 3 - Withdrawal success
-	tx = AttachSenderToTransaction(account, NewTransaction(account, sys.TagStake, buildWithdrawStakePayload(100).Marshal()))
+	tx = AttachSenderToTransaction(account, NewTransaction(account, 0, sys.TagStake, buildWithdrawStakePayload(60).Marshal()))
 	err = ApplyTransaction(&round, state, &tx)
 	assert.NoError(t, err)
 
+	tx = AttachSenderToTransaction(account, NewTransaction(account, 0, sys.TagStake, buildWithdrawStakePayload(40).Marshal()))
+	err = ApplyTransaction(&round, state, &tx)
+	assert.NoError(t, err)
+
+	// Case 4 - Withdrawn stake is unbonding, and is not yet released into the balance
+	balance, _ := ReadAccountBalance(state, accountID)
+	assert.Equal(t, balance, uint64(0))
+
+	assert.Equal(t, []UnbondingRequest{{Account: accountID, Amount: 100, Round: round.Index}}, ReadAccountUnbondingRequests(state, accountID))
+
+	// Case 5 - Withdrawn stake is released after the unbonding period
+	processUnbondingRequests(round.Index+sys.StakeUnbondingRounds-1, state)
+
+	balance, _ = ReadAccountBalance(state, accountID)
+	assert.Equal(t, balance, uint64(0))
+
+	processUnbondingRequests(round.Index+sys.StakeUnbondingRounds, state)
+
 	finalBalance, _ := ReadAccountBalance(state, accountID)
 	assert.Equal(t, finalBalance, uint64(100))
+	assert.Empty(t, ReadAccountUnbondingRequests(state, accountID))
+	assert.Empty(t, GetUnbondingRequests(state, round.Index))
+}
+
+func TestApplyDelegateStakeTransaction(t *testing.T) {
+	t.Parallel()
+
+	state := avl.New(store.NewInmem())
+	round := NewRound(0, state.Checksum(), 0, Transaction{}, Transaction{})
+
+	delegator, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	validator, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	delegatorID, validatorID := delegator.PublicKey(), validator.PublicKey()
+
+	WriteAccountBalance(state, delegatorID, 100)
+
+	apply := func(keys *skademlia.Keypair, payload Stake) error {
+		tx := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagStake, payload.Marshal()))
+		return ApplyTransaction(&round, state, &tx)
+	}
+
+	// Case 1 - Delegating stake to oneself
+	assert.Error(t, apply(delegator, Stake{Opcode: sys.DelegateStake, Amount: 10, Validator: delegatorID}))
+
+	// Case 2 - Not enough balance
+	assert.Error(t, apply(delegator, Stake{Opcode: sys.DelegateStake, Amount: 101, Validator: validatorID}))
+
+	// Case 3 - Delegation success
+	assert.NoError(t, apply(delegator, Stake{Opcode: sys.DelegateStake, Amount: 60, Validator: validatorID}))
+
+	balance, _ := ReadAccountBalance(state, delegatorID)
+	assert.Equal(t, uint64(40), balance)
+
+	delegated, _ := ReadAccountDelegatedStake(state, validatorID)
+	assert.Equal(t, uint64(60), delegated)
+	assert.Equal(t, uint64(60), ReadAccountTotalStake(state, validatorID))
+
+	// Case 4 - Undelegating more than was delegated
+	assert.Error(t, apply(delegator, Stake{Opcode: sys.UndelegateStake, Amount: 61, Validator: validatorID}))
+
+	// Case 5 - Undelegation success, with the undelegated stake unbonding before being released
+	assert.NoError(t, apply(delegator, Stake{Opcode: sys.UndelegateStake, Amount: 60, Validator: validatorID}))
+
+	balance, _ = ReadAccountBalance(state, delegatorID)
+	assert.Equal(t, uint64(40), balance)
+
+	unbonding, _ := ReadAccountUnbonding(state, delegatorID, round.Index)
+	assert.Equal(t, uint64(60), unbonding)
+
+	delegated, _ = ReadAccountDelegatedStake(state, validatorID)
+	assert.Equal(t, uint64(0), delegated)
+	assert.Empty(t, ReadValidatorDelegations(state, validatorID))
+
+	// Case 6 - Setting a commission
+	assert.NoError(t, apply(validator, Stake{Opcode: sys.SetCommission, Amount: 500}))
+
+	commission, _ := ReadAccountCommission(state, validatorID)
+	assert.Equal(t, uint64(500), commission)
+
+	// Case 7 - Delegating stake to a smart contract
+	var contractID AccountID
+	contractID[0] = 1
+
+	WriteAccountContractCode(state, contractID, []byte("code"))
+	assert.Error(t, apply(delegator, Stake{Opcode: sys.DelegateStake, Amount: 10, Validator: contractID}))
+
+	// Case 8 - Undelegating from a validator whose delegated stake was deleted
+	assert.NoError(t, apply(delegator, Stake{Opcode: sys.DelegateStake, Amount: 30, Validator: validatorID}))
+	DeleteAccount(state, validatorID)
+
+	assert.NoError(t, apply(delegator, Stake{Opcode: sys.UndelegateStake, Amount: 30, Validator: validatorID}))
+	assert.Equal(t, uint64(0), ReadAccountTotalStake(state, validatorID))
+
+	unbonding, _ = ReadAccountUnbonding(state, delegatorID, round.Index)
+	assert.Equal(t, uint64(90), unbonding)
+}
+
+func TestDistributeReward(t *testing.T) {
+	t.Parallel()
+
+	state := avl.New(store.NewInmem())
+
+	validator := AccountID{1}
+	delegatorA := AccountID{2}
+	delegatorB := AccountID{3}
+
+	// The validator has staked 100 PERLs, and has been delegated 300 PERLs.
+	WriteAccountStake(state, validator, 100)
+	WriteAccountDelegation(state, delegatorA, validator, 100)
+	WriteAccountDelegation(state, delegatorB, validator, 200)
+	WriteAccountDelegatedStake(state, validator, 300)
+
+	// Case 1 - Delegators are rewarded pro rata to their delegated stake.
+	distributeReward(state, validator, 1000)
+
+	reward, _ := ReadAccountReward(state, validator)
+	assert.Equal(t, uint64(250), reward)
+
+	reward, _ = ReadAccountReward(state, delegatorA)
+	assert.Equal(t, uint64(250), reward)
+
+	reward, _ = ReadAccountReward(state, delegatorB)
+	assert.Equal(t, uint64(500), reward)
+
+	// Case 2 - The validator takes a 10% commission from the share of the delegators.
+	WriteAccountCommission(state, validator, 1000)
+
+	distributeReward(state, validator, 1000)
+
+	reward, _ = ReadAccountReward(state, validator)
+	assert.Equal(t, uint64(250+325), reward)
+
+	reward, _ = ReadAccountReward(state, delegatorA)
+	assert.Equal(t, uint64(250+225), reward)
+
+	reward, _ = ReadAccountReward(state, delegatorB)
+	assert.Equal(t, uint64(500+450), reward)
+}
+
+func TestApplyEvidenceTransaction(t *testing.T) {
+	t.Parallel()
+
+	state := avl.New(store.NewInmem())
+	round := NewRound(0, state.Checksum(), 0, Transaction{}, Transaction{})
+
+	validator, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	reporter, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	validatorID := validator.PublicKey()
+
+	var delegatorID AccountID
+	delegatorID[0] = 1
+
+	WriteAccountStake(state, validatorID, 1000)
+	WriteAccountDelegation(state, delegatorID, validatorID, 200)
+	WriteAccountDelegatedStake(state, validatorID, 200)
+	StoreUnbondingRequest(state, UnbondingRequest{Account: validatorID, Amount: 500, Round: 3})
+
+	sign := func(index uint64, id RoundID) Signature {
+		return edwards25519.Sign(validator.PrivateKey(), QueryResponseSignaturePayload(index, id))
+	}
+
+	apply := func(evidence Evidence) error {
+		tx := AttachSenderToTransaction(reporter, NewTransaction(reporter, 0, sys.TagEvidence, evidence.Marshal()))
+		return ApplyTransaction(&round, state, &tx)
+	}
+
+	evidence := Evidence{
+		Validator:  validatorID,
+		RoundIndex: 10,
+		RoundA:     RoundID{1},
+		SignatureA: sign(10, RoundID{1}),
+		RoundB:     RoundID{2},
+		SignatureB: sign(10, RoundID{2}),
+	}
+
+	// Case 1 - Signatures over rounds of different indices do not conflict
+	invalid := evidence
+	invalid.SignatureB = sign(11, RoundID{2})
+	assert.Error(t, apply(invalid))
+
+	// Case 2 - Signatures not made by the validator
+	invalid = evidence
+	invalid.SignatureA = edwards25519.Sign(reporter.PrivateKey(), QueryResponseSignaturePayload(10, RoundID{1}))
+	assert.Error(t, apply(invalid))
+
+	// Signatures over rounds the validator merely preferred are not evidence either, as honest
+	// validators may change their preference.
+	invalid = evidence
+	invalid.SignatureA = edwards25519.Sign(validator.PrivateKey(), PreferenceSignaturePayload(10, RoundID{1}))
+	assert.Error(t, apply(invalid))
+
+	stake, _ := ReadAccountStake(state, validatorID)
+	assert.Equal(t, uint64(1000), stake)
+
+	// Case 3 - Evidence older than the unbonding period
+	later := NewRound(10+sys.StakeUnbondingRounds+1, state.Checksum(), 0, Transaction{}, Transaction{})
+
+	tx := AttachSenderToTransaction(reporter, NewTransaction(reporter, 0, sys.TagEvidence, evidence.Marshal()))
+	assert.Error(t, ApplyTransaction(&later, state, &tx))
+
+	// Case 4 - Accounts without stake may not be punished
+	unstaked := evidence
+	unstaked.Validator = reporter.PublicKey()
+	unstaked.SignatureA = edwards25519.Sign(reporter.PrivateKey(), QueryResponseSignaturePayload(10, RoundID{1}))
+	unstaked.SignatureB = edwards25519.Sign(reporter.PrivateKey(), QueryResponseSignaturePayload(10, RoundID{2}))
+	assert.Error(t, apply(unstaked))
+
+	// Case 5 - Validator is slashed, including stake delegated to it and stake it has yet to unbond
+	assert.NoError(t, apply(evidence))
+
+	stake, _ = ReadAccountStake(state, validatorID)
+	assert.Equal(t, uint64(900), stake)
+
+	unbonding, _ := ReadAccountUnbonding(state, validatorID, 3)
+	assert.Equal(t, uint64(450), unbonding)
+
+	delegation, _ := ReadAccountDelegation(state, delegatorID, validatorID)
+	assert.Equal(t, uint64(180), delegation)
+	assert.Equal(t, uint64(900+180), ReadAccountTotalStake(state, validatorID))
+
+	assert.Equal(t, []Offence{{Validator: validatorID, RoundIndex: 10, Slashed: 170}}, ReadAccountOffences(state, validatorID))
+
+	// Case 6 - Validator may not be punished twice for the same offence
+	assert.Error(t, apply(evidence))
+
+	stake, _ = ReadAccountStake(state, validatorID)
+	assert.Equal(t, uint64(900), stake)
 }
 
 func TestApplyBatchTransaction(t *testing.T) {
@@ -258,24 +547,26 @@ func TestApplyBatchTransaction(t *testing.T) {
 	aliceID := alice.PublicKey()
 	bobID := bob.PublicKey()
 
-	WriteAccountBalance(state, aliceID, 100)
-
-	// initial stake
-	tx := AttachSenderToTransaction(alice, NewTransaction(alice, sys.TagStake, buildPlaceStakePayload(100).Marshal()))
-	err = ApplyTransaction(&round, state, &tx)
-	assert.NoError(t, err)
+	WriteAccountBalance(state, aliceID, 200)
 
 	// this implies order
 	var batch Batch
-	batch.AddStake(buildWithdrawStakePayload(100))
+	batch.AddStake(buildPlaceStakePayload(100))
 	batch.AddTransfer(buildTransferPayload(bobID, 100))
+	batch.AddStake(buildWithdrawStakePayload(100))
 
-	tx = AttachSenderToTransaction(alice, NewTransaction(alice, sys.TagBatch, batch.Marshal()))
+	tx := AttachSenderToTransaction(alice, NewTransaction(alice, 0, sys.TagBatch, batch.Marshal()))
 	err = ApplyTransaction(&round, state, &tx)
 	assert.NoError(t, err)
 
 	finalBobBalance, _ := ReadAccountBalance(state, bobID)
 	assert.Equal(t, finalBobBalance, uint64(100))
+
+	finalAliceBalance, _ := ReadAccountBalance(state, aliceID)
+	assert.Equal(t, finalAliceBalance, uint64(0))
+
+	unbonding, _ := ReadAccountUnbonding(state, aliceID, round.Index)
+	assert.Equal(t, unbonding, uint64(100))
 }
 
 func TestApplyContractTransaction(t *testing.T) {
@@ -293,13 +584,13 @@ func TestApplyContractTransaction(t *testing.T) {
 
 	// Case 1 - balance < gas_fee
 	WriteAccountBalance(state, accountID, 99999)
-	tx := AttachSenderToTransaction(account, NewTransaction(account, sys.TagContract, buildContractSpawnPayload(100000, 0, code).Marshal()))
+	tx := AttachSenderToTransaction(account, NewTransaction(account, 0, sys.TagContract, buildContractSpawnPayload(100000, 0, code).Marshal()))
 	err = ApplyTransaction(&round, state, &tx)
 	assert.Error(t, err)
 
 	// Case 2 - Success
 	WriteAccountBalance(state, accountID, 100000)
-	tx = AttachSenderToTransaction(account, NewTransaction(account, sys.TagContract, buildContractSpawnPayload(100000, 0, code).Marshal()))
+	tx = AttachSenderToTransaction(account, NewTransaction(account, 0, sys.TagContract, buildContractSpawnPayload(100000, 0, code).Marshal()))
 	err = ApplyTransaction(&round, state, &tx)
 	assert.NoError(t, err)
 
@@ -310,7 +601,7 @@ func TestApplyContractTransaction(t *testing.T) {
 
 	// Try to transfer some money
 	WriteAccountBalance(state, accountID, 1000000000)
-	tx = AttachSenderToTransaction(account, NewTransaction(account, sys.TagTransfer, buildTransferWithInvocationPayload(contractID, 200000000, 500000, []byte("on_money_received"), nil, 0).Marshal()))
+	tx = AttachSenderToTransaction(account, NewTransaction(account, 0, sys.TagTransfer, buildTransferWithInvocationPayload(contractID, 200000000, 500000, []byte("on_money_received"), nil, 0).Marshal()))
 	err = ApplyTransaction(&round, state, &tx)
 	assert.NoError(t, err)
 	finalBalance, _ = ReadAccountBalance(state, accountID)
@@ -319,7 +610,7 @@ func TestApplyContractTransaction(t *testing.T) {
 	// Try to invoke with contract gas balance
 	WriteAccountBalance(state, accountID, 200000000)
 	WriteAccountContractGasBalance(state, contractID, 1000000000)
-	tx = AttachSenderToTransaction(account, NewTransaction(account, sys.TagTransfer, buildTransferWithInvocationPayload(contractID, 200000000, 500000, []byte("on_money_received"), nil, 0).Marshal()))
+	tx = AttachSenderToTransaction(account, NewTransaction(account, 0, sys.TagTransfer, buildTransferWithInvocationPayload(contractID, 200000000, 500000, []byte("on_money_received"), nil, 0).Marshal()))
 	err = ApplyTransaction(&round, state, &tx)
 	assert.NoError(t, err)
 	finalBalance, _ = ReadAccountBalance(state, accountID)
@@ -331,7 +622,7 @@ func TestApplyContractTransaction(t *testing.T) {
 	WriteAccountBalance(state, accountID, 300000000)
 	WriteAccountContractGasBalance(state, contractID, 10)
 
-	tx = AttachSenderToTransaction(account, NewTransaction(account, sys.TagTransfer, buildTransferWithInvocationPayload(contractID, 200000000, 500000, []byte("on_money_received"), nil, 0).Marshal()))
+	tx = AttachSenderToTransaction(account, NewTransaction(account, 0, sys.TagTransfer, buildTransferWithInvocationPayload(contractID, 200000000, 500000, []byte("on_money_received"), nil, 0).Marshal()))
 
 	assert.NoError(t, ApplyTransaction(&round, state, &tx))
 	finalBalance, _ = ReadAccountBalance(state, accountID)
@@ -342,20 +633,20 @@ func TestApplyContractTransaction(t *testing.T) {
 	// Now it should fail
 	WriteAccountBalance(state, accountID, 200000000)
 	WriteAccountContractGasBalance(state, contractID, 0)
-	tx = AttachSenderToTransaction(account, NewTransaction(account, sys.TagTransfer, buildTransferWithInvocationPayload(contractID, 200000000, 500000, []byte("on_money_received"), nil, 0).Marshal()))
+	tx = AttachSenderToTransaction(account, NewTransaction(account, 0, sys.TagTransfer, buildTransferWithInvocationPayload(contractID, 200000000, 500000, []byte("on_money_received"), nil, 0).Marshal()))
 	assert.Error(t, ApplyTransaction(&round, state, &tx))
 
 	code, err = ioutil.ReadFile("testdata/recursive_invocation.wasm")
 	assert.NoError(t, err)
 
 	WriteAccountBalance(state, accountID, 100000000)
-	tx = AttachSenderToTransaction(account, NewTransaction(account, sys.TagContract, buildContractSpawnPayload(100000, 0, code).Marshal()))
+	tx = AttachSenderToTransaction(account, NewTransaction(account, 0, sys.TagContract, buildContractSpawnPayload(100000, 0, code).Marshal()))
 	err = ApplyTransaction(&round, state, &tx)
 	assert.NoError(t, err)
 	recursiveInvocationContractID := AccountID(tx.ID)
 
 	WriteAccountBalance(state, accountID, 6000000)
-	tx = AttachSenderToTransaction(account, NewTransaction(account, sys.TagTransfer, buildTransferWithInvocationPayload(recursiveInvocationContractID, 0, 5000000, []byte("bomb"), recursiveInvocationContractID[:], 0).Marshal()))
+	tx = AttachSenderToTransaction(account, NewTransaction(account, 0, sys.TagTransfer, buildTransferWithInvocationPayload(recursiveInvocationContractID, 0, 5000000, []byte("bomb"), recursiveInvocationContractID[:], 0).Marshal()))
 	err = ApplyTransaction(&round, state, &tx)
 	assert.NoError(t, err)
 
@@ -363,6 +654,110 @@ func TestApplyContractTransaction(t *testing.T) {
 	assert.True(t, finalBalance >= 1000000 && finalBalance < 2000000) // GasLimit specified in contract is 1000000
 }
 
+func TestApplyContractLifecycleTransaction(t *testing.T) {
+	t.Parallel()
+
+	state := avl.New(store.NewInmem())
+	round := NewRound(0, state.Checksum(), 0, Transaction{}, Transaction{})
+
+	owner, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	other, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	code, err := ioutil.ReadFile("testdata/transfer_back.wasm")
+	assert.NoError(t, err)
+
+	upgradedCode, err := ioutil.ReadFile("testdata/recursive_invocation.wasm")
+	assert.NoError(t, err)
+
+	WriteAccountBalance(state, owner.PublicKey(), 1000000000)
+	WriteAccountBalance(state, other.PublicKey(), 1000000000)
+
+	tx := AttachSenderToTransaction(owner, NewTransaction(owner, 0, sys.TagContract, buildContractSpawnPayload(100000, 0, code).Marshal()))
+	assert.NoError(t, ApplyTransaction(&round, state, &tx))
+
+	contractID := AccountID(tx.ID)
+
+	// Case 1 - The creator of the spawn transaction owns the smart contract
+	contractOwner, exists := ReadAccountContractOwner(state, contractID)
+	assert.True(t, exists)
+	assert.Equal(t, AccountID(owner.PublicKey()), contractOwner)
+
+	WriteAccountBalance(state, contractID, 100)
+	WriteAccountContractGasBalance(state, contractID, 10)
+	WriteAccountContractStorage(state, contractID, []byte("key"), []byte("value"))
+
+	upgrade := ContractLifecycle{Opcode: sys.UpgradeContract, Contract: contractID, Code: upgradedCode}
+	destruct := ContractLifecycle{Opcode: sys.DestructContract, Contract: contractID, Beneficiary: other.PublicKey()}
+
+	// Case 2 - Only the owner may upgrade or destruct the smart contract
+	tx = AttachSenderToTransaction(other, NewTransaction(other, 0, sys.TagContractLifecycle, upgrade.Marshal()))
+	assert.Error(t, ApplyTransaction(&round, state, &tx))
+
+	tx = AttachSenderToTransaction(other, NewTransaction(other, 0, sys.TagContractLifecycle, destruct.Marshal()))
+	assert.Error(t, ApplyTransaction(&round, state, &tx))
+
+	// Case 3 - Upgrading replaces the code, but keeps the storage of the smart contract
+	tx = AttachSenderToTransaction(owner, NewTransaction(owner, 0, sys.TagContractLifecycle, (ContractLifecycle{Opcode: sys.UpgradeContract, Contract: contractID, Code: []byte("invalid")}).Marshal()))
+	assert.Error(t, ApplyTransaction(&round, state, &tx))
+
+	tx = AttachSenderToTransaction(owner, NewTransaction(owner, 0, sys.TagContractLifecycle, upgrade.Marshal()))
+	assert.NoError(t, ApplyTransaction(&round, state, &tx))
+
+	contractCode, _ := ReadAccountContractCode(state, contractID)
+	assert.Equal(t, upgradedCode, contractCode)
+
+	value, exists := ReadAccountContractStorage(state, contractID, []byte("key"))
+	assert.True(t, exists)
+	assert.Equal(t, []byte("value"), value)
+
+	// Case 4 - Smart contracts holding stake, or with stake or rewards pending withdrawal, may not be destructed
+	for _, hold := range []func(){
+		func() {
+			WriteAccountStake(state, contractID, 1)
+		},
+		func() {
+			WriteAccountReward(state, contractID, 1)
+		},
+		func() {
+			StoreUnbondingRequest(state, UnbondingRequest{Account: contractID, Amount: 1, Round: round.Index})
+		},
+		func() {
+			StoreRewardWithdrawalRequest(state, RewardWithdrawalRequest{account: contractID, amount: 1, round: round.Index})
+		},
+	} {
+		snapshot := state.Snapshot()
+		hold()
+
+		tx = AttachSenderToTransaction(owner, NewTransaction(owner, 0, sys.TagContractLifecycle, destruct.Marshal()))
+		assert.Error(t, ApplyTransaction(&round, state, &tx))
+
+		state.Revert(snapshot)
+	}
+
+	// Case 5 - Destructing refunds the beneficiary, and deletes the smart contract
+	tx = AttachSenderToTransaction(owner, NewTransaction(owner, 0, sys.TagContractLifecycle, destruct.Marshal()))
+	assert.NoError(t, ApplyTransaction(&round, state, &tx))
+
+	balance, _ := ReadAccountBalance(state, other.PublicKey())
+	assert.Equal(t, uint64(1000000000+100+10), balance)
+
+	_, exists = ReadAccountContractCode(state, contractID)
+	assert.False(t, exists)
+	_, exists = ReadAccountContractStorage(state, contractID, []byte("key"))
+	assert.False(t, exists)
+	_, exists = ReadAccountContractPage(state, contractID, 0)
+	assert.False(t, exists)
+	_, exists = ReadAccountBalance(state, contractID)
+	assert.False(t, exists)
+
+	// Case 6 - A destructed smart contract may no longer be upgraded
+	tx = AttachSenderToTransaction(owner, NewTransaction(owner, 0, sys.TagContractLifecycle, upgrade.Marshal()))
+	assert.Error(t, ApplyTransaction(&round, state, &tx))
+}
+
 func buildTransferWithInvocationPayload(dest AccountID, amount uint64, gasLimit uint64, funcName []byte, param []byte, gasDeposit uint64) Transfer {
 
 	return Transfer{
@@ -403,3 +798,669 @@ func buildWithdrawStakePayload(amount uint64) Stake {
 		Amount: amount,
 	}
 }
+
+func TestRewardValidatorsTip(t *testing.T) {
+	t.Parallel()
+
+	state := avl.New(store.NewInmem())
+	keys, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	root := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil))
+	graph := NewGraph(WithRoot(root))
+
+	tx := AttachSenderToTransaction(keys, NewTransactionWithTip(keys, 1, 10, sys.TagNop, nil), graph.FindEligibleParents()...)
+	fee := tx.Fee() + tx.Tip
+
+	// Case 1 - Creator cannot afford to pay the tip alongside the fee
+	WriteAccountBalance(state, keys.PublicKey(), fee-1)
+	assert.Error(t, rewardValidators(graph, state, &tx, false))
+
+	// Case 2 - Creator pays both the fee and the tip
+	WriteAccountBalance(state, keys.PublicKey(), fee)
+	assert.NoError(t, rewardValidators(graph, state, &tx, false))
+
+	balance, _ := ReadAccountBalance(state, keys.PublicKey())
+	assert.Equal(t, uint64(0), balance)
+}
+
+func TestSimulateTransaction(t *testing.T) {
+	t.Parallel()
+
+	state := avl.New(store.NewInmem())
+	round := NewRound(0, state.Checksum(), 0, Transaction{}, Transaction{})
+	keys, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	root := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil))
+	graph := NewGraph(WithRoot(root))
+
+	creator := keys.PublicKey()
+	recipient := AccountID{1}
+
+	WriteAccountBalance(state, creator, 1000)
+
+	// Case 1 - Transfer is applied, with the creator paying for the transfer alongside its fee.
+	tx := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagTransfer, buildTransferPayload(recipient, 100).Marshal()), graph.FindEligibleParents()...)
+
+	res := SimulateTransaction(graph, state.Snapshot(), &round, &tx)
+	assert.True(t, res.Applied)
+	assert.NoError(t, res.Error)
+	assert.Equal(t, tx.Fee(), res.Fee)
+	assert.Equal(t, uint64(0), res.Gas)
+	assert.ElementsMatch(t, []BalanceDelta{
+		{Account: creator, Before: 1000, After: 1000 - 100 - tx.Fee()},
+		{Account: recipient, Before: 0, After: 100},
+	}, res.Deltas)
+
+	// The ledger state simulated against must not be modified.
+	balance, _ := ReadAccountBalance(state, creator)
+	assert.Equal(t, uint64(1000), balance)
+
+	nonce, _ := ReadAccountNonce(state, creator)
+	assert.Equal(t, uint64(0), nonce)
+
+	// Case 2 - Transfer is rejected, though the creator still pays its fee.
+	tx = AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagTransfer, buildTransferPayload(recipient, 10000).Marshal()), graph.FindEligibleParents()...)
+
+	res = SimulateTransaction(graph, state.Snapshot(), &round, &tx)
+	assert.False(t, res.Applied)
+	assert.Error(t, res.Error)
+	assert.Equal(t, []BalanceDelta{{Account: creator, Before: 1000, After: 1000 - tx.Fee()}}, res.Deltas)
+
+	// Case 3 - Spawning a smart contract reports the gas its initialization expends.
+	code, err := ioutil.ReadFile("testdata/transfer_back.wasm")
+	assert.NoError(t, err)
+
+	WriteAccountBalance(state, creator, 1000000)
+	tx = AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagContract, buildContractSpawnPayload(100000, 0, code).Marshal()), graph.FindEligibleParents()...)
+
+	res = SimulateTransaction(graph, state.Snapshot(), &round, &tx)
+	assert.True(t, res.Applied)
+	assert.Equal(t, uint64(55714), res.Gas)
+	assert.Equal(t, []BalanceDelta{{Account: creator, Before: 1000000, After: 1000000 - 55714 - tx.Fee()}}, res.Deltas)
+}
+
+func TestApplyContractTransactionGas(t *testing.T) {
+	t.Parallel()
+
+	state := avl.New(store.NewInmem())
+	round := NewRound(0, state.Checksum(), 0, Transaction{}, Transaction{})
+	account, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	accountID := account.PublicKey()
+
+	code, err := ioutil.ReadFile("testdata/transfer_back.wasm")
+	assert.NoError(t, err)
+
+	schedule := sys.GasScheduleAt(round.Index)
+	assert.Equal(t, uint32(1), schedule.Version)
+
+	// Spawning the contract charges gas for its initialization.
+	WriteAccountBalance(state, accountID, 1000000)
+	tx := AttachSenderToTransaction(account, NewTransaction(account, 0, sys.TagContract, buildContractSpawnPayload(100000, 0, code).Marshal()))
+	assert.NoError(t, ApplyTransaction(&round, state, &tx))
+
+	balance, _ := ReadAccountBalance(state, accountID)
+	assert.Equal(t, uint64(1000000-55714), balance)
+
+	contractID := AccountID(tx.ID)
+
+	// Invoking the contract charges gas for its instructions and host function calls, with
+	// the contract transferring half of the amount it received back.
+	WriteAccountBalance(state, accountID, 1000000)
+	tx = AttachSenderToTransaction(account, NewTransaction(account, 1, sys.TagTransfer, buildTransferWithInvocationPayload(contractID, 1000, 500000, []byte("on_money_received"), nil, 0).Marshal()))
+	assert.NoError(t, ApplyTransaction(&round, state, &tx))
+
+	contractBalance, _ := ReadAccountBalance(state, contractID)
+	assert.Equal(t, uint64(500), contractBalance)
+
+	balance, _ = ReadAccountBalance(state, accountID)
+	assert.Equal(t, uint64(1000000-500-117189), balance)
+
+	// Running out of gas charges the entire gas limit.
+	WriteAccountBalance(state, accountID, 1000000)
+	tx = AttachSenderToTransaction(account, NewTransaction(account, 2, sys.TagTransfer, buildTransferWithInvocationPayload(contractID, 1000, 1000, []byte("on_money_received"), nil, 0).Marshal()))
+	assert.NoError(t, ApplyTransaction(&round, state, &tx))
+
+	balance, _ = ReadAccountBalance(state, accountID)
+	assert.Equal(t, uint64(1000000-1000-1000), balance)
+}
+
+func TestApplyContractTransactionFailureRevertsStorage(t *testing.T) {
+	t.Parallel()
+
+	state := avl.New(store.NewInmem())
+	round := NewRound(0, state.Checksum(), 0, Transaction{}, Transaction{})
+	account, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	accountID := account.PublicKey()
+
+	var contractID AccountID
+	contractID[0] = 1
+
+	WriteAccountContractCode(state, contractID, buildStorageFailureContract())
+	WriteAccountBalance(state, accountID, 1000000)
+
+	// Both functions write to storage before failing, respectively by trapping and by
+	// reporting an error through _result.
+
+	for nonce, funcName := range []string{"trap", "fail"} {
+		tx := AttachSenderToTransaction(account, NewTransaction(account, uint64(nonce), sys.TagTransfer, buildTransferWithInvocationPayload(contractID, 0, 100000, []byte(funcName), nil, 0).Marshal()))
+		assert.NoError(t, ApplyTransaction(&round, state, &tx))
+
+		_, exists := ReadAccountContractStorage(state, contractID, []byte{0})
+		assert.False(t, exists, funcName)
+	}
+
+	// Gas is nonetheless charged for the failed invocations.
+	balance, _ := ReadAccountBalance(state, accountID)
+	assert.True(t, balance < 1000000)
+}
+
+// buildStorageFailureContract assembles a smart contract exporting two functions which write a
+// single byte to the contracts storage, after which "trap" traps and "fail" reports an error.
+func buildStorageFailureContract() []byte {
+	section := func(id byte, contents ...byte) []byte {
+		return append([]byte{id, byte(len(contents))}, contents...)
+	}
+
+	code := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+	// Types: (i32, i32, i32, i32) -> i32, (i32, i32) -> i32, and () -> ().
+	code = append(code, section(0x01,
+		0x03,
+		0x60, 0x04, 0x7f, 0x7f, 0x7f, 0x7f, 0x01, 0x7f,
+		0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7f,
+		0x60, 0x00, 0x00,
+	)...)
+
+	// Imports: env._storage_set and env._result.
+	imports := []byte{0x02}
+	imports = append(imports, 0x03, 'e', 'n', 'v', 0x0c)
+	imports = append(imports, "_storage_set"...)
+	imports = append(imports, 0x00, 0x00)
+	imports = append(imports, 0x03, 'e', 'n', 'v', 0x07)
+	imports = append(imports, "_result"...)
+	imports = append(imports, 0x00, 0x01)
+	code = append(code, section(0x02, imports...)...)
+
+	// Functions, and a single page of memory.
+	code = append(code, section(0x03, 0x02, 0x02, 0x02)...)
+	code = append(code, section(0x05, 0x01, 0x00, 0x01)...)
+
+	// Exports: _contract_trap and _contract_fail.
+	exports := []byte{0x02, 0x0e}
+	exports = append(exports, "_contract_trap"...)
+	exports = append(exports, 0x00, 0x02, 0x0e)
+	exports = append(exports, "_contract_fail"...)
+	exports = append(exports, 0x00, 0x03)
+	code = append(code, section(0x07, exports...)...)
+
+	// Bodies: _storage_set(0, 1, 0, 1), followed by either unreachable or _result(0, 1).
+	storageSet := []byte{0x41, 0x00, 0x41, 0x01, 0x41, 0x00, 0x41, 0x01, 0x10, 0x00, 0x1a}
+
+	trap := append(append([]byte{0x00}, storageSet...), 0x00, 0x0b)
+	fail := append(append([]byte{0x00}, storageSet...), 0x41, 0x00, 0x41, 0x01, 0x10, 0x01, 0x1a, 0x0b)
+
+	bodies := []byte{0x02, byte(len(trap))}
+	bodies = append(bodies, trap...)
+	bodies = append(bodies, byte(len(fail)))
+	bodies = append(bodies, fail...)
+	code = append(code, section(0x0a, bodies...)...)
+
+	return code
+}
+
+func TestContractExecutorCallContract(t *testing.T) {
+	t.Parallel()
+
+	state := avl.New(store.NewInmem())
+	round := NewRound(0, state.Checksum(), 0, Transaction{}, Transaction{})
+	account, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	accountID := account.PublicKey()
+
+	code, err := ioutil.ReadFile("testdata/transfer_back.wasm")
+	assert.NoError(t, err)
+
+	WriteAccountBalance(state, accountID, 1000000)
+	tx := AttachSenderToTransaction(account, NewTransaction(account, 0, sys.TagContract, buildContractSpawnPayload(100000, 0, code).Marshal()))
+	assert.NoError(t, ApplyTransaction(&round, state, &tx))
+
+	contractID := AccountID(tx.ID)
+
+	var callerID AccountID
+	callerID[0] = 1
+
+	caller := &ContractExecutor{ID: callerID, Snapshot: state, round: &round, tx: &tx}
+
+	// Case 1 - Success, with the callee seeing the caller as its sender
+	vm := &exec.VirtualMachine{}
+	vm.Config.GasLimit = 500000
+
+	assert.NoError(t, caller.callContract(vm, contractID, "on_money_received", nil))
+	assert.True(t, vm.Gas > 0 && vm.Gas < 500000)
+
+	assert.Len(t, caller.Queue, 1)
+	assert.Equal(t, contractID, caller.Queue[0].Sender)
+
+	transfer, err := ParseTransfer(caller.Queue[0].Payload)
+	assert.NoError(t, err)
+	assert.Equal(t, callerID, transfer.Recipient)
+
+	// Case 2 - Callee does not export the function
+	assert.Error(t, caller.callContract(vm, contractID, "missing", nil))
+
+	// Case 3 - Callee is not a smart contract
+	assert.Error(t, caller.callContract(vm, accountID, "on_money_received", nil))
+
+	// Case 4 - Re-entrant call into the calling smart contract
+	reentrant := &ContractExecutor{ID: contractID, Snapshot: state, round: &round, tx: &tx}
+	assert.Error(t, reentrant.callContract(vm, contractID, "on_money_received", nil))
+
+	// Case 5 - Callee runs out of gas, and has its changes rolled back
+	checksum := state.Checksum()
+
+	vm = &exec.VirtualMachine{}
+	vm.Config.GasLimit = 100
+
+	assert.Error(t, caller.callContract(vm, contractID, "on_money_received", nil))
+	assert.Equal(t, uint64(100), vm.Gas)
+	assert.Equal(t, checksum, state.Checksum())
+
+	// Case 6 - Callee returns an error, has its changes rolled back, and leaves its error to be read
+	var failingID AccountID
+	failingID[0] = 2
+
+	WriteAccountContractCode(state, failingID, buildStorageFailureContract())
+	checksum = state.Checksum()
+
+	vm = &exec.VirtualMachine{Memory: make([]byte, PageSize)}
+	vm.Config.GasLimit = 500000
+
+	caller.Schedule = sys.GasScheduleAt(round.Index)
+
+	assert.Error(t, caller.callContract(vm, failingID, "fail", nil))
+	assert.Equal(t, checksum, state.Checksum())
+	assert.Equal(t, []byte{0}, caller.CallResult)
+
+	// Reading the result of a call charges gas per byte read.
+	gas := vm.Gas
+
+	assert.Equal(t, int64(1), invokeHostFunc(caller, vm, "_call_result_len"))
+	assert.Equal(t, int64(0), invokeHostFunc(caller, vm, "_call_result", 0))
+	assert.Equal(t, gas+sys.GasTable["wavelet.call_result_len"]+sys.GasTable["wavelet.call_result"]+sys.GasTable["wavelet.call_result_byte"], vm.Gas)
+
+	// Case 7 - Callee returns data through _return, which is left for the caller to read
+	var returningID AccountID
+	returningID[0] = 3
+
+	WriteAccountContractCode(state, returningID, buildReturnContract())
+
+	vm = &exec.VirtualMachine{Memory: make([]byte, PageSize)}
+	vm.Config.GasLimit = 500000
+
+	assert.NoError(t, caller.callContract(vm, returningID, "answer", nil))
+	assert.Equal(t, []byte("ok"), caller.CallResult)
+
+	assert.Equal(t, int64(2), invokeHostFunc(caller, vm, "_call_result_len"))
+	assert.Equal(t, int64(0), invokeHostFunc(caller, vm, "_call_result", 0))
+	assert.Equal(t, []byte("ok"), vm.Memory[:2])
+}
+
+// buildReturnContract assembles a smart contract exporting a function "answer" which returns
+// the two bytes "ok" through _return.
+func buildReturnContract() []byte {
+	section := func(id byte, contents ...byte) []byte {
+		return append([]byte{id, byte(len(contents))}, contents...)
+	}
+
+	code := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+	// Types: (i32, i32) -> i32, and () -> ().
+	code = append(code, section(0x01,
+		0x02,
+		0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7f,
+		0x60, 0x00, 0x00,
+	)...)
+
+	// Imports: env._return.
+	imports := []byte{0x01}
+	imports = append(imports, 0x03, 'e', 'n', 'v', 0x07)
+	imports = append(imports, "_return"...)
+	imports = append(imports, 0x00, 0x00)
+	code = append(code, section(0x02, imports...)...)
+
+	// Functions, and a single page of memory.
+	code = append(code, section(0x03, 0x01, 0x01)...)
+	code = append(code, section(0x05, 0x01, 0x00, 0x01)...)
+
+	// Exports: _contract_answer.
+	exports := []byte{0x01, 0x10}
+	exports = append(exports, "_contract_answer"...)
+	exports = append(exports, 0x00, 0x01)
+	code = append(code, section(0x07, exports...)...)
+
+	// Body: _return(0, 2).
+	answer := []byte{0x00, 0x41, 0x00, 0x41, 0x02, 0x10, 0x00, 0x1a, 0x0b}
+
+	bodies := []byte{0x01, byte(len(answer))}
+	bodies = append(bodies, answer...)
+	code = append(code, section(0x0a, bodies...)...)
+
+	// Data: "ok" at offset 0.
+	code = append(code, section(0x0b, 0x01, 0x00, 0x41, 0x00, 0x0b, 0x02, 'o', 'k')...)
+
+	return code
+}
+
+func TestContractExecutorStorage(t *testing.T) {
+	t.Parallel()
+
+	state := avl.New(store.NewInmem())
+
+	var contractID AccountID
+	contractID[0] = 1
+
+	executor := &ContractExecutor{ID: contractID, Snapshot: state, Schedule: sys.GasScheduleAt(0)}
+
+	vm := &exec.VirtualMachine{Memory: make([]byte, PageSize)}
+	copy(vm.Memory[0:], "hello")
+	copy(vm.Memory[16:], "world")
+
+	getCost := sys.GasTable["wavelet.storage.get"]
+	setCost := sys.GasTable["wavelet.storage.set"]
+	iterCost := sys.GasTable["wavelet.storage.iter"]
+	readCost := sys.GasTable["wavelet.storage.read_byte"]
+	writeCost := sys.GasTable["wavelet.storage.write_byte"]
+
+	// Case 1 - Get a missing key
+	assert.Equal(t, int64(-1), invokeHostFunc(executor, vm, "_storage_get", 0, 5, 32, 16))
+	assert.Equal(t, getCost+5*readCost, vm.Gas)
+
+	// Case 2 - Set and get a key
+	vm.Gas = 0
+	assert.Equal(t, int64(0), invokeHostFunc(executor, vm, "_storage_set", 0, 5, 16, 5))
+	assert.Equal(t, setCost+10*writeCost, vm.Gas)
+
+	vm.Gas = 0
+	assert.Equal(t, int64(5), invokeHostFunc(executor, vm, "_storage_get", 0, 5, 32, 16))
+	assert.Equal(t, []byte("world"), vm.Memory[32:37])
+	assert.Equal(t, getCost+10*readCost, vm.Gas)
+
+	// Case 3 - Iterate through keys
+	vm.Gas = 0
+	assert.Equal(t, int64(5), invokeHostFunc(executor, vm, "_storage_iter", 0, 0, 48, 16))
+	assert.Equal(t, []byte("hello"), vm.Memory[48:53])
+	assert.Equal(t, iterCost+5*readCost, vm.Gas)
+
+	vm.Memory[5] = 0
+	assert.Equal(t, int64(-1), invokeHostFunc(executor, vm, "_storage_iter", 0, 6, 48, 16))
+
+	// Case 4 - Delete a key
+	assert.Equal(t, int64(0), invokeHostFunc(executor, vm, "_storage_delete", 0, 5))
+	assert.Equal(t, int64(1), invokeHostFunc(executor, vm, "_storage_delete", 0, 5))
+	assert.Equal(t, int64(-1), invokeHostFunc(executor, vm, "_storage_get", 0, 5, 32, 16))
+}
+
+func TestContractExecutorEmitEvent(t *testing.T) {
+	t.Parallel()
+
+	var contractID AccountID
+	contractID[0] = 1
+
+	tx := &Transaction{ID: TransactionID{2}}
+
+	executor := &ContractExecutor{ID: contractID, Schedule: sys.GasScheduleAt(0), tx: tx}
+
+	vm := &exec.VirtualMachine{Memory: make([]byte, PageSize)}
+	copy(vm.Memory[0:], "transfer")
+	copy(vm.Memory[16:], "hello")
+
+	assert.Equal(t, int64(0), invokeHostFunc(executor, vm, "_emit_event", 0, 8, 16, 5))
+	assert.Equal(t, sys.GasTable["wavelet.emit_event"]+13*sys.GasTable["wavelet.event_byte"], vm.Gas)
+
+	// The emitted event must not alias the smart contracts memory.
+	copy(vm.Memory[16:], "world")
+
+	assert.Equal(t, []Event{{Contract: contractID, TxID: tx.ID, Topic: "transfer", Data: []byte("hello")}}, executor.Events)
+}
+
+func TestContractExecutorContext(t *testing.T) {
+	t.Parallel()
+
+	round := NewRound(7, ZeroMerkleNodeID, 0, Transaction{}, Transaction{})
+	tx := &Transaction{ID: TransactionID{1}, Creator: AccountID{2}}
+
+	executor := &ContractExecutor{Schedule: sys.GasScheduleAt(0), round: &round, tx: tx, amount: 42}
+
+	vm := &exec.VirtualMachine{Memory: make([]byte, PageSize)}
+
+	assert.Equal(t, int64(7), invokeHostFunc(executor, vm, "_round_index"))
+	assert.Equal(t, int64(42), invokeHostFunc(executor, vm, "_amount"))
+
+	assert.Equal(t, int64(0), invokeHostFunc(executor, vm, "_round_id", 0))
+	assert.Equal(t, round.ID[:], vm.Memory[0:SizeRoundID])
+
+	assert.Equal(t, int64(0), invokeHostFunc(executor, vm, "_tx_id", 32))
+	assert.Equal(t, tx.ID[:], vm.Memory[32:32+SizeTransactionID])
+
+	assert.Equal(t, int64(0), invokeHostFunc(executor, vm, "_sender_id", 64))
+	assert.Equal(t, tx.Creator[:], vm.Memory[64:64+SizeAccountID])
+
+	expectedGas := sys.GasTable["wavelet.round_index"] + sys.GasTable["wavelet.amount"] +
+		sys.GasTable["wavelet.round_id"] + sys.GasTable["wavelet.tx_id"] + sys.GasTable["wavelet.sender_id"]
+	assert.Equal(t, expectedGas, vm.Gas)
+
+	// Without any round or transaction, the context is zeroed out.
+	executor = &ContractExecutor{Schedule: sys.GasScheduleAt(0)}
+
+	vm = &exec.VirtualMachine{Memory: make([]byte, PageSize)}
+	vm.Memory[0] = 1
+
+	assert.Equal(t, int64(0), invokeHostFunc(executor, vm, "_round_index"))
+	assert.Equal(t, int64(0), invokeHostFunc(executor, vm, "_sender_id", 0))
+	assert.Equal(t, make([]byte, SizeAccountID), vm.Memory[0:SizeAccountID])
+}
+
+func TestContractExecutorTrace(t *testing.T) {
+	t.Parallel()
+
+	state := avl.New(store.NewInmem())
+	round := NewRound(0, state.Checksum(), 0, Transaction{}, Transaction{})
+
+	code, err := ioutil.ReadFile("testdata/transfer_back.wasm")
+	assert.NoError(t, err)
+
+	contractID := AccountID{1}
+	tx := &Transaction{ID: TransactionID{2}, Creator: AccountID{3}}
+
+	// The smart contract expects to have been initialized beforehand.
+	assert.NoError(t, new(ContractExecutor).Execute(state, contractID, &round, tx, 0, 1000000, "init", nil, code))
+
+	// Case 1 - Every host function called is traced, alongside the smart contract exiting successfully
+	executor := &ContractExecutor{Trace: new(ContractTrace)}
+	assert.NoError(t, executor.Execute(state, contractID, &round, tx, 100, 1000000, "on_money_received", nil, code))
+
+	entries := executor.Trace.Entries
+	assert.NotEmpty(t, entries)
+
+	var names []string
+
+	for _, entry := range entries[:len(entries)-1] {
+		assert.Equal(t, TraceHostCall, entry.Kind)
+		assert.Equal(t, contractID, entry.Contract)
+		assert.Equal(t, 0, entry.Depth)
+		assert.True(t, entry.Gas <= executor.Gas)
+
+		names = append(names, entry.Name)
+	}
+
+	assert.Contains(t, names, "_payload_len")
+	assert.Contains(t, names, "_payload")
+	assert.Contains(t, names, "_send_transaction")
+
+	exit := entries[len(entries)-1]
+	assert.Equal(t, TraceExit, exit.Kind)
+	assert.Equal(t, "ok", exit.Reason)
+	assert.Equal(t, executor.Gas, exit.Gas)
+
+	// Case 2 - Running out of gas is traced as the reason the smart contract exited
+	executor = &ContractExecutor{Trace: new(ContractTrace)}
+	assert.NoError(t, executor.Execute(state, contractID, &round, tx, 100, 10, "on_money_received", nil, code))
+
+	exit = executor.Trace.Entries[len(executor.Trace.Entries)-1]
+	assert.Equal(t, TraceExit, exit.Kind)
+	assert.Contains(t, exit.Reason, "gas limit exceeded")
+
+	// Case 3 - Nothing is traced unless opted into
+	executor = &ContractExecutor{}
+	assert.NoError(t, executor.Execute(state, contractID, &round, tx, 100, 1000000, "on_money_received", nil, code))
+	assert.Nil(t, executor.Trace)
+}
+
+func TestContractExecutorTraceMemoryGrowth(t *testing.T) {
+	t.Parallel()
+
+	trace := new(ContractTrace)
+
+	executor := &ContractExecutor{
+		ID:       AccountID{1},
+		Schedule: sys.GasScheduleAt(0),
+		Trace:    trace,
+	}
+
+	vm := &exec.VirtualMachine{Memory: make([]byte, PageSize), CallStack: []exec.Frame{{Regs: []int64{2}}}}
+	assert.EqualValues(t, 1, executor.growMemory(0)(vm))
+
+	// Attempts to grow memory are traced even should they be refused, or run out of gas.
+	vm.Config.MaxMemoryPages = 4
+	assert.EqualValues(t, -1, executor.growMemory(0)(vm))
+
+	vm.Config.GasLimit = 2*executor.Schedule.MemoryPageCost + executor.Schedule.MemoryPageCost/2
+	vm.CallStack[0].Regs[0] = 1
+	assert.Panics(t, func() { executor.growMemory(0)(vm) })
+
+	assert.Equal(t, []ContractTraceEntry{{
+		Kind:       TraceMemoryGrowth,
+		Contract:   executor.ID,
+		Gas:        2 * executor.Schedule.MemoryPageCost,
+		MemorySize: 3 * PageSize,
+		Result:     1,
+	}, {
+		Kind:       TraceMemoryGrowth,
+		Contract:   executor.ID,
+		Gas:        2 * executor.Schedule.MemoryPageCost,
+		MemorySize: 5 * PageSize,
+		Result:     -1,
+	}, {
+		Kind:       TraceMemoryGrowth,
+		Contract:   executor.ID,
+		Gas:        2 * executor.Schedule.MemoryPageCost,
+		MemorySize: 4 * PageSize,
+		Result:     -1,
+	}}, trace.Entries)
+}
+
+// invokeHostFunc invokes a host function resolved by a contract executor against the memory
+// of a virtual machine, with the given parameters.
+func invokeHostFunc(e *ContractExecutor, vm *exec.VirtualMachine, name string, params ...int64) int64 {
+	vm.CallStack = []exec.Frame{{Locals: params}}
+	vm.CurrentFrame = 0
+
+	return e.ResolveFunc("env", name)(vm)
+}
+
+func TestContractExecutorGrowMemory(t *testing.T) {
+	t.Parallel()
+
+	executor := &ContractExecutor{Schedule: sys.GasSchedule{MemoryPageCost: 1000}}
+
+	vm := &exec.VirtualMachine{Memory: make([]byte, PageSize), CallStack: []exec.Frame{{Regs: []int64{2}}}}
+	vm.Config.GasLimit = 2500
+	vm.Config.MaxMemoryPages = 4
+
+	// Gas is charged for every page of memory grown.
+	assert.EqualValues(t, 1, executor.growMemory(0)(vm))
+	assert.Equal(t, 3*PageSize, len(vm.Memory))
+	assert.Equal(t, uint64(2000), vm.Gas)
+
+	// Memory is not grown past the max number of pages, and no gas is charged for trying to.
+	vm.CallStack[0].Regs[0] = 2
+	assert.EqualValues(t, -1, executor.growMemory(0)(vm))
+	assert.Equal(t, uint64(2000), vm.Gas)
+
+	// Memory is not grown should the smart contract not have enough gas to pay for it.
+	vm.CallStack[0].Regs[0] = 1
+	assert.Panics(t, func() { executor.growMemory(0)(vm) })
+	assert.Equal(t, 3*PageSize, len(vm.Memory))
+	assert.Equal(t, uint64(2000), vm.Gas)
+}
+
+func TestContractExecutorMemoryGrowthChargedOnGrowth(t *testing.T) {
+	t.Parallel()
+
+	state := avl.New(store.NewInmem())
+	code := buildMemoryGrowthContract()
+
+	// The smart contract grows its memory by a page, after which it reports a result.
+	executor := &ContractExecutor{}
+	assert.NoError(t, executor.Execute(state, AccountID{1}, nil, nil, 0, 100000, "grow", nil, code))
+	assert.False(t, executor.GasLimitExceeded)
+	assert.NotEmpty(t, executor.Error)
+	assert.True(t, executor.Gas > executor.Schedule.MemoryPageCost)
+
+	// The smart contract is halted the moment it grows its memory without enough gas to pay for
+	// it, and so never gets to report a result.
+	executor = &ContractExecutor{}
+	assert.NoError(t, executor.Execute(state, AccountID{1}, nil, nil, 0, sys.GasScheduleAt(0).MemoryPageCost/2, "grow", nil, code))
+	assert.True(t, executor.GasLimitExceeded)
+	assert.Empty(t, executor.Error)
+}
+
+// buildMemoryGrowthContract assembles a smart contract exporting a function "grow" which grows its
+// memory by a single page, and then reports the first byte of its memory through _result.
+func buildMemoryGrowthContract() []byte {
+	section := func(id byte, contents ...byte) []byte {
+		return append([]byte{id, byte(len(contents))}, contents...)
+	}
+
+	code := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+	// Types: (i32, i32) -> i32, and () -> ().
+	code = append(code, section(0x01,
+		0x02,
+		0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7f,
+		0x60, 0x00, 0x00,
+	)...)
+
+	// Imports: env._result.
+	imports := []byte{0x01}
+	imports = append(imports, 0x03, 'e', 'n', 'v', 0x07)
+	imports = append(imports, "_result"...)
+	imports = append(imports, 0x00, 0x00)
+	code = append(code, section(0x02, imports...)...)
+
+	// Functions, and a single page of memory.
+	code = append(code, section(0x03, 0x01, 0x01)...)
+	code = append(code, section(0x05, 0x01, 0x00, 0x01)...)
+
+	// Exports: _contract_grow.
+	exports := []byte{0x01, 0x0e}
+	exports = append(exports, "_contract_grow"...)
+	exports = append(exports, 0x00, 0x01)
+	code = append(code, section(0x07, exports...)...)
+
+	// Body: drop(grow_memory(1)), followed by _result(0, 1).
+	grow := []byte{0x00, 0x41, 0x01, 0x40, 0x00, 0x1a, 0x41, 0x00, 0x41, 0x01, 0x10, 0x00, 0x1a, 0x0b}
+
+	bodies := []byte{0x01, byte(len(grow))}
+	bodies = append(bodies, grow...)
+	code = append(code, section(0x0a, bodies...)...)
+
+	return code
+}
@@ -54,6 +54,15 @@ const (
 
 	// PayloadParamNameContractCode defines a string representation of the contract_code payload param.
 	PayloadParamNameContractCode = "contract_code"
+
+	// PayloadParamNameContractID defines a string representation of the contract_id payload param.
+	PayloadParamNameContractID = "contract_id"
+
+	// PayloadParamNameBeneficiary defines a string representation of the beneficiary payload param.
+	PayloadParamNameBeneficiary = "beneficiary"
+
+	// PayloadParamNameValidator defines a string representation of the validator payload param.
+	PayloadParamNameValidator = "validator"
 )
 
 var (
@@ -88,7 +97,7 @@ func ParseJSON(data []byte, tag string) ([]byte, error) {
 		return nil, err // Return found error
 	}
 
-	if intTag := sys.TagLabels[tag]; intTag >= sys.TagBatch || intTag < 0 { // Check invalid tag value
+	if intTag := sys.TagLabels[tag]; intTag == sys.TagBatch || intTag > sys.TagContractLifecycle || intTag < 0 { // Check invalid tag value
 		return nil, ErrInvalidTag // Return invalid tag error
 	}
 
@@ -103,6 +112,8 @@ func ParseJSON(data []byte, tag string) ([]byte, error) {
 		return parseContract(data) // Parse
 	case "batch":
 		return parseBatch(data) // Parse
+	case "contract_lifecycle":
+		return parseContractLifecycle(data) // Parse
 	}
 
 	return nil, ErrCouldNotParse // Return error (shouldn't ever get here)
@@ -278,7 +289,7 @@ func parseStake(data []byte) ([]byte, error) {
 
 	operationInt := json.GetInt(PayloadParamNameOperation) // Get operation code
 
-	if sys.Tag(operationInt) >= sys.TagBatch || sys.Tag(operationInt) < 0 { // Check invalid value
+	if operationInt > int(sys.SetCommission) || operationInt < 0 { // Check invalid value
 		return nil, ErrInvalidOperation // Return invalid operation error
 	}
 
@@ -289,6 +300,12 @@ func parseStake(data []byte) ([]byte, error) {
 		operation = sys.WithdrawReward // Set operation
 	case 2:
 		operation = sys.WithdrawReward // Set operation
+	case 3:
+		operation = sys.DelegateStake // Set operation
+	case 4:
+		operation = sys.UndelegateStake // Set operation
+	case 5:
+		operation = sys.SetCommission // Set operation
 	}
 
 	decodedAmount := uint64(json.GetFloat64(PayloadParamNameAmount)) // Get amount value
@@ -306,6 +323,22 @@ func parseStake(data []byte) ([]byte, error) {
 		return nil, err // Return found error
 	}
 
+	if operation == sys.DelegateStake || operation == sys.UndelegateStake { // Check operation requires a validator
+		decodedValidator, err := hex.DecodeString(string(json.GetStringBytes(PayloadParamNameValidator))) // Decode validator hex string
+		if err != nil {                                                                                   // Check for errors
+			return nil, err // Return found error
+		}
+
+		if len(decodedValidator) != SizeAccountID { // Check validator not valid size
+			return nil, ErrInvalidAccountIDSize // Return error
+		}
+
+		_, err = payload.Write(decodedValidator) // Write validator
+		if err != nil {                          // Check for errors
+			return nil, err // Return found error
+		}
+	}
+
 	return payload.Bytes(), nil // Return payload
 }
 
@@ -468,6 +501,72 @@ func parseBatch(data []byte) ([]byte, error) {
 	return payload.Bytes(), nil // Return payload
 }
 
+// parseContractLifecycle parses a transaction payload with the contract lifecycle tag.
+func parseContractLifecycle(data []byte) ([]byte, error) {
+	var p fastjson.Parser // Initialize parser
+
+	json, err := p.Parse(string(data)) // Parse json
+	if err != nil {                    // Check for errors
+		return nil, err // Return found error
+	}
+
+	if !json.Exists(PayloadParamNameOperation) || !json.Exists(PayloadParamNameContractID) { // Check no value
+		return nil, ErrNilField // Return nil field error
+	}
+
+	var lifecycle ContractLifecycle // Initialize payload
+
+	if lifecycle.Contract, err = parseAccountID(json.GetStringBytes(PayloadParamNameContractID)); err != nil { // Decode contract ID
+		return nil, err // Return found error
+	}
+
+	switch json.GetInt(PayloadParamNameOperation) { // Handle different operations
+	case int(sys.UpgradeContract):
+		if !json.Exists(PayloadParamNameContractCode) { // Check no value
+			return nil, ErrNilField // Return nil field error
+		}
+
+		lifecycle.Opcode = sys.UpgradeContract // Set operation
+
+		lifecycle.Code, err = ioutil.ReadFile(string(json.GetStringBytes(PayloadParamNameContractCode))) // Read contract code
+		if err != nil {                                                                                  // Check for errors
+			return nil, err // Return found error
+		}
+	case int(sys.DestructContract):
+		if !json.Exists(PayloadParamNameBeneficiary) { // Check no value
+			return nil, ErrNilField // Return nil field error
+		}
+
+		lifecycle.Opcode = sys.DestructContract // Set operation
+
+		if lifecycle.Beneficiary, err = parseAccountID(json.GetStringBytes(PayloadParamNameBeneficiary)); err != nil { // Decode beneficiary
+			return nil, err // Return found error
+		}
+	default:
+		return nil, ErrInvalidOperation // Return invalid operation error
+	}
+
+	return lifecycle.Marshal(), nil // Return payload
+}
+
+// parseAccountID decodes a hex-encoded account ID.
+func parseAccountID(raw []byte) (AccountID, error) {
+	var id AccountID // Initialize ID buffer
+
+	decoded, err := hex.DecodeString(string(raw)) // Decode hex string
+	if err != nil {                               // Check for errors
+		return id, err // Return found error
+	}
+
+	if len(decoded) != SizeAccountID { // Check invalid length
+		return id, ErrInvalidAccountIDSize // Return invalid account ID error
+	}
+
+	copy(id[:], decoded) // Copy ID to buffer
+
+	return id, nil
+}
+
 /*
 	END TAG HANDLERS
 */
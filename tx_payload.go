@@ -30,6 +30,11 @@ type (
 	Stake struct {
 		Opcode byte
 		Amount uint64
+
+		// Validator to delegate stake to or undelegate stake from, should
+		// stake be delegated or undelegated.
+
+		Validator AccountID
 	}
 
 	Contract struct {
@@ -45,6 +50,36 @@ type (
 		Tags     []uint8
 		Payloads [][]byte
 	}
+
+	ContractLifecycle struct {
+		Opcode   byte
+		Contract AccountID
+
+		// Code to replace the smart contracts code with, should
+		// the smart contract be upgraded.
+
+		Code []byte
+
+		// Recipient of the smart contracts remaining balance and
+		// gas balance, should the smart contract be destructed.
+
+		Beneficiary AccountID
+	}
+
+	// Evidence proves that a validator has equivocated by signing two conflicting
+	// rounds as finalized for the same round index. Signatures over rounds that a
+	// validator merely prefers are not evidence, as an honest validator may change
+	// its preference for a round index over the course of consensus.
+	Evidence struct {
+		Validator  AccountID
+		RoundIndex uint64
+
+		RoundA     RoundID
+		SignatureA Signature
+
+		RoundB     RoundID
+		SignatureB Signature
+	}
 )
 
 // ParseTransfer parses and performs sanity checks on the payload of a transfer transaction.
@@ -129,18 +164,40 @@ func ParseTransfer(payload []byte) (Transfer, error) {
 func ParseStake(payload []byte) (Stake, error) {
 	var stake Stake
 
-	if len(payload) != 9 {
-		return stake, errors.New("stake: payload must be exactly 9 bytes")
+	if len(payload) < 1 {
+		return stake, errors.New("stake: payload must not be empty")
 	}
 
 	stake.Opcode = payload[0]
 
-	if stake.Opcode > sys.WithdrawReward {
-		return stake, errors.New("stake: opcode must be 0, 1, or 2")
+	if stake.Opcode > sys.SetCommission {
+		return stake, errors.New("stake: opcode must be 0, 1, 2, 3, 4, or 5")
+	}
+
+	delegates := stake.Opcode == sys.DelegateStake || stake.Opcode == sys.UndelegateStake
+
+	if delegates && len(payload) != 9+SizeAccountID {
+		return stake, errors.Errorf("stake: payload must be exactly %d bytes when delegating or undelegating stake", 9+SizeAccountID)
+	}
+
+	if !delegates && len(payload) != 9 {
+		return stake, errors.New("stake: payload must be exactly 9 bytes")
 	}
 
 	stake.Amount = binary.LittleEndian.Uint64(payload[1:9])
 
+	if delegates {
+		copy(stake.Validator[:], payload[9:])
+	}
+
+	if stake.Opcode == sys.SetCommission {
+		if stake.Amount > sys.MaxCommission {
+			return stake, errors.Errorf("stake: commission must be at most %d basis points, but requested a commission of %d basis points", sys.MaxCommission, stake.Amount)
+		}
+
+		return stake, nil
+	}
+
 	if stake.Amount == 0 {
 		return stake, errors.New("stake: amount must be greater than zero")
 	}
@@ -252,6 +309,95 @@ func ParseBatch(payload []byte) (Batch, error) {
 	return batch, nil
 }
 
+// ParseContractLifecycle parses and performs sanity checks on the payload of a contract lifecycle transaction.
+func ParseContractLifecycle(payload []byte) (ContractLifecycle, error) {
+	r := bytes.NewReader(payload)
+
+	var lifecycle ContractLifecycle
+
+	opcode, err := r.ReadByte()
+	if err != nil {
+		return lifecycle, errors.Wrap(err, "contract_lifecycle: failed to decode opcode")
+	}
+
+	lifecycle.Opcode = opcode
+
+	if _, err := io.ReadFull(r, lifecycle.Contract[:]); err != nil {
+		return lifecycle, errors.Wrap(err, "contract_lifecycle: failed to decode smart contract ID")
+	}
+
+	switch lifecycle.Opcode {
+	case sys.UpgradeContract:
+		if lifecycle.Code, err = ioutil.ReadAll(r); err != nil {
+			return lifecycle, errors.Wrap(err, "contract_lifecycle: failed to decode smart contract code")
+		}
+
+		if len(lifecycle.Code) == 0 {
+			return lifecycle, errors.New("contract_lifecycle: smart contract must have code of length greater than zero")
+		}
+	case sys.DestructContract:
+		if _, err := io.ReadFull(r, lifecycle.Beneficiary[:]); err != nil {
+			return lifecycle, errors.Wrap(err, "contract_lifecycle: failed to decode beneficiary")
+		}
+
+		if r.Len() > 0 {
+			return lifecycle, errors.New("contract_lifecycle: payload has trailing bytes")
+		}
+
+		if lifecycle.Beneficiary == lifecycle.Contract {
+			return lifecycle, errors.New("contract_lifecycle: smart contract may not be its own beneficiary")
+		}
+	default:
+		return lifecycle, errors.New("contract_lifecycle: opcode must be 0 or 1")
+	}
+
+	return lifecycle, nil
+}
+
+// ParseEvidence parses and performs sanity checks on the payload of an evidence transaction.
+func ParseEvidence(payload []byte) (Evidence, error) {
+	r := bytes.NewReader(payload)
+	b := make([]byte, 8)
+
+	var evidence Evidence
+
+	if _, err := io.ReadFull(r, evidence.Validator[:]); err != nil {
+		return evidence, errors.Wrap(err, "evidence: failed to decode validator")
+	}
+
+	if _, err := io.ReadFull(r, b[:8]); err != nil {
+		return evidence, errors.Wrap(err, "evidence: failed to decode round index")
+	}
+
+	evidence.RoundIndex = binary.LittleEndian.Uint64(b[:8])
+
+	if _, err := io.ReadFull(r, evidence.RoundA[:]); err != nil {
+		return evidence, errors.Wrap(err, "evidence: failed to decode first round ID")
+	}
+
+	if _, err := io.ReadFull(r, evidence.SignatureA[:]); err != nil {
+		return evidence, errors.Wrap(err, "evidence: failed to decode signature over first round ID")
+	}
+
+	if _, err := io.ReadFull(r, evidence.RoundB[:]); err != nil {
+		return evidence, errors.Wrap(err, "evidence: failed to decode second round ID")
+	}
+
+	if _, err := io.ReadFull(r, evidence.SignatureB[:]); err != nil {
+		return evidence, errors.Wrap(err, "evidence: failed to decode signature over second round ID")
+	}
+
+	if r.Len() > 0 {
+		return evidence, errors.New("evidence: payload has trailing bytes")
+	}
+
+	if evidence.RoundA == evidence.RoundB {
+		return evidence, errors.New("evidence: rounds must conflict")
+	}
+
+	return evidence, nil
+}
+
 func (t Transfer) Marshal() []byte {
 	buf := new(bytes.Buffer)
 	buf.Write(t.Recipient[:])
@@ -277,6 +423,11 @@ func (s Stake) Marshal() []byte {
 	buf := new(bytes.Buffer)
 	buf.WriteByte(s.Opcode)
 	binary.Write(buf, binary.LittleEndian, s.Amount)
+
+	if s.Opcode == sys.DelegateStake || s.Opcode == sys.UndelegateStake {
+		buf.Write(s.Validator[:])
+	}
+
 	return buf.Bytes()
 }
 
@@ -290,6 +441,32 @@ func (c Contract) Marshal() []byte {
 	return buf.Bytes()
 }
 
+func (c ContractLifecycle) Marshal() []byte {
+	buf := new(bytes.Buffer)
+	buf.WriteByte(c.Opcode)
+	buf.Write(c.Contract[:])
+
+	switch c.Opcode {
+	case sys.UpgradeContract:
+		buf.Write(c.Code)
+	case sys.DestructContract:
+		buf.Write(c.Beneficiary[:])
+	}
+
+	return buf.Bytes()
+}
+
+func (e Evidence) Marshal() []byte {
+	buf := new(bytes.Buffer)
+	buf.Write(e.Validator[:])
+	binary.Write(buf, binary.LittleEndian, e.RoundIndex)
+	buf.Write(e.RoundA[:])
+	buf.Write(e.SignatureA[:])
+	buf.Write(e.RoundB[:])
+	buf.Write(e.SignatureB[:])
+	return buf.Bytes()
+}
+
 // AddNop adds a Nop payload into a batch.
 func (b *Batch) AddNop() error {
 	if b.Size == 255 {
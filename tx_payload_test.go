@@ -162,6 +162,24 @@ func TestParseStake(t *testing.T) {
 	stakeWithdraw, err := ParseStake(stake.Marshal())
 	assert.NoError(t, err)
 	assert.Equal(t, stake, stakeWithdraw)
+
+	// DelegateStake and UndelegateStake carry the validator stake is delegated to
+	stake.Opcode = sys.DelegateStake
+	stake.Validator = AccountID{1, 2, 3}
+	stakeDelegate, err := ParseStake(stake.Marshal())
+	assert.NoError(t, err)
+	assert.Equal(t, stake, stakeDelegate)
+
+	stake.Opcode = sys.UndelegateStake
+	stakeUndelegate, err := ParseStake(stake.Marshal())
+	assert.NoError(t, err)
+	assert.Equal(t, stake, stakeUndelegate)
+
+	// SetCommission may set a commission of zero
+	stake = Stake{Opcode: sys.SetCommission}
+	stakeCommission, err := ParseStake(stake.Marshal())
+	assert.NoError(t, err)
+	assert.Equal(t, stake, stakeCommission)
 }
 
 func TestParseStake_Errors(t *testing.T) {
@@ -177,9 +195,24 @@ func TestParseStake_Errors(t *testing.T) {
 			},
 		},
 		{
-			"opcode must be 0, 1, or 2",
+			"opcode must be 0, 1, 2, 3, 4, or 5",
+			func() []byte {
+				return validStake(t, sys.SetCommission+1).Marshal()
+			},
+		},
+		{
+			"payload must be exactly 41 bytes when delegating or undelegating stake",
+			func() []byte {
+				payload := validStake(t, sys.DelegateStake).Marshal()
+				return payload[:9]
+			},
+		},
+		{
+			"commission must be at most 10000 basis points",
 			func() []byte {
-				return validStake(t, sys.WithdrawReward+1).Marshal()
+				stake := validStake(t, sys.SetCommission)
+				stake.Amount = sys.MaxCommission + 1
+				return stake.Marshal()
 			},
 		},
 		{
@@ -371,6 +404,148 @@ func TestParseBatch_Errors(t *testing.T) {
 	}
 }
 
+func TestParseContractLifecycle(t *testing.T) {
+	upgrade := validContractLifecycle(t, sys.UpgradeContract)
+
+	upgrade2, err := ParseContractLifecycle(upgrade.Marshal())
+	assert.NoError(t, err)
+	assert.Equal(t, upgrade, upgrade2)
+
+	destruct := validContractLifecycle(t, sys.DestructContract)
+
+	destruct2, err := ParseContractLifecycle(destruct.Marshal())
+	assert.NoError(t, err)
+	assert.Equal(t, destruct, destruct2)
+}
+
+func TestParseContractLifecycle_Errors(t *testing.T) {
+	tests := []struct {
+		Err     string
+		Payload func() []byte
+	}{
+		{
+			"failed to decode opcode",
+			func() []byte {
+				return []byte{}
+			},
+		},
+		{
+			"failed to decode smart contract ID",
+			func() []byte {
+				payload := validContractLifecycle(t, sys.UpgradeContract).Marshal()
+				return payload[:1+31]
+			},
+		},
+		{
+			"smart contract must have code of length greater than zero",
+			func() []byte {
+				lifecycle := validContractLifecycle(t, sys.UpgradeContract)
+				lifecycle.Code = []byte{}
+				return lifecycle.Marshal()
+			},
+		},
+		{
+			"failed to decode beneficiary",
+			func() []byte {
+				payload := validContractLifecycle(t, sys.DestructContract).Marshal()
+				return payload[:1+32+31]
+			},
+		},
+		{
+			"payload has trailing bytes",
+			func() []byte {
+				payload := validContractLifecycle(t, sys.DestructContract).Marshal()
+				return append(payload, 0)
+			},
+		},
+		{
+			"smart contract may not be its own beneficiary",
+			func() []byte {
+				lifecycle := validContractLifecycle(t, sys.DestructContract)
+				lifecycle.Beneficiary = lifecycle.Contract
+				return lifecycle.Marshal()
+			},
+		},
+		{
+			"opcode must be 0 or 1",
+			func() []byte {
+				payload := validContractLifecycle(t, sys.DestructContract).Marshal()
+				payload[0] = 2
+				return payload
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Err, func(t *testing.T) {
+			_, err := ParseContractLifecycle(tt.Payload())
+			if err == nil {
+				t.Fatal("expecting an error, got nil instead")
+			}
+			assert.Contains(t, err.Error(), fmt.Sprintf("contract_lifecycle: %s", tt.Err))
+		})
+	}
+}
+
+func TestParseEvidence(t *testing.T) {
+	evidence := validEvidence(t)
+
+	evidence2, err := ParseEvidence(evidence.Marshal())
+	assert.NoError(t, err)
+	assert.Equal(t, evidence, evidence2)
+}
+
+func TestParseEvidence_Errors(t *testing.T) {
+	tests := []struct {
+		Err     string
+		Payload func() []byte
+	}{
+		{
+			"failed to decode validator",
+			func() []byte {
+				return []byte{}
+			},
+		},
+		{
+			"failed to decode round index",
+			func() []byte {
+				return validEvidence(t).Marshal()[:32+7]
+			},
+		},
+		{
+			"failed to decode signature over second round ID",
+			func() []byte {
+				payload := validEvidence(t).Marshal()
+				return payload[:len(payload)-1]
+			},
+		},
+		{
+			"payload has trailing bytes",
+			func() []byte {
+				return append(validEvidence(t).Marshal(), 0)
+			},
+		},
+		{
+			"rounds must conflict",
+			func() []byte {
+				evidence := validEvidence(t)
+				evidence.RoundB = evidence.RoundA
+				return evidence.Marshal()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Err, func(t *testing.T) {
+			_, err := ParseEvidence(tt.Payload())
+			if err == nil {
+				t.Fatal("expecting an error, got nil instead")
+			}
+			assert.Contains(t, err.Error(), fmt.Sprintf("evidence: %s", tt.Err))
+		})
+	}
+}
+
 func validTransfer(t *testing.T) Transfer {
 	keys, err := skademlia.NewKeys(sys.SKademliaC1, sys.SKademliaC2)
 	if err != nil {
@@ -410,3 +585,35 @@ func validBatch(t *testing.T) Batch {
 	assert.NoError(t, batch.AddContract(validContract(t)))
 	return batch
 }
+
+func validContractLifecycle(t *testing.T, opcode byte) ContractLifecycle {
+	lifecycle := ContractLifecycle{
+		Opcode:   opcode,
+		Contract: AccountID{1},
+	}
+
+	switch opcode {
+	case sys.UpgradeContract:
+		lifecycle.Code = []byte("loremipsumdolorsitamet")
+	case sys.DestructContract:
+		lifecycle.Beneficiary = AccountID{2}
+	}
+
+	return lifecycle
+}
+
+func validEvidence(t *testing.T) Evidence {
+	keys, err := skademlia.NewKeys(sys.SKademliaC1, sys.SKademliaC2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return Evidence{
+		Validator:  keys.PublicKey(),
+		RoundIndex: 42,
+		RoundA:     RoundID{1},
+		SignatureA: Signature{2},
+		RoundB:     RoundID{3},
+		SignatureB: Signature{4},
+	}
+}
@@ -35,7 +35,7 @@ func BenchmarkNewTX(b *testing.B) {
 	b.ReportAllocs()
 
 	for i := 0; i < b.N; i++ {
-		AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil))
+		AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil))
 	}
 }
 
@@ -43,7 +43,7 @@ func BenchmarkMarshalUnmarshalTX(b *testing.B) {
 	keys, err := skademlia.NewKeys(1, 1)
 	assert.NoError(b, err)
 
-	tx := AttachSenderToTransaction(keys, NewTransaction(keys, sys.TagNop, nil))
+	tx := AttachSenderToTransaction(keys, NewTransaction(keys, 0, sys.TagNop, nil))
 
 	b.ResetTimer()
 	b.ReportAllocs()
@@ -53,3 +53,25 @@ func BenchmarkMarshalUnmarshalTX(b *testing.B) {
 		assert.NoError(b, err)
 	}
 }
+
+func TestTransactionTip(t *testing.T) {
+	keys, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	tx := AttachSenderToTransaction(keys, NewTransactionWithTip(keys, 0, 10, sys.TagNop, nil))
+
+	decoded, err := UnmarshalTransaction(bytes.NewReader(tx.Marshal()))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), decoded.Tip)
+
+	// The tip is covered by the creators signature, and may not be altered by a sender.
+	tx.Tip = 100
+	assert.NotEqual(t, decoded.CreatorSignaturePayload(), tx.CreatorSignaturePayload())
+}
+
+func TestTransactionFee(t *testing.T) {
+	assert.Equal(t, sys.TransactionFeeAmount, Transaction{Tag: sys.TagNop}.Fee())
+
+	tx := Transaction{Tag: sys.TagTransfer, Payload: make([]byte, 1025)}
+	assert.Equal(t, sys.TransactionFeeAmount+2*sys.TransactionFeePerKiB, tx.Fee())
+}
@@ -22,6 +22,8 @@ package wavelet
 import (
 	"encoding/hex"
 	"fmt"
+	"github.com/perlin-network/noise/skademlia"
+	"github.com/perlin-network/wavelet/sys"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
@@ -54,6 +56,110 @@ func SelectPeers(peers []*grpc.ClientConn, amount int) ([]*grpc.ClientConn, erro
 	return activePeers, nil
 }
 
+// SelectPeersByStake samples amount peers that are ready to be queried, with the odds of a peer being
+// sampled being proportional to its total stake within stakes. Peers that have placed less than
+// sys.MinimumStake, or no stake at all, are weighted as though they had placed sys.MinimumStake.
+// Validators are dialed by the addresses recorded for them in book, or otherwise amongst our closest
+// peers.
+func SelectPeersByStake(client *skademlia.Client, book *PeerBook, stakes map[AccountID]uint64, amount int) ([]*grpc.ClientConn, error) {
+	closest := client.ClosestPeerIDs()
+
+	known := make(map[AccountID]*skademlia.ID, len(closest)+len(stakes))
+
+	for _, id := range closest {
+		known[id.PublicKey()] = id
+	}
+
+	for account := range stakes {
+		if _, exists := known[account]; exists {
+			continue
+		}
+
+		if id, exists := book.Lookup(account); exists {
+			known[account] = id
+		}
+	}
+
+	if len(known) < amount {
+		return nil, errors.Errorf("only connected to %d peer(s), but require a minimum of %d peer(s)", len(known), amount)
+	}
+
+	peers := make([]*skademlia.ID, 0, len(known))
+	weights := make([]float64, 0, len(known))
+
+	for account, id := range known {
+		peers = append(peers, id)
+		weights = append(weights, float64(voteWeight(stakes, account)))
+	}
+
+	selected := make([]*grpc.ClientConn, 0, amount)
+
+	// Order peers by weighted sampling, such that should a sampled peer not be ready, the next
+	// peer sampled takes its place.
+
+	for _, i := range sampleByWeight(weights, len(weights)) {
+		if len(selected) == amount {
+			break
+		}
+
+		conn, err := client.Dial(peers[i].Address())
+		if err != nil || conn.GetState() != connectivity.Ready {
+			continue
+		}
+
+		selected = append(selected, conn)
+	}
+
+	return selected, nil
+}
+
+// voteWeight returns the weight of the vote of an account, being its total stake within stakes
+// floored to sys.MinimumStake.
+func voteWeight(stakes map[AccountID]uint64, account AccountID) uint64 {
+	if stake := stakes[account]; stake > sys.MinimumStake {
+		return stake
+	}
+
+	return sys.MinimumStake
+}
+
+// sampleByWeight samples amount distinct indices of weights without replacement, with the odds
+// of an index being sampled being proportional to its weight.
+func sampleByWeight(weights []float64, amount int) []int {
+	remaining := make([]int, len(weights))
+
+	total := float64(0)
+
+	for i, weight := range weights {
+		remaining[i] = i
+		total += weight
+	}
+
+	sampled := make([]int, 0, amount)
+
+	for len(sampled) < amount && len(remaining) > 0 {
+		target := rand.Float64() * total
+
+		j := len(remaining) - 1 // Fall back to the last index should floating point error leave target unreached.
+
+		for k, i := range remaining {
+			if target < weights[i] {
+				j = k
+				break
+			}
+
+			target -= weights[i]
+		}
+
+		sampled = append(sampled, remaining[j])
+		total -= weights[remaining[j]]
+
+		remaining = append(remaining[:j], remaining[j+1:]...)
+	}
+
+	return sampled
+}
+
 func ExportGraphDOT(round *Round, graph *Graph) {
 	visited := map[TransactionID]struct{}{round.Start.ID: {}}
 
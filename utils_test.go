@@ -78,6 +78,97 @@ func TestSelectPeers(t *testing.T) {
 	assert.Equal(t, 4, len(selected))
 }
 
+func TestSelectPeersByStake(t *testing.T) {
+	nodes := make([]*skademlia.Client, 6)
+	addrs := make([]string, 6)
+	for i := 0; i < 6; i++ {
+		var cleanup func()
+		nodes[i], addrs[i], cleanup = newNode(t)
+		defer cleanup()
+	}
+
+	// The last node is not amongst the peers of the first node.
+
+	for i := 0; i < 5; i++ {
+		_, err := nodes[i].Dial(addrs[(i+1)%5])
+		assert.NoError(t, err)
+	}
+
+	for i := 0; i < 5; i++ {
+		nodes[i].Bootstrap()
+	}
+
+	book := NewPeerBook()
+	stakes := make(map[AccountID]uint64)
+
+	_, err := SelectPeersByStake(nodes[0], book, stakes, 5)
+	assert.EqualError(t, err, "only connected to 4 peer(s), but require a minimum of 5 peer(s)")
+
+	// Peers without stake are sampled as though they had placed the minimum stake.
+
+	selected, err := SelectPeersByStake(nodes[0], book, stakes, 3)
+	assert.NoError(t, err)
+	assert.Len(t, selected, 3)
+
+	// A peer with an overwhelming stake should practically always be sampled.
+
+	whale := nodes[0].ClosestPeerIDs()[0]
+	stakes[whale.PublicKey()] = 1e15
+
+	conn, err := nodes[0].Dial(whale.Address())
+	assert.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		selected, err := SelectPeersByStake(nodes[0], book, stakes, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []*grpc.ClientConn{conn}, selected)
+	}
+
+	// Validators that are not amongst our closest peers are dialed through the peer book.
+
+	validator := nodes[5].ID()
+	stakes[validator.PublicKey()] = 1e15
+
+	_, err = SelectPeersByStake(nodes[0], book, stakes, 5)
+	assert.Error(t, err)
+
+	book.Add(validator)
+
+	expected, err := nodes[0].Dial(validator.Address())
+	assert.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		selected, err := SelectPeersByStake(nodes[0], book, stakes, 2)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []*grpc.ClientConn{conn, expected}, selected)
+	}
+}
+
+func TestSampleByWeight(t *testing.T) {
+	weights := []float64{1, 2, 7, 0}
+
+	counts := make([]int, len(weights))
+
+	for i := 0; i < 10000; i++ {
+		sampled := sampleByWeight(weights, 1)
+		assert.Len(t, sampled, 1)
+
+		counts[sampled[0]]++
+	}
+
+	assert.InDelta(t, 1000, counts[0], 200)
+	assert.InDelta(t, 2000, counts[1], 300)
+	assert.InDelta(t, 7000, counts[2], 300)
+	assert.Equal(t, 0, counts[3])
+
+	// Sampling is done without replacement.
+
+	sampled := sampleByWeight(weights, 3)
+	assert.ElementsMatch(t, []int{0, 1, 2}, sampled)
+
+	assert.Len(t, sampleByWeight(weights, 10), len(weights))
+}
+
 func newNode(t *testing.T) (*skademlia.Client, string, func()) {
 	keys, err := skademlia.NewKeys(sys.SKademliaC1, sys.SKademliaC2)
 	assert.NoError(t, err)
@@ -20,21 +20,162 @@
 package wavelet
 
 import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"sort"
+
+	"github.com/perlin-network/noise/edwards25519"
 	"github.com/perlin-network/noise/skademlia"
 	"github.com/perlin-network/wavelet/sys"
+	"github.com/pkg/errors"
 	"sync"
 )
 
+// QueryResponseSignaturePayload returns the contents that a validator signs to vouch for a round
+// having been finalized at a round index. Signatures over two different rounds for the same index
+// are evidence that the validator has equivocated.
+func QueryResponseSignaturePayload(roundIndex uint64, roundID RoundID) []byte {
+	buf := make([]byte, 8+SizeRoundID)
+
+	binary.BigEndian.PutUint64(buf[:8], roundIndex)
+	copy(buf[8:], roundID[:])
+
+	return buf
+}
+
+// PreferenceSignaturePayload returns the contents that a validator signs to vouch for a round that
+// it prefers, but has yet to finalize. An honest validator may change its preference over the course
+// of consensus, so unlike QueryResponseSignaturePayload, the payload is never accepted as evidence.
+func PreferenceSignaturePayload(roundIndex uint64, roundID RoundID) []byte {
+	return append([]byte{0x1}, QueryResponseSignaturePayload(roundIndex, roundID)...)
+}
+
+// Vote is a signed vote a validator cast for a round at a round index while the round at the index
+// was being finalized. Votes are stored alongside the round finalized at their index, such that who
+// voted for what may be audited and proven to a third party. Finalized denotes whether the voter
+// signed the round as finalized, or as a round it merely preferred.
+type Vote struct {
+	Voter      AccountID
+	RoundIndex uint64
+	RoundID    RoundID
+	Finalized  bool
+	Signature  Signature
+}
+
+// SignaturePayload returns the contents that the voter signed in casting the vote.
+func (v Vote) SignaturePayload() []byte {
+	if v.Finalized {
+		return QueryResponseSignaturePayload(v.RoundIndex, v.RoundID)
+	}
+
+	return PreferenceSignaturePayload(v.RoundIndex, v.RoundID)
+}
+
+func (v Vote) Marshal() []byte {
+	var w bytes.Buffer
+
+	w.Write(v.Voter[:])
+
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v.RoundIndex)
+	w.Write(buf[:])
+
+	w.Write(v.RoundID[:])
+
+	if v.Finalized {
+		w.WriteByte(1)
+	} else {
+		w.WriteByte(0)
+	}
+
+	w.Write(v.Signature[:])
+
+	return w.Bytes()
+}
+
+func UnmarshalVote(r io.Reader) (v Vote, err error) {
+	if _, err = io.ReadFull(r, v.Voter[:]); err != nil {
+		err = errors.Wrap(err, "failed to decode vote voter")
+		return
+	}
+
+	var buf [8]byte
+
+	if _, err = io.ReadFull(r, buf[:]); err != nil {
+		err = errors.Wrap(err, "failed to decode vote round index")
+		return
+	}
+
+	v.RoundIndex = binary.BigEndian.Uint64(buf[:])
+
+	if _, err = io.ReadFull(r, v.RoundID[:]); err != nil {
+		err = errors.Wrap(err, "failed to decode vote round ID")
+		return
+	}
+
+	if _, err = io.ReadFull(r, buf[:1]); err != nil {
+		err = errors.Wrap(err, "failed to decode whether vote is over a finalized round")
+		return
+	}
+
+	v.Finalized = buf[0] == 1
+
+	if _, err = io.ReadFull(r, v.Signature[:]); err != nil {
+		err = errors.Wrap(err, "failed to decode vote signature")
+		return
+	}
+
+	return
+}
+
 type vote struct {
 	voter     *skademlia.ID
 	preferred *Round
+
+	// Signature of the voter over the index and ID of its preferred round.
+	signature Signature
+}
+
+// verify checks that the voter has signed its preferred round, either as a round it has finalized
+// or as a round it merely prefers, and returns the signed vote.
+func (v vote) verify() (Vote, bool) {
+	signed := Vote{
+		Voter:      v.voter.PublicKey(),
+		RoundIndex: v.preferred.Index,
+		RoundID:    v.preferred.ID,
+		Finalized:  true,
+		Signature:  v.signature,
+	}
+
+	if edwards25519.Verify(signed.Voter, signed.SignaturePayload(), signed.Signature) {
+		return signed, true
+	}
+
+	signed.Finalized = false
+
+	return signed, edwards25519.Verify(signed.Voter, signed.SignaturePayload(), signed.Signature)
 }
 
-func CollectVotes(accounts *Accounts, snowball *Snowball, voteChan <-chan vote, wg *sync.WaitGroup) {
+// CollectVotes ticks snowball with the round preferred by at least sys.SnowballAlpha of every
+// sys.SnowballK votes received from distinct voters. Each vote is weighted by the total stake of its
+// voter, as returned by stakes, floored to sys.MinimumStake. Should stakes be nil, every vote is
+// weighted equally. Every signed vote counted is passed to record, should it not be nil.
+func CollectVotes(snowball *Snowball, voteChan <-chan vote, wg *sync.WaitGroup, stakes func() map[AccountID]uint64, record func(Vote)) {
 	votes := make([]vote, 0, sys.SnowballK)
 	voters := make(map[AccountID]struct{}, sys.SnowballK)
 
 	for vote := range voteChan {
+		var signed Vote
+
+		if vote.preferred != nil {
+			var ok bool
+
+			if signed, ok = vote.verify(); !ok {
+				continue // Only count votes that the voter has signed, such that they may be proven to a third party.
+			}
+		}
+
 		if _, recorded := voters[vote.voter.PublicKey()]; recorded {
 			continue // To make sure the sampling process is fair, only allow one vote per peer.
 		}
@@ -42,37 +183,29 @@ func CollectVotes(accounts *Accounts, snowball *Snowball, voteChan <-chan vote,
 		voters[vote.voter.PublicKey()] = struct{}{}
 		votes = append(votes, vote)
 
+		if vote.preferred != nil && record != nil {
+			record(signed)
+		}
+
 		if len(votes) == cap(votes) {
-			snapshot := accounts.Snapshot()
+			var validators map[AccountID]uint64
 
-			stakes := make(map[AccountID]float64, len(votes))
-			maxStake := float64(0)
+			if stakes != nil {
+				validators = stakes()
+			}
+
+			counts := make(map[RoundID]float64, len(votes))
+			totalCount := float64(0)
 
 			for i, vote := range votes {
 				if vote.preferred == nil {
 					votes[i].preferred = ZeroRoundPtr
 				}
 
-				s, _ := ReadAccountStake(snapshot, vote.voter.PublicKey())
-				if s < sys.MinimumStake {
-					s = sys.MinimumStake
-				}
+				weight := float64(voteWeight(validators, vote.voter.PublicKey()))
 
-				stake := float64(s)
-				stakes[vote.voter.PublicKey()] = stake
-
-				if maxStake < stake {
-					maxStake = stake
-				}
-			}
-
-			counts := make(map[RoundID]float64, len(votes))
-			totalCount := float64(0)
-
-			for _, vote := range votes {
-				count := stakes[vote.voter.PublicKey()] / maxStake
-				counts[vote.preferred.ID] += count
-				totalCount += count
+				counts[votes[i].preferred.ID] += weight
+				totalCount += weight
 			}
 
 			var majority *Round
@@ -95,3 +228,18 @@ func CollectVotes(accounts *Accounts, snowball *Snowball, voteChan <-chan vote,
 		wg.Done()
 	}
 }
+
+// sortVotes returns the votes held by a map keyed by their voters, ordered by their voters.
+func sortVotes(votes map[AccountID]Vote) []Vote {
+	sorted := make([]Vote, 0, len(votes))
+
+	for _, vote := range votes {
+		sorted = append(sorted, vote)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Voter[:], sorted[j].Voter[:]) < 0
+	})
+
+	return sorted
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2019 Perlin
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package wavelet
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/perlin-network/noise/edwards25519"
+	"github.com/perlin-network/noise/skademlia"
+	"github.com/perlin-network/wavelet/sys"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestCollectVotesVerifiesSignatures(t *testing.T) {
+	a, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	b, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	preferred := NewRound(1, MerkleNodeID{1}, 0, Transaction{}, Transaction{})
+	conflicting := NewRound(1, MerkleNodeID{2}, 0, Transaction{}, Transaction{})
+
+	signed := func(keys *skademlia.Keypair, round *Round) vote {
+		v := vote{voter: skademlia.NewID(":0", keys.PublicKey(), [blake2b.Size256]byte{}), preferred: round}
+		v.signature = edwards25519.Sign(keys.PrivateKey(), QueryResponseSignaturePayload(round.Index, round.ID))
+
+		return v
+	}
+
+	// A vote signed over another round than the one preferred must not be counted, and must not
+	// prevent the voter from casting a properly signed vote afterwards.
+
+	forged := signed(a, &preferred)
+	forged.preferred = &conflicting
+
+	snowball := NewSnowball(WithBeta(0))
+	voteChan := make(chan vote, sys.SnowballK)
+
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+
+	var recorded []Vote
+
+	go CollectVotes(snowball, voteChan, wg, nil, func(vote Vote) {
+		recorded = append(recorded, vote)
+	})
+
+	voteChan <- forged
+	voteChan <- signed(a, &preferred)
+
+	// Votes for rounds that the voter has yet to finalize are signed over a separate payload.
+
+	unfinalized := vote{voter: skademlia.NewID(":0", b.PublicKey(), [blake2b.Size256]byte{}), preferred: &preferred}
+	unfinalized.signature = edwards25519.Sign(b.PrivateKey(), PreferenceSignaturePayload(preferred.Index, preferred.ID))
+
+	voteChan <- unfinalized
+
+	close(voteChan)
+	wg.Wait()
+
+	if assert.NotNil(t, snowball.Preferred()) {
+		assert.Equal(t, preferred.ID, snowball.Preferred().ID)
+	}
+
+	// Only the signed votes that were counted are recorded, alongside what their voters signed.
+
+	if assert.Len(t, recorded, 2) {
+		assert.Equal(t, AccountID(a.PublicKey()), recorded[0].Voter)
+		assert.True(t, recorded[0].Finalized)
+
+		assert.Equal(t, AccountID(b.PublicKey()), recorded[1].Voter)
+		assert.False(t, recorded[1].Finalized)
+
+		for _, vote := range recorded {
+			assert.Equal(t, preferred.Index, vote.RoundIndex)
+			assert.Equal(t, preferred.ID, vote.RoundID)
+			assert.True(t, edwards25519.Verify(vote.Voter, vote.SignaturePayload(), vote.Signature))
+		}
+	}
+}
+
+func TestCollectVotesWeightsByStake(t *testing.T) {
+	a, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	b, err := skademlia.NewKeys(1, 1)
+	assert.NoError(t, err)
+
+	preferred := NewRound(1, MerkleNodeID{1}, 0, Transaction{}, Transaction{})
+	conflicting := NewRound(1, MerkleNodeID{2}, 0, Transaction{}, Transaction{})
+
+	signed := func(keys *skademlia.Keypair, round *Round) vote {
+		v := vote{voter: skademlia.NewID(":0", keys.PublicKey(), [blake2b.Size256]byte{}), preferred: round}
+		v.signature = edwards25519.Sign(keys.PrivateKey(), QueryResponseSignaturePayload(round.Index, round.ID))
+
+		return v
+	}
+
+	collect := func(stakes func() map[AccountID]uint64) *Round {
+		snowball := NewSnowball(WithBeta(0))
+		voteChan := make(chan vote, sys.SnowballK)
+
+		wg := new(sync.WaitGroup)
+		wg.Add(1)
+
+		go CollectVotes(snowball, voteChan, wg, stakes, nil)
+
+		voteChan <- signed(a, &preferred)
+		voteChan <- signed(b, &conflicting)
+
+		close(voteChan)
+		wg.Wait()
+
+		return snowball.Preferred()
+	}
+
+	// Votes are weighted equally without stakes, such that neither round has a majority.
+
+	assert.Nil(t, collect(nil))
+
+	// A voter with an overwhelming stake carries the majority.
+
+	stakes := map[AccountID]uint64{a.PublicKey(): 1e15}
+
+	if majority := collect(func() map[AccountID]uint64 { return stakes }); assert.NotNil(t, majority) {
+		assert.Equal(t, preferred.ID, majority.ID)
+	}
+}
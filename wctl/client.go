@@ -21,6 +21,7 @@ package wctl
 
 import (
 	"encoding/base64"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"github.com/fasthttp/websocket"
@@ -28,6 +29,8 @@ import (
 	"github.com/valyala/fasthttp"
 	"net/http"
 	"net/url"
+	"strconv"
+	"sync"
 	"time"
 )
 
@@ -43,6 +46,13 @@ type Client struct {
 
 	stdClient *http.Client
 
+	nonce       uint64
+	nonceLoaded bool
+	nonceLock   sync.Mutex
+
+	// sent holds the IDs of transactions sent by this client whose receipts are yet to be seen.
+	sent map[string]struct{}
+
 	edwards25519.PrivateKey
 	edwards25519.PublicKey
 }
@@ -230,6 +240,46 @@ func (c *Client) PollContracts(stop <-chan struct{}, contractID *string) (<-chan
 	return evChan, nil
 }
 
+func (c *Client) PollEvents(stop <-chan struct{}, contractID *string, topic *string) (<-chan []byte, error) {
+	v := url.Values{}
+	if contractID != nil {
+		v.Set("contract", *contractID)
+	}
+	if topic != nil {
+		v.Set("topic", *topic)
+	}
+
+	if stop == nil {
+		stop = make(chan struct{})
+	}
+
+	ws, err := c.EstablishWS(RouteWSEvents, v)
+	if err != nil {
+		return nil, err
+	}
+
+	evChan := make(chan []byte)
+
+	go func() {
+		defer close(evChan)
+
+		for {
+			_, message, err := ws.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			select {
+			case <-stop:
+				return
+			case evChan <- message:
+			}
+		}
+	}()
+
+	return evChan, nil
+}
+
 func (c *Client) PollTransactions(stop <-chan struct{}, txID *string, senderID *string, creatorID *string, tag *byte) (<-chan []byte, error) {
 	v := url.Values{}
 	if txID != nil {
@@ -304,6 +354,38 @@ func (c *Client) GetAccount(accountID string) (Account, error) {
 	return res, err
 }
 
+// GetAccountProof returns the balance of an account alongside a Merkle proof that the balance is
+// held within the accounts tree at the end of a round. The proof is for the latest round should
+// round be nil.
+func (c *Client) GetAccountProof(accountID string, round *uint64) (AccountProof, error) {
+	path := fmt.Sprintf("%s/%s/proof", RouteAccount, accountID)
+	if round != nil {
+		path = fmt.Sprintf("%s?round=%d", path, *round)
+	}
+
+	var res AccountProof
+	err := c.RequestJSON(path, ReqGet, nil, &res)
+	return res, err
+}
+
+// GetDelegations returns all stake an account has delegated to validators.
+func (c *Client) GetDelegations(accountID string) (DelegationList, error) {
+	path := fmt.Sprintf("%s/%s/delegations", RouteAccount, accountID)
+
+	var res DelegationList
+	err := c.RequestJSON(path, ReqGet, nil, &res)
+	return res, err
+}
+
+// GetDelegators returns all stake delegated to a validator.
+func (c *Client) GetDelegators(accountID string) (DelegationList, error) {
+	path := fmt.Sprintf("%s/%s/delegators", RouteAccount, accountID)
+
+	var res DelegationList
+	err := c.RequestJSON(path, ReqGet, nil, &res)
+	return res, err
+}
+
 func (c *Client) GetContractCode(contractID string) (string, error) {
 	path := fmt.Sprintf("%s/%s", RouteContract, contractID)
 
@@ -311,6 +393,14 @@ func (c *Client) GetContractCode(contractID string) (string, error) {
 	return string(res), err
 }
 
+// GetContractABI returns the JSON-encoded ABI a smart contract shipped within its code.
+func (c *Client) GetContractABI(contractID string) (string, error) {
+	path := fmt.Sprintf("%s/%s/abi", RouteContract, contractID)
+
+	res, err := c.Request(path, ReqGet, nil)
+	return string(res), err
+}
+
 func (c *Client) GetContractPages(contractID string, index *uint64) (string, error) {
 	path := fmt.Sprintf("%s/%s/page", RouteContract, contractID)
 	if index != nil {
@@ -321,6 +411,36 @@ func (c *Client) GetContractPages(contractID string, index *uint64) (string, err
 	return base64.StdEncoding.EncodeToString(res), err
 }
 
+// QueryContract invokes a smart contract function without submitting a transaction, such that
+// none of the changes made by the function are committed and no gas is paid. The hex-encoded
+// result of the function, alongside the gas it would have used, is returned.
+func (c *Client) QueryContract(contractID string, funcName string, params []byte, amount uint64) (QueryContractResponse, error) {
+	return c.queryContract(contractID, funcName, params, amount, false)
+}
+
+// QueryContractWithTrace queries a smart contract function like QueryContract, and additionally
+// returns a trace of every host function the smart contract called, every time it grew its memory,
+// and the reason it exited.
+func (c *Client) QueryContractWithTrace(contractID string, funcName string, params []byte, amount uint64) (QueryContractResponse, error) {
+	return c.queryContract(contractID, funcName, params, amount, true)
+}
+
+func (c *Client) queryContract(contractID string, funcName string, params []byte, amount uint64, trace bool) (QueryContractResponse, error) {
+	path := fmt.Sprintf("%s/%s/query", RouteContract, contractID)
+
+	req := QueryContractRequest{
+		Sender:   hex.EncodeToString(c.PublicKey[:]),
+		FuncName: funcName,
+		Params:   hex.EncodeToString(params),
+		Amount:   amount,
+		Trace:    trace,
+	}
+
+	var res QueryContractResponse
+	err := c.RequestJSON(path, ReqPost, &req, &res)
+	return res, err
+}
+
 func (c *Client) ListTransactions(senderID *string, creatorID *string, offset *uint64, limit *uint64) ([]Transaction, error) {
 	path := fmt.Sprintf("%s?", RouteTxList)
 	if senderID != nil {
@@ -343,6 +463,29 @@ func (c *Client) ListTransactions(senderID *string, creatorID *string, offset *u
 
 }
 
+func (c *Client) ListEvents(contractID *string, topic *string, from *uint64, to *uint64) ([]Event, error) {
+	v := url.Values{}
+	if contractID != nil {
+		v.Set("contract", *contractID)
+	}
+	if topic != nil {
+		v.Set("topic", *topic)
+	}
+	if from != nil {
+		v.Set("from", strconv.FormatUint(*from, 10))
+	}
+	if to != nil {
+		v.Set("to", strconv.FormatUint(*to, 10))
+	}
+
+	path := fmt.Sprintf("%s?%s", RouteEvents, v.Encode())
+
+	var res EventList
+
+	err := c.RequestJSON(path, ReqGet, nil, &res)
+	return res, err
+}
+
 func (c *Client) GetTransaction(txID string) (Transaction, error) {
 	path := fmt.Sprintf("%s/%s", RouteTxList, txID)
 
@@ -351,21 +494,137 @@ func (c *Client) GetTransaction(txID string) (Transaction, error) {
 	return res, err
 }
 
+// GetReceipt returns the receipt of a transaction that has been finalized in a consensus round,
+// describing whether or not it was applied, the fee and gas it expended, and the events it emitted.
+func (c *Client) GetReceipt(txID string) (Receipt, error) {
+	path := fmt.Sprintf("%s/%s/receipt", RouteTxList, txID)
+
+	var res Receipt
+	if err := c.RequestJSON(path, ReqGet, nil, &res); err != nil {
+		return res, err
+	}
+
+	c.nonceLock.Lock()
+	if _, sent := c.sent[txID]; sent {
+		delete(c.sent, txID)
+
+		// A rejected transaction never consumed its nonce, and so every transaction sent after
+		// it would otherwise be rejected as well.
+		if res.Status == "rejected" {
+			c.nonceLoaded = false
+		}
+	}
+	c.nonceLock.Unlock()
+
+	return res, nil
+}
+
+// NextNonce returns the nonce to sign the next transaction created by this client with.
+// The nonce is loaded from the clients account the first time it is requested, and is
+// tracked locally afterwards such that several transactions may be sent within a single
+// consensus round. The nonce is loaded from the clients account again should a transaction
+// fail to be sent, or be reported by GetReceipt to have been rejected.
+func (c *Client) NextNonce() (uint64, error) {
+	return c.loadNonce(true)
+}
+
+// loadNonce returns the nonce the next transaction created by this client is to be signed with,
+// only reserving it for that transaction should advance be set.
+func (c *Client) loadNonce(advance bool) (uint64, error) {
+	c.nonceLock.Lock()
+	defer c.nonceLock.Unlock()
+
+	if !c.nonceLoaded {
+		account, err := c.GetAccount(hex.EncodeToString(c.PublicKey[:]))
+		if err != nil {
+			return 0, err
+		}
+
+		c.nonce = account.Nonce
+		c.nonceLoaded = true
+	}
+
+	nonce := c.nonce
+
+	if advance {
+		c.nonce++
+	}
+
+	return nonce, nil
+}
+
 func (c *Client) SendTransaction(tag byte, payload []byte) (SendTransactionResponse, error) {
+	return c.SendTransactionWithTip(tag, 0, payload)
+}
+
+// SendTransactionWithTip sends a transaction whose creator pays an additional priority tip
+// on top of its transaction fee, such that it is preferred by nodes over transactions that
+// pay a smaller tip.
+func (c *Client) SendTransactionWithTip(tag byte, tip uint64, payload []byte) (SendTransactionResponse, error) {
 	var res SendTransactionResponse
 
-	var nonce [8]byte // TODO(kenta): nonce
+	nonce, err := c.NextNonce()
+	if err != nil {
+		return res, err
+	}
 
-	signature := edwards25519.Sign(c.PrivateKey, append(nonce[:], append([]byte{tag}, payload...)...))
+	req, err := c.signTransaction(nonce, tag, tip, payload)
+	if err == nil {
+		err = c.RequestJSON(RouteTxSend, ReqPost, &req, &res)
+	}
 
-	req := SendTransactionRequest{
-		Sender:    hex.EncodeToString(c.PublicKey[:]),
-		Tag:       tag,
-		Payload:   hex.EncodeToString(payload),
-		Signature: hex.EncodeToString(signature[:]),
+	c.nonceLock.Lock()
+	defer c.nonceLock.Unlock()
+
+	if err != nil {
+		c.nonceLoaded = false
+		return res, err
 	}
 
-	err := c.RequestJSON(RouteTxSend, ReqPost, &req, &res)
+	if c.sent == nil {
+		c.sent = make(map[string]struct{})
+	}
+
+	c.sent[res.ID] = struct{}{}
+
+	return res, nil
+}
+
+// SimulateTransaction signs a transaction the same way SendTransactionWithTip does, and has the node
+// report whether or not it would be applied on top of its latest round, alongside the fees, gas, and
+// balance changes it would incur. The transaction is never sent out to the network, and so the nonce
+// it is signed with is not consumed by the client.
+func (c *Client) SimulateTransaction(tag byte, tip uint64, payload []byte) (SimulateTransactionResponse, error) {
+	var res SimulateTransactionResponse
+
+	nonce, err := c.loadNonce(false)
+	if err != nil {
+		return res, err
+	}
+
+	req, err := c.signTransaction(nonce, tag, tip, payload)
+	if err != nil {
+		return res, err
+	}
+
+	err = c.RequestJSON(RouteTxSim, ReqPost, &req, &res)
 
 	return res, err
 }
+
+func (c *Client) signTransaction(nonce uint64, tag byte, tip uint64, payload []byte) (SendTransactionRequest, error) {
+	var buf [16]byte
+	binary.BigEndian.PutUint64(buf[:8], nonce)
+	binary.BigEndian.PutUint64(buf[8:], tip)
+
+	signature := edwards25519.Sign(c.PrivateKey, append(buf[:], append([]byte{tag}, payload...)...))
+
+	return SendTransactionRequest{
+		Sender:    hex.EncodeToString(c.PublicKey[:]),
+		Nonce:     nonce,
+		Tip:       tip,
+		Tag:       tag,
+		Payload:   hex.EncodeToString(payload),
+		Signature: hex.EncodeToString(signature[:]),
+	}, nil
+}
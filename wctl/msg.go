@@ -20,7 +20,12 @@
 package wctl
 
 import (
+	"bytes"
+	"encoding/hex"
+	"github.com/perlin-network/wavelet/avl"
+	"github.com/pkg/errors"
 	"github.com/valyala/fastjson"
+	"strconv"
 )
 
 const (
@@ -29,6 +34,8 @@ const (
 	RouteContract = "/contract"
 	RouteTxList   = "/tx"
 	RouteTxSend   = "/tx/send"
+	RouteTxSim    = "/tx/simulate"
+	RouteEvents   = "/events"
 
 	RouteWSBroadcaster  = "/poll/broadcaster"
 	RouteWSConsensus    = "/poll/consensus"
@@ -37,6 +44,7 @@ const (
 	RouteWSContracts    = "/poll/contract"
 	RouteWSTransactions = "/poll/tx"
 	RouteWSMetrics      = "/poll/metrics"
+	RouteWSEvents       = "/poll/events"
 
 	ReqPost = "POST"
 	ReqGet  = "GET"
@@ -48,6 +56,7 @@ var (
 	_ UnmarshalableJSON = (*Transaction)(nil)
 	_ UnmarshalableJSON = (*TransactionList)(nil)
 	_ UnmarshalableJSON = (*Account)(nil)
+	_ UnmarshalableJSON = (*EventList)(nil)
 
 	_ MarshalableJSON = (*SendTransactionRequest)(nil)
 )
@@ -62,6 +71,8 @@ type MarshalableJSON interface {
 
 type SendTransactionRequest struct {
 	Sender    string `json:"sender"`
+	Nonce     uint64 `json:"nonce"`
+	Tip       uint64 `json:"tip"`
 	Tag       byte   `json:"tag"`
 	Payload   string `json:"payload"`
 	Signature string `json:"signature"`
@@ -72,6 +83,8 @@ func (s *SendTransactionRequest) MarshalJSON() ([]byte, error) {
 	o := arena.NewObject()
 
 	o.Set("sender", arena.NewString(s.Sender))
+	o.Set("nonce", arena.NewNumberString(strconv.FormatUint(s.Nonce, 10)))
+	o.Set("tip", arena.NewNumberString(strconv.FormatUint(s.Tip, 10)))
 	o.Set("tag", arena.NewNumberInt(int(s.Tag)))
 	o.Set("payload", arena.NewString(s.Payload))
 	o.Set("signature", arena.NewString(s.Signature))
@@ -79,6 +92,96 @@ func (s *SendTransactionRequest) MarshalJSON() ([]byte, error) {
 	return o.MarshalTo(nil), nil
 }
 
+type QueryContractRequest struct {
+	Sender   string `json:"sender"`
+	FuncName string `json:"func_name"`
+	Params   string `json:"func_params"`
+	Amount   uint64 `json:"amount"`
+	GasLimit uint64 `json:"gas_limit,omitempty"`
+	Trace    bool   `json:"trace,omitempty"`
+}
+
+func (q *QueryContractRequest) MarshalJSON() ([]byte, error) {
+	var arena fastjson.Arena
+	o := arena.NewObject()
+
+	o.Set("sender", arena.NewString(q.Sender))
+	o.Set("func_name", arena.NewString(q.FuncName))
+	o.Set("func_params", arena.NewString(q.Params))
+	o.Set("amount", arena.NewNumberString(strconv.FormatUint(q.Amount, 10)))
+
+	if q.GasLimit != 0 {
+		o.Set("gas_limit", arena.NewNumberString(strconv.FormatUint(q.GasLimit, 10)))
+	}
+
+	if q.Trace {
+		o.Set("trace", arena.NewTrue())
+	}
+
+	return o.MarshalTo(nil), nil
+}
+
+type QueryContractResponse struct {
+	Result           string   `json:"result"`
+	GasUsed          uint64   `json:"gas_used"`
+	GasLimitExceeded bool     `json:"gas_limit_exceeded"`
+	Logs             []string `json:"logs"`
+
+	Trace []ContractTraceEntry `json:"trace,omitempty"`
+}
+
+type ContractTraceEntry struct {
+	Kind       string  `json:"kind"`
+	ContractID string  `json:"contract_id"`
+	Depth      int     `json:"depth"`
+	Gas        uint64  `json:"gas"`
+	Name       string  `json:"name,omitempty"`
+	Args       []int64 `json:"args,omitempty"`
+	Result     int64   `json:"result,omitempty"`
+	MemorySize int     `json:"memory_size,omitempty"`
+	Reason     string  `json:"reason,omitempty"`
+}
+
+func (q *QueryContractResponse) UnmarshalJSON(b []byte) error {
+	var parser fastjson.Parser
+
+	v, err := parser.ParseBytes(b)
+	if err != nil {
+		return err
+	}
+
+	q.Result = string(v.GetStringBytes("result"))
+	q.GasUsed = v.GetUint64("gas_used")
+	q.GasLimitExceeded = v.GetBool("gas_limit_exceeded")
+
+	q.Logs = q.Logs[:0]
+	for _, line := range v.GetArray("logs") {
+		q.Logs = append(q.Logs, string(line.GetStringBytes()))
+	}
+
+	q.Trace = q.Trace[:0]
+	for _, e := range v.GetArray("trace") {
+		entry := ContractTraceEntry{
+			Kind:       string(e.GetStringBytes("kind")),
+			ContractID: string(e.GetStringBytes("contract_id")),
+			Depth:      e.GetInt("depth"),
+			Gas:        e.GetUint64("gas"),
+			Name:       string(e.GetStringBytes("name")),
+			Result:     e.GetInt64("result"),
+			MemorySize: e.GetInt("memory_size"),
+			Reason:     string(e.GetStringBytes("reason")),
+		}
+
+		for _, arg := range e.GetArray("args") {
+			entry.Args = append(entry.Args, arg.GetInt64())
+		}
+
+		q.Trace = append(q.Trace, entry)
+	}
+
+	return nil
+}
+
 type SendTransactionResponse struct {
 	ID       string   `json:"tx_id"`
 	Parents  []string `json:"parent_ids"`
@@ -105,6 +208,47 @@ func (s *SendTransactionResponse) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+type BalanceDelta struct {
+	AccountID string `json:"account_id"`
+	Before    uint64 `json:"before"`
+	After     uint64 `json:"after"`
+}
+
+type SimulateTransactionResponse struct {
+	ID      string         `json:"tx_id"`
+	Applied bool           `json:"applied"`
+	Error   string         `json:"error"`
+	Fee     uint64         `json:"fee"`
+	GasUsed uint64         `json:"gas_used"`
+	Deltas  []BalanceDelta `json:"balance_deltas"`
+}
+
+func (s *SimulateTransactionResponse) UnmarshalJSON(b []byte) error {
+	var parser fastjson.Parser
+
+	v, err := parser.ParseBytes(b)
+	if err != nil {
+		return err
+	}
+
+	s.ID = string(v.GetStringBytes("tx_id"))
+	s.Applied = v.GetBool("applied")
+	s.Error = string(v.GetStringBytes("error"))
+	s.Fee = v.GetUint64("fee")
+	s.GasUsed = v.GetUint64("gas_used")
+
+	s.Deltas = s.Deltas[:0]
+	for _, delta := range v.GetArray("balance_deltas") {
+		s.Deltas = append(s.Deltas, BalanceDelta{
+			AccountID: string(delta.GetStringBytes("account_id")),
+			Before:    delta.GetUint64("before"),
+			After:     delta.GetUint64("after"),
+		})
+	}
+
+	return nil
+}
+
 type LedgerStatusResponse struct {
 	PublicKey     string   `json:"public_key"`
 	HostAddress   string   `json:"address"`
@@ -148,6 +292,9 @@ type Transaction struct {
 
 	Timestamp uint64 `json:"timestamp"`
 
+	Nonce uint64 `json:"nonce"`
+	Tip   uint64 `json:"tip"`
+
 	Tag     byte   `json:"tag"`
 	Payload []byte `json:"payload"`
 
@@ -183,6 +330,8 @@ func (t *Transaction) ParseJSON(v *fastjson.Value) {
 	}
 
 	t.Timestamp = v.GetUint64("timestamp")
+	t.Nonce = v.GetUint64("nonce")
+	t.Tip = v.GetUint64("tip")
 	t.Tag = byte(v.GetUint("tag"))
 	t.Payload = v.GetStringBytes("payload")
 	t.AccountsMerkleRoot = string(v.GetStringBytes("accounts_root"))
@@ -221,12 +370,155 @@ func (t *TransactionList) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+type Event struct {
+	Round      uint64 `json:"round"`
+	ContractID string `json:"contract_id"`
+	TxID       string `json:"tx_id"`
+	Topic      string `json:"topic"`
+	Data       string `json:"data"`
+}
+
+type EventList []Event
+
+func (e *EventList) UnmarshalJSON(b []byte) error {
+	var parser fastjson.Parser
+
+	v, err := parser.ParseBytes(b)
+	if err != nil {
+		return err
+	}
+
+	a, err := v.Array()
+	if err != nil {
+		return err
+	}
+
+	var list []Event
+
+	for i := range a {
+		list = append(list, Event{
+			Round:      a[i].GetUint64("round"),
+			ContractID: string(a[i].GetStringBytes("contract_id")),
+			TxID:       string(a[i].GetStringBytes("tx_id")),
+			Topic:      string(a[i].GetStringBytes("topic")),
+			Data:       string(a[i].GetStringBytes("data")),
+		})
+	}
+
+	*e = list
+
+	return nil
+}
+
+type AccountProof struct {
+	Round      uint64 `json:"round"`
+	MerkleRoot string `json:"merkle_root"`
+	Key        string `json:"key"`
+	Value      string `json:"value"`
+	Balance    uint64 `json:"balance"`
+	Proof      string `json:"proof"`
+}
+
+func (p *AccountProof) UnmarshalJSON(b []byte) error {
+	var parser fastjson.Parser
+
+	v, err := parser.ParseBytes(b)
+	if err != nil {
+		return err
+	}
+
+	p.Round = v.GetUint64("round")
+	p.MerkleRoot = string(v.GetStringBytes("merkle_root"))
+	p.Key = string(v.GetStringBytes("key"))
+	p.Value = string(v.GetStringBytes("value"))
+	p.Balance = v.GetUint64("balance")
+	p.Proof = string(v.GetStringBytes("proof"))
+
+	return nil
+}
+
+// Verify checks the proof of an accounts balance against the Merkle root of the accounts tree
+// at the end of a round, which should be obtained from a source other than the node that served
+// the proof.
+func (p AccountProof) Verify(merkleRoot [avl.MerkleHashSize]byte) (bool, error) {
+	key, err := hex.DecodeString(p.Key)
+	if err != nil {
+		return false, errors.Wrap(err, "key must be hex-encoded")
+	}
+
+	value, err := hex.DecodeString(p.Value)
+	if err != nil {
+		return false, errors.Wrap(err, "value must be hex-encoded")
+	}
+
+	buf, err := hex.DecodeString(p.Proof)
+	if err != nil {
+		return false, errors.Wrap(err, "proof must be hex-encoded")
+	}
+
+	proof, err := avl.UnmarshalProof(bytes.NewReader(buf))
+	if err != nil {
+		return false, err
+	}
+
+	return avl.VerifyProof(merkleRoot, key, value, proof), nil
+}
+
+type Receipt struct {
+	TxID    string  `json:"tx_id"`
+	Round   uint64  `json:"round"`
+	Status  string  `json:"status"`
+	Error   string  `json:"error,omitempty"`
+	Fee     uint64  `json:"fee"`
+	GasUsed uint64  `json:"gas_used"`
+	Events  []Event `json:"events"`
+}
+
+func (r *Receipt) UnmarshalJSON(b []byte) error {
+	var parser fastjson.Parser
+
+	v, err := parser.ParseBytes(b)
+	if err != nil {
+		return err
+	}
+
+	r.TxID = string(v.GetStringBytes("tx_id"))
+	r.Round = v.GetUint64("round")
+	r.Status = string(v.GetStringBytes("status"))
+	r.Error = string(v.GetStringBytes("error"))
+	r.Fee = v.GetUint64("fee")
+	r.GasUsed = v.GetUint64("gas_used")
+
+	r.Events = r.Events[:0]
+
+	for _, e := range v.GetArray("events") {
+		r.Events = append(r.Events, Event{
+			Round:      e.GetUint64("round"),
+			ContractID: string(e.GetStringBytes("contract_id")),
+			TxID:       string(e.GetStringBytes("tx_id")),
+			Topic:      string(e.GetStringBytes("topic")),
+			Data:       string(e.GetStringBytes("data")),
+		})
+	}
+
+	return nil
+}
+
 type Account struct {
 	PublicKey string `json:"public_key"`
 	Balance   uint64 `json:"balance"`
 	Stake     uint64 `json:"stake"`
+	Nonce     uint64 `json:"nonce"`
+
+	DelegatedStake uint64    `json:"delegated_stake"`
+	Commission     uint64    `json:"commission"`
+	Offences       []Offence `json:"offences,omitempty"`
+
+	// Stake withdrawn by the account that is yet to be released into its balance.
+	Unbonding []Unbonding `json:"unbonding"`
 
 	IsContract bool   `json:"is_contract"`
+	Owner      string `json:"owner,omitempty"`
 	NumPages   uint64 `json:"num_mem_pages,omitempty"`
 }
 
@@ -241,8 +533,78 @@ func (a *Account) UnmarshalJSON(b []byte) error {
 	a.PublicKey = string(v.GetStringBytes("public_key"))
 	a.Balance = v.GetUint64("balance")
 	a.Stake = v.GetUint64("stake")
+	a.Nonce = v.GetUint64("nonce")
+	a.DelegatedStake = v.GetUint64("delegated_stake")
+	a.Commission = v.GetUint64("commission")
+
+	a.Offences = a.Offences[:0]
+
+	for _, o := range v.GetArray("offences") {
+		a.Offences = append(a.Offences, Offence{
+			Round:   o.GetUint64("round"),
+			Slashed: o.GetUint64("slashed"),
+		})
+	}
+
+	a.Unbonding = a.Unbonding[:0]
+
+	for _, u := range v.GetArray("unbonding") {
+		a.Unbonding = append(a.Unbonding, Unbonding{
+			Round:  u.GetUint64("round"),
+			Amount: u.GetUint64("amount"),
+		})
+	}
 	a.IsContract = v.GetBool("is_contract")
+	a.Owner = string(v.GetStringBytes("owner"))
 	a.NumPages = v.GetUint64("num_mem_pages")
 
 	return nil
 }
+
+type Delegation struct {
+	Delegator string `json:"delegator"`
+	Validator string `json:"validator"`
+	Amount    uint64 `json:"amount"`
+}
+
+type DelegationList []Delegation
+
+func (d *DelegationList) UnmarshalJSON(b []byte) error {
+	var parser fastjson.Parser
+
+	v, err := parser.ParseBytes(b)
+	if err != nil {
+		return err
+	}
+
+	a, err := v.Array()
+	if err != nil {
+		return err
+	}
+
+	list := make(DelegationList, 0, len(a))
+
+	for i := range a {
+		list = append(list, Delegation{
+			Delegator: string(a[i].GetStringBytes("delegator")),
+			Validator: string(a[i].GetStringBytes("validator")),
+			Amount:    a[i].GetUint64("amount"),
+		})
+	}
+
+	*d = list
+
+	return nil
+}
+
+// Offence denotes stake slashed from a validator for equivocating at a round.
+type Offence struct {
+	Round   uint64 `json:"round"`
+	Slashed uint64 `json:"slashed"`
+}
+
+// Unbonding denotes an amount of stake withdrawn at a round that is yet to be released.
+type Unbonding struct {
+	Round  uint64 `json:"round"`
+	Amount uint64 `json:"amount"`
+}